@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/config"
+	"os"
+)
+
+func configValidate(args []string) int {
+	flags, common := newFlagSet("config validate")
+	log, ok := parseArgs(flags, common, args)
+	if !ok {
+		return 1
+	}
+
+	loadedConfig, errs := config.LoadYamlFile(*common.configPath, log)
+	if len(errs) > 0 {
+		printConfigErrors(errs)
+		return 1
+	}
+
+	// The properties can only be checked against the actual input files
+	inputs, _, closeInputs, err := newInputs(loadedConfig, loadedConfig.Inputs)
+	if err != nil {
+		printConfigErrors([]error{err})
+		return 1
+	}
+	defer closeInputs(log)
+
+	if errs := loadedConfig.CheckPropertyReferences(inputs); len(errs) > 0 {
+		printConfigErrors(errs)
+		return 1
+	}
+
+	fmt.Fprintf(os.Stderr, "The configuration file %v is valid.\n", *common.configPath)
+	return 0
+}
+
+func printConfigErrors(errs []error) {
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	fmt.Fprintf(os.Stderr, "%v error(s) found.\n", len(errs))
+}
+
+func configSchema(args []string) int {
+	flags, common := newFlagSet("config schema")
+	if _, ok := parseArgs(flags, common, args); !ok {
+		return 1
+	}
+
+	schema, err := json.MarshalIndent(config.GenerateJsonSchema(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Println(string(schema))
+	return 0
+}
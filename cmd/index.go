@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	configPackage "github.com/rodb-io/rodb/pkg/config"
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"sort"
+)
+
+func indexBuild(args []string) int {
+	flags, common := newFlagSet("index build")
+	indexNames := flags.StringSlice("index", []string{}, "Name of the index to build (can be repeated).\nAll the persisted indexes are built by default")
+	config, log, ok := parseFlags(flags, common, args)
+	if !ok {
+		return 1
+	}
+
+	indexConfigs, err := getPersistentIndexConfigs(config, *indexNames)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+
+	inputs, _, closeInputs, err := newInputs(config, getIndexesInputConfigs(config, indexConfigs))
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+	defer closeInputs(log)
+
+	outdatedIndexConfigs := make(map[string]index.Config)
+	for _, indexName := range getSortedIndexNames(indexConfigs) {
+		indexLog := log.WithField("object", "indexes."+indexName)
+		err := index.Verify(indexConfigs[indexName], inputs)
+		if err == nil {
+			indexLog.Infof("The index is already up to date")
+			continue
+		}
+		indexLog.Infof("The index will be built: %v", err)
+
+		if err := index.Reset(indexConfigs[indexName]); err != nil {
+			indexLog.Errorf("Error removing the outdated index: %v", err)
+			return 1
+		}
+		outdatedIndexConfigs[indexName] = indexConfigs[indexName]
+	}
+
+	indexes, err := index.NewFromConfigs(outdatedIndexConfigs, inputs)
+	if err != nil {
+		log.Errorf("Error building indexes: %v", err)
+		return 1
+	}
+	if err := index.Close(indexes); err != nil {
+		log.Errorf("Error closing indexes: %v", err)
+		return 1
+	}
+
+	log.Infof("Successfully built %v index(es)", len(indexes))
+
+	return 0
+}
+
+func indexVerify(args []string) int {
+	flags, common := newFlagSet("index verify")
+	indexNames := flags.StringSlice("index", []string{}, "Name of the index to verify (can be repeated).\nAll the persisted indexes are verified by default")
+	config, log, ok := parseFlags(flags, common, args)
+	if !ok {
+		return 1
+	}
+
+	indexConfigs, err := getPersistentIndexConfigs(config, *indexNames)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+
+	inputs, _, closeInputs, err := newInputs(config, getIndexesInputConfigs(config, indexConfigs))
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+	defer closeInputs(log)
+
+	invalidCount := 0
+	for _, indexName := range getSortedIndexNames(indexConfigs) {
+		indexLog := log.WithField("object", "indexes."+indexName)
+		if err := index.Verify(indexConfigs[indexName], inputs); err != nil {
+			indexLog.Errorf("The index is not valid: %v", err)
+			invalidCount++
+		} else {
+			indexLog.Infof("The index is valid")
+		}
+	}
+
+	if invalidCount > 0 {
+		log.Errorf("%v index(es) must be built", invalidCount)
+		return 1
+	}
+
+	return 0
+}
+
+// Returns the configuration of the given indexes, or
+// of all the persisted indexes if no name is given
+func getPersistentIndexConfigs(
+	config *configPackage.Config,
+	indexNames []string,
+) (map[string]index.Config, error) {
+	indexConfigs := make(map[string]index.Config)
+	if len(indexNames) == 0 {
+		for indexName, indexConfig := range config.Indexes {
+			if index.IsPersistent(indexConfig) {
+				indexConfigs[indexName] = indexConfig
+			}
+		}
+
+		return indexConfigs, nil
+	}
+
+	for _, indexName := range indexNames {
+		indexConfig, indexExists := config.Indexes[indexName]
+		if !indexExists {
+			return nil, fmt.Errorf("The index '%v' does not exist.", indexName)
+		}
+		if !index.IsPersistent(indexConfig) {
+			return nil, fmt.Errorf("The index '%v' is not persisted, and cannot be built in advance.", indexName)
+		}
+		indexConfigs[indexName] = indexConfig
+	}
+
+	return indexConfigs, nil
+}
+
+func getSortedIndexNames(indexConfigs map[string]index.Config) []string {
+	indexNames := make([]string, 0, len(indexConfigs))
+	for indexName := range indexConfigs {
+		indexNames = append(indexNames, indexName)
+	}
+	sort.Strings(indexNames)
+
+	return indexNames
+}
+
+// Returns the configuration of the inputs used by the given indexes
+func getIndexesInputConfigs(
+	config *configPackage.Config,
+	indexConfigs map[string]index.Config,
+) map[string]input.Config {
+	inputConfigs := make(map[string]input.Config)
+	for inputName, inputConfig := range config.Inputs {
+		for _, indexConfig := range indexConfigs {
+			if indexConfig.DoesHandleInput(inputConfig) {
+				inputConfigs[inputName] = inputConfig
+			}
+		}
+	}
+
+	return inputConfigs
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/rodb-io/rodb/pkg/inspect"
+	"io/ioutil"
+	"os"
+)
+
+func initConfig(args []string) int {
+	flags, common := newFlagSet("init")
+	inputPath := flags.String("input", "", "Path to the data file (.csv, .json or .xml) to inspect")
+	listen := flags.String("listen", ":8080", "The address on which the generated http service listens")
+	sampleSize := flags.Int("sample", 1000, "The number of records used to guess the types")
+	log, ok := parseArgs(flags, common, args)
+	if !ok {
+		return 1
+	}
+
+	if *inputPath == "" {
+		log.Error("The --input flag is required.")
+		return 1
+	}
+	if *sampleSize < 1 {
+		log.Error("The --sample flag must be at least 1.")
+		return 1
+	}
+	if _, err := os.Stat(*common.configPath); err == nil {
+		log.Errorf("The file %v already exists. Remove it, or use --config to choose another path.", *common.configPath)
+		return 1
+	}
+
+	result, err := inspect.Inspect(*inputPath, *sampleSize)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+	for _, candidate := range result.RecordXPathCandidates {
+		log.Infof("The records may also be matched by the xpath '%v'.", candidate)
+	}
+
+	configData, err := result.NewStarterConfig(*listen)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+
+	if err := ioutil.WriteFile(*common.configPath, configData, 0644); err != nil {
+		log.Errorf("Cannot write the file %v: %v", *common.configPath, err)
+		return 1
+	}
+
+	log.Infof("The configuration file %v has been created.", *common.configPath)
+	return 0
+}
@@ -3,33 +3,154 @@ package main
 import (
 	"fmt"
 	"github.com/rodb-io/rodb/pkg/config"
-	"github.com/rodb-io/rodb/pkg/index"
 	"github.com/rodb-io/rodb/pkg/input"
-	"github.com/rodb-io/rodb/pkg/output"
 	"github.com/rodb-io/rodb/pkg/parser"
-	"github.com/rodb-io/rodb/pkg/service"
 	"github.com/sirupsen/logrus"
 	flag "github.com/spf13/pflag"
 	"os"
-	"os/signal"
+	"strings"
 )
 
+type command struct {
+	description string
+	run         func(args []string) int
+}
+
+var commands = map[string]command{
+	"serve": {
+		description: "Starts the services (default)",
+		run:         serve,
+	},
+	"index build": {
+		description: "Creates or refreshes the persisted indexes, then exits",
+		run:         indexBuild,
+	},
+	"index verify": {
+		description: "Checks that the persisted indexes are complete and up to date",
+		run:         indexVerify,
+	},
+	"query": {
+		description: "Prints the response of an output, then exits",
+		run:         query,
+	},
+	"config validate": {
+		description: "Checks the configuration file and reports all it's errors",
+		run:         configValidate,
+	},
+	"init": {
+		description: "Creates a configuration file by inspecting a data file",
+		run:         initConfig,
+	},
+	"config schema": {
+		description: "Prints the JSON Schema of the configuration file",
+		run:         configSchema,
+	},
+}
+
 func main() {
-	logLevelString := flag.StringP(
-		"loglevel",
-		"l",
-		logrus.InfoLevel.String(),
-		"Changes the logging level.\nSupported values: panic, fatal, error, warn[ing], info, debug, trace",
-	)
-	configPath := flag.StringP("config", "c", "rodb.yaml", "Path to the configuration file")
-	flag.Parse()
-
-	logLevel, err := logrus.ParseLevel(*logLevelString)
+	args := os.Args[1:]
+
+	// Without a command, the services are started
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		os.Exit(serve(args))
+		return
+	}
+
+	for name, command := range commands {
+		words := strings.Split(name, " ")
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == name {
+			os.Exit(command.run(args[len(words):]))
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown command '%v'\n", strings.Join(args, " "))
+	printCommands()
+	os.Exit(1)
+}
+
+func printCommands() {
+	fmt.Fprintln(os.Stderr, "Available commands:")
+	for _, name := range []string{
+		"serve",
+		"index build",
+		"index verify",
+		"query",
+		"config validate",
+		"config schema",
+		"init",
+	} {
+		fmt.Fprintf(os.Stderr, "  rodb %-16v %v\n", name, commands[name].description)
+	}
+}
+
+// The flags shared by all the commands
+type commonFlags struct {
+	logLevel   *string
+	configPath *string
+}
+
+func newFlagSet(commandName string) (*flag.FlagSet, *commonFlags) {
+	flags := flag.NewFlagSet(commandName, flag.ContinueOnError)
+	return flags, &commonFlags{
+		logLevel: flags.StringP(
+			"loglevel",
+			"l",
+			logrus.InfoLevel.String(),
+			"Changes the logging level.\nSupported values: panic, fatal, error, warn[ing], info, debug, trace",
+		),
+		configPath: flags.StringP("config", "c", "rodb.yaml", "Path to the configuration file, or to a directory containing configuration files"),
+	}
+}
+
+// Parses the arguments, and initializes the logger and configuration.
+// The number of positional arguments must match the given names.
+func parseFlags(
+	flags *flag.FlagSet,
+	common *commonFlags,
+	args []string,
+	argNames ...string,
+) (*config.Config, *logrus.Logger, bool) {
+	log, ok := parseArgs(flags, common, args, argNames...)
+	if !ok {
+		return nil, nil, false
+	}
+
+	config, err := config.NewConfigFromYamlFile(*common.configPath, log)
+	if err != nil {
+		log.Errorf("Error initializing config: %v", err)
+		return nil, nil, false
+	}
+
+	return config, log, true
+}
+
+// Parses the arguments, and initializes the logger
+func parseArgs(
+	flags *flag.FlagSet,
+	common *commonFlags,
+	args []string,
+	argNames ...string,
+) (*logrus.Logger, bool) {
+	if err := flags.Parse(args); err != nil {
+		return nil, false
+	}
+	if flags.NArg() > len(argNames) {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument '%v'\n", flags.Arg(len(argNames)))
+		flags.PrintDefaults()
+		return nil, false
+	}
+	if flags.NArg() < len(argNames) {
+		fmt.Fprintf(os.Stderr, "Error: the argument <%v> is required\n", argNames[flags.NArg()])
+		flags.PrintDefaults()
+		return nil, false
+	}
+
+	logLevel, err := logrus.ParseLevel(*common.logLevel)
 	if err != nil {
 		fmt.Printf("Error: %v", err)
-		flag.PrintDefaults()
-		os.Exit(1)
-		return
+		flags.PrintDefaults()
+		return nil, false
 	}
 
 	log := logrus.New()
@@ -38,81 +159,49 @@ func main() {
 		DisableTimestamp: true,
 	})
 
-	config, err := config.NewConfigFromYamlFile(*configPath, log)
-	if err != nil {
-		log.Errorf("Error initializing config: %v", err)
-		os.Exit(1)
-		return
-	}
+	return log, true
+}
 
+// Creates the parsers and the given inputs, as well as the inputs
+// used by the mapping parsers. The returned function closes them,
+// and logs the errors if any.
+func newInputs(
+	config *config.Config,
+	inputConfigs map[string]input.Config,
+) (input.List, parser.List, func(log *logrus.Logger), error) {
 	parsers, err := parser.NewFromConfigs(config.Parsers)
 	if err != nil {
-		log.Errorf("Error initializing parsers: %v", err)
-		os.Exit(1)
-		return
+		return nil, nil, nil, fmt.Errorf("Error initializing parsers: %w", err)
 	}
-	defer (func() {
-		if err := parser.Close(parsers); err != nil {
-			log.Errorf("Error closing parsers: %v", err)
-		}
-	})()
 
-	inputs, err := input.NewFromConfigs(config.Inputs, parsers)
-	if err != nil {
-		log.Errorf("Error initializing inputs: %v", err)
-		os.Exit(1)
-		return
+	allInputConfigs := make(map[string]input.Config, len(inputConfigs))
+	for inputName, inputConfig := range inputConfigs {
+		allInputConfigs[inputName] = inputConfig
 	}
-	defer (func() {
-		if err := input.Close(inputs); err != nil {
-			log.Errorf("Error closing inputs: %v", err)
+	for _, parserConfig := range config.Parsers {
+		if mappingConfig, isMapping := parserConfig.(*parser.MappingConfig); isMapping && mappingConfig.Input != "" {
+			allInputConfigs[mappingConfig.Input] = config.Inputs[mappingConfig.Input]
 		}
-	})()
-
-	indexes, err := index.NewFromConfigs(config.Indexes, inputs)
-	if err != nil {
-		log.Errorf("Error initializing indexes: %v", err)
-		os.Exit(1)
-		return
 	}
-	defer (func() {
-		if err := index.Close(indexes); err != nil {
-			log.Errorf("Error closing inputs: %v", err)
-		}
-	})()
 
-	outputs, err := output.NewFromConfigs(config.Outputs, inputs, indexes, parsers)
+	inputs, err := input.NewFromConfigs(allInputConfigs, parsers)
 	if err != nil {
-		log.Errorf("Error initializing outputs: %v", err)
-		os.Exit(1)
-		return
+		parser.Close(parsers)
+		return nil, nil, nil, fmt.Errorf("Error initializing inputs: %w", err)
 	}
-	defer (func() {
-		if err := output.Close(outputs); err != nil {
-			log.Errorf("Error closing outputs: %v", err)
-		}
-	})()
 
-	services, err := service.NewFromConfigs(config.Services, outputs, log)
-	if err != nil {
-		log.Errorf("Error initializing services: %v", err)
-		os.Exit(1)
-		return
+	if err := input.LoadParserMappings(parsers, inputs); err != nil {
+		input.Close(inputs)
+		parser.Close(parsers)
+		return nil, nil, nil, fmt.Errorf("Error loading the mapping parsers: %w", err)
 	}
-	go (func() {
-		signals := make(chan os.Signal, 1)
-		signal.Notify(signals, os.Interrupt, os.Kill)
-		signal := <-signals
-		log.Printf("Received signal '%v'. Shutting down...", signal.String())
-
-		if err := service.Close(services); err != nil {
-			log.Errorf("Error closing services: %v", err)
-		}
-	})()
 
-	if err := service.Wait(services); err != nil {
-		log.Error(err)
-		os.Exit(1)
-		return
-	}
+	return inputs, parsers, func(log *logrus.Logger) {
+		if err := input.Close(inputs); err != nil {
+			log.Errorf("Error closing inputs: %v", err)
+		}
+		if err := parser.Close(parsers); err != nil {
+			log.Errorf("Error closing parsers: %v", err)
+		}
+	}, nil
 }
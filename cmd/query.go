@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/output"
+	"io"
+	"os"
+	"strings"
+)
+
+func query(args []string) int {
+	flags, common := newFlagSet("query <outputName>")
+	paramStrings := flags.StringArray("param", []string{}, "A parameter sent to the output, formatted as key=value (can be repeated)")
+	config, log, ok := parseFlags(flags, common, args, "outputName")
+	if !ok {
+		return 1
+	}
+
+	outputName := flags.Arg(0)
+	outputConfig, outputExists := config.Outputs[outputName]
+	if !outputExists {
+		log.Errorf("The output '%v' does not exist.", outputName)
+		return 1
+	}
+
+	params, err := parseQueryParams(*paramStrings)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+
+	// Only the components used by this output are created
+	inputConfigs := make(map[string]input.Config)
+	for _, inputName := range outputConfig.GetInputNames() {
+		inputConfigs[inputName] = config.Inputs[inputName]
+	}
+	indexConfigs := make(map[string]index.Config)
+	for _, indexName := range outputConfig.GetIndexNames() {
+		indexConfigs[indexName] = config.Indexes[indexName]
+	}
+
+	inputs, parsers, closeInputs, err := newInputs(config, inputConfigs)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+	defer closeInputs(log)
+
+	indexes, err := index.NewFromConfigs(indexConfigs, inputs)
+	if err != nil {
+		log.Errorf("Error initializing indexes: %v", err)
+		return 1
+	}
+	defer (func() {
+		if err := index.Close(indexes); err != nil {
+			log.Errorf("Error closing indexes: %v", err)
+		}
+	})()
+
+	queriedOutput, err := output.NewFromConfig(outputConfig, inputs, indexes, parsers)
+	if err != nil {
+		log.Errorf("Error initializing output: %v", err)
+		return 1
+	}
+	defer (func() {
+		if err := queriedOutput.Close(); err != nil {
+			log.Errorf("Error closing output: %v", err)
+		}
+	})()
+
+	var responseError error
+	err = queriedOutput.Handle(
+		params,
+		nil,
+		func(err error) error {
+			responseError = err
+			return nil
+		},
+		func() io.Writer {
+			return os.Stdout
+		},
+	)
+	if err != nil {
+		log.Errorf("Error while handling the query: %v", err)
+		return 1
+	}
+	if responseError != nil {
+		log.Errorf("The query returned an error: %v", responseError)
+		return 1
+	}
+
+	// The responses do not end with a line break
+	fmt.Println()
+
+	return 0
+}
+
+func parseQueryParams(paramStrings []string) (map[string]string, error) {
+	params := make(map[string]string, len(paramStrings))
+	for _, paramString := range paramStrings {
+		parts := strings.SplitN(paramString, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid parameter '%v'. The expected format is key=value.", paramString)
+		}
+		if _, alreadyExists := params[parts[0]]; alreadyExists {
+			return nil, errors.New("The parameter '" + parts[0] + "' is given more than once.")
+		}
+		params[parts[0]] = parts[1]
+	}
+
+	return params, nil
+}
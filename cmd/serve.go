@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/rodb-io/rodb/pkg/service"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func serve(args []string) int {
+	flags, common := newFlagSet("serve")
+	config, log, ok := parseFlags(flags, common, args)
+	if !ok {
+		return 1
+	}
+
+	server, err := newServer(config, *common.configPath, log)
+	if err != nil {
+		log.Error(err)
+		return 1
+	}
+	defer server.close()
+
+	go (func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, os.Kill, syscall.SIGHUP)
+		for signal := range signals {
+			if signal == syscall.SIGHUP {
+				if err := server.reload(); err != nil {
+					log.Errorf("The configuration has not been reloaded: %v", err)
+				}
+				continue
+			}
+
+			log.Printf("Received signal '%v'. Shutting down...", signal.String())
+			if err := service.Close(server.services); err != nil {
+				log.Errorf("Error closing services: %v", err)
+			}
+			return
+		}
+	})()
+
+	if err := service.Wait(server.services); err != nil {
+		log.Error(err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"fmt"
+	configPackage "github.com/rodb-io/rodb/pkg/config"
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/output"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/rodb-io/rodb/pkg/service"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// The running services, and the components they use, which
+// can be replaced by reloading the configuration file
+type server struct {
+	configPath string
+	log        *logrus.Logger
+	reloadLock sync.Mutex
+	config     *configPackage.Config
+	components *components
+	services   service.List
+}
+
+type components struct {
+	parsers parser.List
+	inputs  input.List
+	indexes index.List
+	outputs output.List
+}
+
+func newComponents() *components {
+	return &components{
+		parsers: make(parser.List),
+		inputs:  make(input.List),
+		indexes: make(index.List),
+		outputs: make(output.List),
+	}
+}
+
+func newServer(config *configPackage.Config, configPath string, log *logrus.Logger) (*server, error) {
+	server := &server{
+		configPath: configPath,
+		log:        log,
+		config:     config,
+	}
+
+	components, err := newComponentsFromConfig(config, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	server.components = components
+
+	services, err := service.NewFromConfigs(config.Services, components.outputs, server.reload, log)
+	if err != nil {
+		components.close(log)
+		return nil, fmt.Errorf("Error initializing services: %w", err)
+	}
+	server.services = services
+
+	return server, nil
+}
+
+// Creates the components of the given config. The inputs and indexes
+// of the previous config and components are reused when unchanged.
+func newComponentsFromConfig(
+	config *configPackage.Config,
+	previousConfig *configPackage.Config,
+	previous *components,
+) (*components, error) {
+	next := newComponents()
+	created := newComponents()
+
+	reuseInputs := previous != nil && !hasChangedParsers(previousConfig, config)
+	if reuseInputs {
+		next.parsers = previous.parsers
+	} else {
+		parsers, err := parser.NewFromConfigs(config.Parsers)
+		if err != nil {
+			return nil, fmt.Errorf("Error initializing parsers: %w", err)
+		}
+		next.parsers = parsers
+		created.parsers = parsers
+	}
+
+	for inputName, inputConfig := range config.Inputs {
+		if reuseInputs {
+			if previousInputConfig, exists := previousConfig.Inputs[inputName]; exists && configPackage.IsSameConfig(previousInputConfig, inputConfig) {
+				next.inputs[inputName] = previous.inputs[inputName]
+				continue
+			}
+		}
+
+		createdInput, err := input.NewFromConfig(inputConfig, next.parsers)
+		if err != nil {
+			created.close(nil)
+			return nil, fmt.Errorf("Error initializing inputs: %w", err)
+		}
+		next.inputs[inputName] = createdInput
+		created.inputs[inputName] = createdInput
+	}
+
+	if !reuseInputs {
+		if err := input.LoadParserMappings(next.parsers, next.inputs); err != nil {
+			created.close(nil)
+			return nil, fmt.Errorf("Error loading the mapping parsers: %w", err)
+		}
+	}
+
+	indexConfigs := make(map[string]index.Config)
+	for indexName, indexConfig := range config.Indexes {
+		if previous != nil && isReusableIndex(indexName, previousConfig, config, created.inputs) {
+			next.indexes[indexName] = previous.indexes[indexName]
+			continue
+		}
+
+		if previous != nil {
+			if err := checkIndexRebuild(indexName, previousConfig, config, next.inputs); err != nil {
+				created.close(nil)
+				return nil, err
+			}
+		}
+		indexConfigs[indexName] = indexConfig
+	}
+	indexes, err := index.NewFromConfigs(indexConfigs, next.inputs)
+	if err != nil {
+		created.close(nil)
+		return nil, fmt.Errorf("Error initializing indexes: %w", err)
+	}
+	for indexName, createdIndex := range indexes {
+		next.indexes[indexName] = createdIndex
+		created.indexes[indexName] = createdIndex
+	}
+
+	// The outputs are only referencing the other components,
+	// so they can always be created again
+	outputs, err := output.NewFromConfigs(config.Outputs, next.inputs, next.indexes, next.parsers)
+	if err != nil {
+		created.close(nil)
+		return nil, fmt.Errorf("Error initializing outputs: %w", err)
+	}
+	next.outputs = outputs
+
+	return next, nil
+}
+
+// The inputs are using the parsers, so they can only be reused if all
+// the parsers, and the inputs from which the mappings are loaded, are
+// also unchanged
+func hasChangedParsers(previousConfig *configPackage.Config, config *configPackage.Config) bool {
+	if len(previousConfig.Parsers) != len(config.Parsers) {
+		return true
+	}
+	for parserName, parserConfig := range config.Parsers {
+		previousParserConfig, exists := previousConfig.Parsers[parserName]
+		if !exists || !configPackage.IsSameConfig(previousParserConfig, parserConfig) {
+			return true
+		}
+
+		if mappingConfig, isMapping := parserConfig.(*parser.MappingConfig); isMapping && mappingConfig.Input != "" {
+			inputName := mappingConfig.Input
+			if !configPackage.IsSameConfig(previousConfig.Inputs[inputName], config.Inputs[inputName]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// An index can be reused if it's config is unchanged,
+// and if it does not handle any input that has been replaced
+func isReusableIndex(
+	indexName string,
+	previousConfig *configPackage.Config,
+	config *configPackage.Config,
+	createdInputs input.List,
+) bool {
+	previousIndexConfig, exists := previousConfig.Indexes[indexName]
+	if !exists || !configPackage.IsSameConfig(previousIndexConfig, config.Indexes[indexName]) {
+		return false
+	}
+
+	for inputName := range createdInputs {
+		if config.Indexes[indexName].DoesHandleInput(config.Inputs[inputName]) {
+			return false
+		}
+	}
+	for inputName, previousInputConfig := range previousConfig.Inputs {
+		if _, exists := config.Inputs[inputName]; !exists && previousIndexConfig.DoesHandleInput(previousInputConfig) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// The persisted files of an index can not be
+// rebuilt while the previous index is still using them
+func checkIndexRebuild(
+	indexName string,
+	previousConfig *configPackage.Config,
+	config *configPackage.Config,
+	inputs input.List,
+) error {
+	indexConfig := config.Indexes[indexName]
+	previousIndexConfig, exists := previousConfig.Indexes[indexName]
+	if !exists || !index.IsPersistent(indexConfig) || !index.IsPersistent(previousIndexConfig) {
+		return nil
+	}
+	if index.FilePath(indexConfig) != index.FilePath(previousIndexConfig) {
+		return nil
+	}
+
+	if err := index.Verify(indexConfig, inputs); err != nil {
+		return fmt.Errorf("The index '%v' must be rebuilt in the file '%v', which is still in use. Change it's path, or restart instead: %w", indexName, index.FilePath(indexConfig), err)
+	}
+
+	return nil
+}
+
+// Reloads the configuration file, and replaces the
+// components used by the services without stopping them
+func (server *server) reload() error {
+	server.reloadLock.Lock()
+	defer server.reloadLock.Unlock()
+
+	server.log.Infof("Reloading the configuration...")
+
+	config, err := configPackage.NewConfigFromYamlFile(server.configPath, server.log)
+	if err != nil {
+		return fmt.Errorf("Error initializing config: %w", err)
+	}
+
+	next, err := newComponentsFromConfig(config, server.config, server.components)
+	if err != nil {
+		return err
+	}
+
+	apply, err := service.Reload(server.services, config.Services, next.outputs)
+	if err != nil {
+		next.getUnused(server.components).close(server.log)
+		return fmt.Errorf("Error reloading services: %w", err)
+	}
+
+	// Once the pending requests are finished,
+	// the replaced components can be closed
+	apply()
+	server.components.getUnused(next).close(server.log)
+
+	server.config = config
+	server.components = next
+
+	server.log.Infof("The configuration has been reloaded.")
+
+	return nil
+}
+
+// Returns the components that are not used by the other components
+func (current *components) getUnused(other *components) *components {
+	unused := newComponents()
+	unused.outputs = current.outputs
+	for inputName, currentInput := range current.inputs {
+		if other.inputs[inputName] != currentInput {
+			unused.inputs[inputName] = currentInput
+		}
+	}
+	for indexName, currentIndex := range current.indexes {
+		if other.indexes[indexName] != currentIndex {
+			unused.indexes[indexName] = currentIndex
+		}
+	}
+	for parserName, currentParser := range current.parsers {
+		if other.parsers[parserName] != currentParser {
+			unused.parsers[parserName] = currentParser
+		}
+	}
+
+	return unused
+}
+
+// Closes the components, and logs the errors if a logger is given
+func (components *components) close(log *logrus.Logger) {
+	logError := func(message string, err error) {
+		if err != nil && log != nil {
+			log.Errorf(message, err)
+		}
+	}
+
+	logError("Error closing outputs: %v", output.Close(components.outputs))
+	logError("Error closing indexes: %v", index.Close(components.indexes))
+	logError("Error closing inputs: %v", input.Close(components.inputs))
+	logError("Error closing parsers: %v", parser.Close(components.parsers))
+}
+
+func (server *server) close() {
+	server.reloadLock.Lock()
+	defer server.reloadLock.Unlock()
+
+	server.components.close(server.log)
+}
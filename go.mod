@@ -3,12 +3,15 @@ module github.com/rodb-io/rodb
 go 1.16
 
 require (
+	github.com/andybalholm/brotli v1.0.4
 	github.com/antchfx/xmlquery v1.3.6
 	github.com/antchfx/xpath v1.1.11
 	github.com/fsnotify/fsnotify v1.4.9
+	github.com/klauspost/compress v1.13.6
 	github.com/mattn/go-sqlite3 v1.14.8
 	github.com/sirupsen/logrus v1.7.0
 	github.com/spf13/pflag v1.0.5
 	golang.org/x/text v0.3.8
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Checks if two component configs have the same settings.
+// Only the fields coming from the configuration file (having a yaml
+// tag) are compared, which excludes the loggers and computed values.
+func IsSameConfig(a interface{}, b interface{}) bool {
+	return isSameConfigValue(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func isSameConfigValue(a reflect.Value, b reflect.Value) bool {
+	if a.IsValid() != b.IsValid() {
+		return false
+	}
+	if !a.IsValid() {
+		return true
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return isSameConfigValue(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			tag := strings.Split(a.Type().Field(i).Tag.Get("yaml"), ",")[0]
+			if tag == "" || tag == "-" {
+				continue
+			}
+			if !isSameConfigValue(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !isSameConfigValue(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			if !isSameConfigValue(a.MapIndex(key), b.MapIndex(key)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestIsSameConfig(t *testing.T) {
+	newConfig := func(parser string) *input.CsvConfig {
+		return &input.CsvConfig{
+			Name: "test",
+			Path: "test.csv",
+			Columns: []*input.CsvColumnConfig{
+				{Name: "a", Parser: parser},
+			},
+			ColumnIndexByName: map[string]int{"a": 0},
+			Logger:            logrus.NewEntry(logrus.New()),
+		}
+	}
+
+	t.Run("same", func(t *testing.T) {
+		a := newConfig("string")
+		b := newConfig("string")
+		b.ColumnIndexByName = nil
+		if !IsSameConfig(a, b) {
+			t.Fatalf("Expected the configs to be the same")
+		}
+	})
+	t.Run("different", func(t *testing.T) {
+		if IsSameConfig(newConfig("string"), newConfig("integer")) {
+			t.Fatalf("Expected the configs to be different")
+		}
+	})
+	t.Run("different types", func(t *testing.T) {
+		if IsSameConfig(newConfig("string"), &input.JsonConfig{Name: "test"}) {
+			t.Fatalf("Expected the configs to be different")
+		}
+	})
+}
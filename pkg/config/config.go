@@ -9,11 +9,14 @@ import (
 	"github.com/rodb-io/rodb/pkg/service"
 	"github.com/sirupsen/logrus"
 	yaml "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
 	"io/ioutil"
-	"os"
+	"reflect"
+	"sort"
 )
 
 type configParser struct {
+	Include  []string
 	Parsers  []parserParser
 	Inputs   []inputParser
 	Indexes  []indexParser
@@ -21,25 +24,140 @@ type configParser struct {
 	Outputs  []outputParser
 }
 
+// Parses a single file, without loading it's includes
+func parseConfigFile(configPath string, log *logrus.Logger) (*configParser, []error) {
+	configData, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, []error{fmt.Errorf("Cannot read config file %v: %w", configPath, err)}
+	}
+
+	document := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(configData, document); err != nil {
+		return nil, newYamlLocator(configPath, nil, nil).newParsingErrors(err)
+	}
+	if len(document.Content) == 0 {
+		return &configParser{}, nil
+	}
+
+	// The environment variables are only replaced in the values,
+	// so they can not change the structure of the file
+	if errs := expandEnvInYamlNode(document, configPath, log); len(errs) > 0 {
+		return nil, errs
+	}
+
+	expandedData, err := yamlv3.Marshal(document)
+	if err != nil {
+		return nil, []error{fmt.Errorf("Cannot read config file %v: %w", configPath, err)}
+	}
+	locator := newYamlLocator(configPath, document, expandedData)
+
+	parsedConfig := &configParser{}
+	if err := yaml.UnmarshalStrict(expandedData, parsedConfig); err != nil {
+		return nil, locator.newParsingErrors(err)
+	}
+
+	parsedConfig.setLocator(locator)
+
+	return parsedConfig, nil
+}
+
+func (parsedConfig *configParser) setLocator(locator *yamlLocator) {
+	for i := range parsedConfig.Parsers {
+		parsedConfig.Parsers[i].locator = locator
+	}
+	for i := range parsedConfig.Inputs {
+		parsedConfig.Inputs[i].locator = locator
+	}
+	for i := range parsedConfig.Indexes {
+		parsedConfig.Indexes[i].locator = locator
+	}
+	for i := range parsedConfig.Services {
+		parsedConfig.Services[i].locator = locator
+	}
+	for i := range parsedConfig.Outputs {
+		parsedConfig.Outputs[i].locator = locator
+	}
+}
+
+func (parsedConfig *configParser) merge(other *configParser) {
+	parsedConfig.Parsers = append(parsedConfig.Parsers, other.Parsers...)
+	parsedConfig.Inputs = append(parsedConfig.Inputs, other.Inputs...)
+	parsedConfig.Indexes = append(parsedConfig.Indexes, other.Indexes...)
+	parsedConfig.Services = append(parsedConfig.Services, other.Services...)
+	parsedConfig.Outputs = append(parsedConfig.Outputs, other.Outputs...)
+}
+
 type Config struct {
 	Parsers  map[string]parser.Config
 	Inputs   map[string]input.Config
 	Indexes  map[string]index.Config
 	Services map[string]service.Config
 	Outputs  map[string]output.Config
+
+	// The files declaring each component, by "section.name"
+	locators map[string]*yamlLocator
 }
 
 func NewConfigFromYamlFile(configPath string, log *logrus.Logger) (*Config, error) {
-	configData, err := ioutil.ReadFile(configPath)
+	config, errs := LoadYamlFile(configPath, log)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return config, nil
+}
+
+// Parses and validates the configuration file, or all the configuration
+// files of a directory, including the files they include. Rather than
+// stopping at the first error, it returns all the errors that can be found.
+func LoadYamlFile(configPath string, log *logrus.Logger) (*Config, []error) {
+	parsedConfig, errs := loadConfigFiles(configPath, log)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	config, err := NewConfigFromParsedConfig(parsedConfig)
 	if err != nil {
-		return nil, fmt.Errorf("Cannot read config file %v: %w", configPath, err)
+		return nil, []error{err}
+	}
+
+	config.addDefaultConfigs(log)
+
+	if errs := config.validateAll(log); len(errs) > 0 {
+		return nil, errs
 	}
 
-	yamlConfigWithEnv := []byte(os.ExpandEnv(string(configData)))
+	return config, nil
+}
 
+// The components of a configuration declared in Go code
+type Components struct {
+	Parsers  []parser.Config
+	Inputs   []input.Config
+	Indexes  []index.Config
+	Services []service.Config
+	Outputs  []output.Config
+}
+
+// Creates a configuration from components declared in Go code, rather
+// than in a file. Like when loading a file, the default components are
+// added, and each component is validated, which sets it's default values.
+func NewConfig(components Components, log *logrus.Logger) (*Config, error) {
 	parsedConfig := &configParser{}
-	if err := yaml.UnmarshalStrict(yamlConfigWithEnv, parsedConfig); err != nil {
-		return nil, fmt.Errorf("Cannot parse config file %v: %w", configPath, err)
+	for _, parserConfig := range components.Parsers {
+		parsedConfig.Parsers = append(parsedConfig.Parsers, parserParser{parser: parserConfig})
+	}
+	for _, inputConfig := range components.Inputs {
+		parsedConfig.Inputs = append(parsedConfig.Inputs, inputParser{input: inputConfig})
+	}
+	for _, indexConfig := range components.Indexes {
+		parsedConfig.Indexes = append(parsedConfig.Indexes, indexParser{index: indexConfig})
+	}
+	for _, serviceConfig := range components.Services {
+		parsedConfig.Services = append(parsedConfig.Services, serviceParser{service: serviceConfig})
+	}
+	for _, outputConfig := range components.Outputs {
+		parsedConfig.Outputs = append(parsedConfig.Outputs, outputParser{output: outputConfig})
 	}
 
 	config, err := NewConfigFromParsedConfig(parsedConfig)
@@ -63,58 +181,75 @@ func NewConfigFromParsedConfig(parsedConfig *configParser) (*Config, error) {
 		Indexes:  map[string]index.Config{},
 		Services: map[string]service.Config{},
 		Outputs:  map[string]output.Config{},
+		locators: map[string]*yamlLocator{},
 	}
 
 	for _, parser := range parsedConfig.Parsers {
 		name := parser.parser.GetName()
 		if _, exists := config.Parsers[name]; exists {
-			return nil, fmt.Errorf("Duplicate name '%v' for parser.", name)
+			return nil, config.newDuplicateError("parsers", "parser", name, parser.locator)
 		}
 		config.Parsers[name] = parser.parser
+		config.locators["parsers."+name] = parser.locator
 	}
 	for _, input := range parsedConfig.Inputs {
 		name := input.input.GetName()
 		if _, exists := config.Inputs[name]; exists {
-			return nil, fmt.Errorf("Duplicate name '%v' for input.", name)
+			return nil, config.newDuplicateError("inputs", "input", name, input.locator)
 		}
 		config.Inputs[name] = input.input
+		config.locators["inputs."+name] = input.locator
 	}
 	for _, index := range parsedConfig.Indexes {
 		name := index.index.GetName()
 		if _, exists := config.Indexes[name]; exists {
-			return nil, fmt.Errorf("Duplicate name '%v' for index.", name)
+			return nil, config.newDuplicateError("indexes", "index", name, index.locator)
 		}
 		config.Indexes[name] = index.index
+		config.locators["indexes."+name] = index.locator
 	}
 	for _, service := range parsedConfig.Services {
 		name := service.service.GetName()
 		if _, exists := config.Services[name]; exists {
-			return nil, fmt.Errorf("Duplicate name '%v' for service.", name)
+			return nil, config.newDuplicateError("services", "service", name, service.locator)
 		}
 		config.Services[name] = service.service
+		config.locators["services."+name] = service.locator
 	}
 	for _, output := range parsedConfig.Outputs {
 		name := output.output.GetName()
 		if _, exists := config.Outputs[name]; exists {
-			return nil, fmt.Errorf("Duplicate name '%v' for output.", name)
+			return nil, config.newDuplicateError("outputs", "output", name, output.locator)
 		}
 		config.Outputs[name] = output.output
+		config.locators["outputs."+name] = output.locator
 	}
 
 	return config, nil
 }
 
-func (config *Config) addDefaultConfigs(log *logrus.Logger) {
-	defaultIndex := &index.NoopConfig{
-		Name: "default",
+// Locates the error on the file declaring the given component
+func (config *Config) newError(section string, name string, configPath string, err error) error {
+	return config.locators[section+"."+name].newError(section, name, configPath, err)
+}
+
+func (config *Config) newDuplicateError(section string, kind string, name string, duplicateLocator *yamlLocator) error {
+	err := fmt.Errorf("Duplicate name '%v' for %v.", name, kind)
+	if firstLocator := config.locators[section+"."+name]; firstLocator != nil && duplicateLocator != nil {
+		err = fmt.Errorf("Duplicate name '%v' for %v, which is already declared in %v.", name, kind, firstLocator.file)
 	}
-	if _, exists := config.Indexes[defaultIndex.GetName()]; exists {
-		log.Warnf("You have declared an index named 'default', which will replace the internally used one.\n")
-	} else {
-		config.Indexes[defaultIndex.GetName()] = defaultIndex
+
+	if duplicateLocator == nil {
+		return err
 	}
 
-	for _, parserConfig := range []parser.Config{
+	return duplicateLocator.newError(section, name, "", err)
+}
+
+// The parsers that are always available, unless a
+// parser with the same name is declared in the config
+func DefaultParserConfigs() []parser.Config {
+	return []parser.Config{
 		&parser.StringConfig{
 			Name:               "string",
 			ConvertFromCharset: "",
@@ -128,6 +263,15 @@ func (config *Config) addDefaultConfigs(log *logrus.Logger) {
 			DecimalSeparator: ".",
 			IgnoreCharacters: "",
 		},
+		&parser.DecimalConfig{
+			Name:             "decimal",
+			DecimalSeparator: ".",
+			IgnoreCharacters: "",
+		},
+		&parser.GeoPointConfig{
+			Name:      "geopoint",
+			Separator: ",",
+		},
 		&parser.BooleanConfig{
 			Name:        "boolean",
 			TrueValues:  []string{"true", "1", "TRUE"},
@@ -136,7 +280,20 @@ func (config *Config) addDefaultConfigs(log *logrus.Logger) {
 		&parser.JsonConfig{
 			Name: "json",
 		},
-	} {
+	}
+}
+
+func (config *Config) addDefaultConfigs(log *logrus.Logger) {
+	defaultIndex := &index.NoopConfig{
+		Name: "default",
+	}
+	if _, exists := config.Indexes[defaultIndex.GetName()]; exists {
+		log.Warnf("You have declared an index named 'default', which will replace the internally used one.\n")
+	} else {
+		config.Indexes[defaultIndex.GetName()] = defaultIndex
+	}
+
+	for _, parserConfig := range DefaultParserConfigs() {
 		if _, exists := config.Parsers[parserConfig.GetName()]; exists {
 			log.Warnf("You have declared a parser named '%v', which will replace the default one.\n", parserConfig.GetName())
 		} else {
@@ -146,35 +303,111 @@ func (config *Config) addDefaultConfigs(log *logrus.Logger) {
 }
 
 func (config *Config) Validate(log *logrus.Logger) error {
-	for subConfigName, subConfig := range config.Parsers {
+	if errs := config.validateAll(log); len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
+
+// Validates every component, and returns all their errors
+func (config *Config) validateAll(log *logrus.Logger) []error {
+	errs := make([]error, 0)
+	addError := func(section string, name string, err error) {
+		errs = append(errs, config.newError(section, name, getConfigPathFromError(err), err))
+	}
+
+	for _, subConfigName := range getSortedNames(config.Parsers) {
+		subConfig := config.Parsers[subConfigName]
 		if err := subConfig.Validate(config.Parsers, log.WithField("object", "parsers."+subConfigName)); err != nil {
-			return fmt.Errorf("parsers.%v: %w", subConfigName, err)
+			addError("parsers", subConfigName, err)
+			continue
+		}
+
+		// The parsers can not access the inputs when being validated
+		if mappingConfig, isMapping := subConfig.(*parser.MappingConfig); isMapping && mappingConfig.Input != "" {
+			if _, inputExists := config.Inputs[mappingConfig.Input]; !inputExists {
+				addError("parsers", subConfigName, fmt.Errorf("mapping.input: Input '%v' not found in inputs list.", mappingConfig.Input))
+			}
 		}
 	}
 
-	for subConfigName, subConfig := range config.Inputs {
+	for _, subConfigName := range getSortedNames(config.Inputs) {
+		subConfig := config.Inputs[subConfigName]
 		if err := subConfig.Validate(config.Parsers, log.WithField("object", "inputs."+subConfigName)); err != nil {
-			return fmt.Errorf("inputs.%v: %w", subConfigName, err)
+			addError("inputs", subConfigName, err)
 		}
 	}
 
-	for subConfigName, subConfig := range config.Indexes {
+	for _, subConfigName := range getSortedNames(config.Indexes) {
+		subConfig := config.Indexes[subConfigName]
 		if err := subConfig.Validate(config.Inputs, log.WithField("object", "indexes."+subConfigName)); err != nil {
-			return fmt.Errorf("indexes.%v: %w", subConfigName, err)
+			addError("indexes", subConfigName, err)
 		}
 	}
 
-	for subConfigName, subConfig := range config.Services {
+	for _, subConfigName := range getSortedNames(config.Services) {
+		subConfig := config.Services[subConfigName]
 		if err := subConfig.Validate(config.Outputs, log.WithField("object", "services."+subConfigName)); err != nil {
-			return fmt.Errorf("services.%v: %w", subConfigName, err)
+			addError("services", subConfigName, err)
 		}
 	}
 
-	for subConfigName, subConfig := range config.Outputs {
+	for _, subConfigName := range getSortedNames(config.Outputs) {
+		subConfig := config.Outputs[subConfigName]
 		if err := subConfig.Validate(config.Inputs, config.Indexes, config.Parsers, log.WithField("object", "outputs."+subConfigName)); err != nil {
-			return fmt.Errorf("outputs.%v: %w", subConfigName, err)
+			addError("outputs", subConfigName, err)
 		}
 	}
 
-	return nil
+	return errs
+}
+
+// Checks that the properties used by the indexes and outputs exist in their
+// inputs. This can only be done once the inputs have been created.
+// The references to an input that is not in the given list are ignored.
+func (config *Config) CheckPropertyReferences(inputs input.List) []error {
+	errs := make([]error, 0)
+	checkReferences := func(section string, name string, references []input.PropertyReference) {
+		for _, reference := range references {
+			referencedInput, inputExists := inputs[reference.Input]
+			if !inputExists {
+				continue
+			}
+
+			if err := referencedInput.CheckPropertyPath(reference.Property); err != nil {
+				err = fmt.Errorf("%v: Invalid property for the input '%v': %w", reference.ConfigPath, reference.Input, err)
+				errs = append(errs, config.newError(section, name, reference.ConfigPath, err))
+			}
+		}
+	}
+
+	for _, parserName := range getSortedNames(config.Parsers) {
+		if mappingConfig, isMapping := config.Parsers[parserName].(*parser.MappingConfig); isMapping && mappingConfig.Input != "" {
+			checkReferences("parsers", parserName, []input.PropertyReference{
+				{Input: mappingConfig.Input, Property: mappingConfig.KeyProperty, ConfigPath: "keyProperty"},
+				{Input: mappingConfig.Input, Property: mappingConfig.ValueProperty, ConfigPath: "valueProperty"},
+			})
+		}
+	}
+	for _, indexName := range getSortedNames(config.Indexes) {
+		checkReferences("indexes", indexName, config.Indexes[indexName].GetPropertyReferences())
+	}
+	for _, outputName := range getSortedNames(config.Outputs) {
+		checkReferences("outputs", outputName, config.Outputs[outputName].GetPropertyReferences())
+	}
+
+	return errs
+}
+
+// The components are sorted, to always report the errors in the same order
+func getSortedNames(configs interface{}) []string {
+	keys := reflect.ValueOf(configs).MapKeys()
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.String()
+	}
+	sort.Strings(names)
+
+	return names
 }
@@ -0,0 +1,419 @@
+package config
+
+import (
+	"errors"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadYamlFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		return path
+	}
+	csvPath := writeFile("data.csv", "id,name\n1,a\n")
+
+	t.Run("valid", func(t *testing.T) {
+		path := writeFile("valid.yaml", `
+inputs:
+  - name: data
+    type: csv
+    path: `+csvPath+`
+    columns:
+      - name: id
+`)
+		config, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if len(errs) > 0 {
+			t.Fatalf("Unexpected errors: '%+v'", errs)
+		}
+		if _, exists := config.Inputs["data"]; !exists {
+			t.Fatalf("Expected the input 'data' to exist")
+		}
+	})
+	t.Run("all errors", func(t *testing.T) {
+		path := writeFile("invalid.yaml", `
+inputs:
+  - name: data
+    type: csv
+    path: `+csvPath+`
+    columns:
+      - name: id
+indexes:
+  - name: first
+    type: map
+    input: wrong
+    properties:
+      - id
+  - name: second
+    type: map
+    input: data
+    properties:
+      - id
+    path: `+dir+`
+`)
+		_, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if expect, got := 2, len(errs); got != expect {
+			t.Fatalf("Expected '%v' errors, got '%v': '%+v'", expect, got, errs)
+		}
+
+		for i, expect := range []struct {
+			line   int
+			column int
+			path   string
+		}{
+			{11, 5, "indexes.first"},
+			{19, 5, "indexes.second"},
+		} {
+			validationError := &ValidationError{}
+			if !errors.As(errs[i], &validationError) {
+				t.Fatalf("Expected a ValidationError, got '%+v'", errs[i])
+			}
+			if got := validationError.File; got != path {
+				t.Fatalf("Expected '%v', got '%v'", path, got)
+			}
+			if got := validationError.Line; got != expect.line {
+				t.Fatalf("Expected '%v', got '%v'", expect.line, got)
+			}
+			if got := validationError.Column; got != expect.column {
+				t.Fatalf("Expected '%v', got '%v'", expect.column, got)
+			}
+			if got := validationError.Path; got != expect.path {
+				t.Fatalf("Expected '%v', got '%v'", expect.path, got)
+			}
+		}
+	})
+	t.Run("parsing errors", func(t *testing.T) {
+		path := writeFile("unknown.yaml", `
+inputs:
+  - name: data
+    type: csv
+    unknown: true
+`)
+		_, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if expect, got := 1, len(errs); got != expect {
+			t.Fatalf("Expected '%v' errors, got '%v': '%+v'", expect, got, errs)
+		}
+		if expect, got := path+":5:5: field unknown not found in type input.CsvConfig", errs[0].Error(); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
+
+func TestNewConfig(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		inputConfig := &input.CsvConfig{
+			Name:    "input",
+			Path:    "config_test.go",
+			Columns: []*input.CsvColumnConfig{{Name: "a"}},
+		}
+		config, err := NewConfig(Components{
+			Inputs: []input.Config{inputConfig},
+		}, logrus.New())
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if _, exists := config.Parsers["string"]; !exists {
+			t.Fatalf("Expected the default parsers to be added")
+		}
+		if _, exists := config.Indexes["default"]; !exists {
+			t.Fatalf("Expected the default index to be added")
+		}
+		if expect, got := "string", inputConfig.Columns[0].Parser; got != expect {
+			t.Fatalf("Expected the default value '%v', got '%v'", expect, got)
+		}
+		if inputConfig.Logger == nil {
+			t.Fatalf("Expected the logger to be set")
+		}
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := NewConfig(Components{
+			Parsers: []parser.Config{
+				&parser.JsonConfig{Name: "a"},
+				&parser.JsonConfig{Name: "a"},
+			},
+		}, logrus.New())
+		if err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewConfig(Components{
+			Inputs: []input.Config{&input.CsvConfig{Name: "input"}},
+		}, logrus.New())
+		if err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("mapping input", func(t *testing.T) {
+		_, err := NewConfig(Components{
+			Parsers: []parser.Config{
+				&parser.MappingConfig{Name: "a", Input: "wrong", KeyProperty: "k", ValueProperty: "v"},
+			},
+		}, logrus.New())
+		if err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
+
+type registeredParserConfig struct {
+	Name   string `yaml:"name"`
+	Type   string `yaml:"type"`
+	Prefix string `yaml:"prefix"`
+}
+
+func (config *registeredParserConfig) Validate(parsers map[string]parser.Config, log *logrus.Entry) error {
+	return nil
+}
+
+func (config *registeredParserConfig) GetName() string {
+	return config.Name
+}
+
+func (config *registeredParserConfig) Primitive() bool {
+	return true
+}
+
+func TestLoadYamlFileRegisteredType(t *testing.T) {
+	parser.Register("registered", func() parser.Config { return &registeredParserConfig{} }, func(config parser.Config, parsers parser.List) (parser.Parser, error) {
+		return parser.NewMockWithPrefix(config.(*registeredParserConfig).Prefix), nil
+	})
+
+	path := filepath.Join(t.TempDir(), "rodb.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+parsers:
+  - name: custom
+    type: registered
+    prefix: foo
+`), 0644)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	config, errs := LoadYamlFile(path, logrus.StandardLogger())
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: '%+v'", errs)
+	}
+
+	parserConfig, isRegisteredConfig := config.Parsers["custom"].(*registeredParserConfig)
+	if !isRegisteredConfig {
+		t.Fatalf("Expected a '*registeredParserConfig', got '%#v'", config.Parsers["custom"])
+	}
+	if expect, got := "foo", parserConfig.Prefix; got != expect {
+		t.Fatalf("Expected '%v', got '%v'", expect, got)
+	}
+
+	definitions := GenerateJsonSchema()["definitions"].(map[string]interface{})
+	if _, exists := definitions["config.registeredParserConfig"]; !exists {
+		t.Fatalf("Expected the registered type to be in the JSON schema")
+	}
+}
+
+func TestCheckPropertyReferences(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "data.csv")
+	if err := ioutil.WriteFile(csvPath, []byte("id,name\n1,a\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	configPath := filepath.Join(dir, "rodb.yaml")
+	if err := ioutil.WriteFile(configPath, []byte(`
+inputs:
+  - name: data
+    type: csv
+    path: `+csvPath+`
+    columns:
+      - name: id
+indexes:
+  - name: ids
+    type: map
+    input: data
+    properties:
+      - id
+      - wrong
+`), 0644); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	config, errs := LoadYamlFile(configPath, logrus.StandardLogger())
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: '%+v'", errs)
+	}
+
+	parsers, err := parser.NewFromConfigs(config.Parsers)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	inputs, err := input.NewFromConfigs(config.Inputs, parsers)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	defer input.Close(inputs)
+
+	errs = config.CheckPropertyReferences(inputs)
+	if expect, got := 1, len(errs); got != expect {
+		t.Fatalf("Expected '%v' errors, got '%v': '%+v'", expect, got, errs)
+	}
+	validationError := &ValidationError{}
+	if !errors.As(errs[0], &validationError) {
+		t.Fatalf("Expected a ValidationError, got '%+v'", errs[0])
+	}
+	if expect, got := 14, validationError.Line; got != expect {
+		t.Fatalf("Expected '%v', got '%v'", expect, got)
+	}
+}
+
+func TestGetConfigPathFromError(t *testing.T) {
+	for _, testCase := range []struct {
+		err    string
+		expect string
+	}{
+		{"csv.columns[0].parser: Parser 'foo' not found", "columns.0.parser"},
+		{"jsonObject.parameters.id.property: Invalid", "parameters.id.property"},
+		{"map.input: Input 'wrong' not found in inputs list.", "input"},
+		{"The csv file does not exist", ""},
+	} {
+		if got := getConfigPathFromError(errors.New(testCase.err)); got != testCase.expect {
+			t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+		}
+	}
+}
+
+func TestLoadYamlFileIncludes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		return path
+	}
+	csvPath := writeFile("data.csv", "id,name\n1,a\n")
+	writeFile("inputs/data.yaml", `
+inputs:
+  - name: data
+    type: csv
+    path: `+csvPath+`
+    columns:
+      - name: id
+`)
+	writeFile("inputs/other.yaml", `
+include:
+  - ../shared.yaml
+inputs:
+  - name: other
+    type: csv
+    path: `+csvPath+`
+    columns:
+      - name: id
+`)
+	writeFile("shared.yaml", `
+parsers:
+  - name: shared
+    type: integer
+`)
+
+	t.Run("glob", func(t *testing.T) {
+		path := writeFile("glob/rodb.yaml", `
+include:
+  - ../inputs/*.yaml
+  - ../shared.yaml
+`)
+		config, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if len(errs) > 0 {
+			t.Fatalf("Unexpected errors: '%+v'", errs)
+		}
+		for _, name := range []string{"data", "other"} {
+			if _, exists := config.Inputs[name]; !exists {
+				t.Fatalf("Expected the input '%v' to exist", name)
+			}
+		}
+		if _, exists := config.Parsers["shared"]; !exists {
+			t.Fatalf("Expected the parser 'shared' to exist")
+		}
+	})
+	t.Run("directory", func(t *testing.T) {
+		config, errs := LoadYamlFile(filepath.Join(dir, "inputs"), logrus.StandardLogger())
+		if len(errs) > 0 {
+			t.Fatalf("Unexpected errors: '%+v'", errs)
+		}
+		if expect, got := 2, len(config.Inputs); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("missing file", func(t *testing.T) {
+		path := writeFile("missing/rodb.yaml", `
+include:
+  - ./wrong.yaml
+  - ./*.wrong
+`)
+		_, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if expect, got := 1, len(errs); got != expect {
+			t.Fatalf("Expected '%v' errors, got '%v': '%+v'", expect, got, errs)
+		}
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		path := writeFile("duplicate/rodb.yaml", `
+include:
+  - ../inputs/data.yaml
+inputs:
+  - name: data
+    type: csv
+    path: `+csvPath+`
+    columns:
+      - name: id
+`)
+		_, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if expect, got := 1, len(errs); got != expect {
+			t.Fatalf("Expected '%v' errors, got '%v': '%+v'", expect, got, errs)
+		}
+
+		validationError := &ValidationError{}
+		if !errors.As(errs[0], &validationError) {
+			t.Fatalf("Expected a ValidationError, got '%+v'", errs[0])
+		}
+		includedPath := filepath.Join(dir, "inputs", "data.yaml")
+		if validationError.File != includedPath || validationError.Line != 3 {
+			t.Fatalf("Expected the error to be located in '%v' at line 3, got '%v'", includedPath, validationError)
+		}
+	})
+	t.Run("error location", func(t *testing.T) {
+		writeFile("invalid/index.yaml", `
+indexes:
+  - name: wrong
+    type: map
+    input: unknown
+    properties:
+      - id
+`)
+		path := writeFile("invalid/rodb.yaml", `
+include:
+  - ./index.yaml
+  - ../inputs/data.yaml
+`)
+		_, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if expect, got := 1, len(errs); got != expect {
+			t.Fatalf("Expected '%v' errors, got '%v': '%+v'", expect, got, errs)
+		}
+
+		validationError := &ValidationError{}
+		if !errors.As(errs[0], &validationError) {
+			t.Fatalf("Expected a ValidationError, got '%+v'", errs[0])
+		}
+		if expect, got := filepath.Join(dir, "invalid", "index.yaml"), validationError.File; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	yamlv3 "gopkg.in/yaml.v3"
+	"os"
+	"strings"
+)
+
+// Replaces the environment variables in the scalar values of the
+// given yaml tree. The mapping keys are never modified.
+func expandEnvInYamlNode(node *yamlv3.Node, file string, log *logrus.Logger) []error {
+	errs := make([]error, 0)
+	var walk func(node *yamlv3.Node)
+	walk = func(node *yamlv3.Node) {
+		switch node.Kind {
+		case yamlv3.DocumentNode, yamlv3.SequenceNode:
+			for _, child := range node.Content {
+				walk(child)
+			}
+		case yamlv3.MappingNode:
+			for i := 1; i < len(node.Content); i += 2 {
+				walk(node.Content[i])
+			}
+		case yamlv3.ScalarNode:
+			if !strings.Contains(node.Value, "$") {
+				return
+			}
+
+			expanded, unsetNames, err := expandEnv(node.Value, os.LookupEnv)
+			if err != nil {
+				errs = append(errs, &ValidationError{
+					File:   file,
+					Line:   node.Line,
+					Column: node.Column,
+					Err:    err,
+				})
+				return
+			}
+			for _, name := range unsetNames {
+				log.Warnf("%v:%v:%v: The environment variable '%v' is not set, and has been replaced by an empty string.\n", file, node.Line, node.Column, name)
+			}
+
+			node.Value = expanded
+
+			// A plain value is resolved again after the replacement,
+			// as if it's new content had been written in the file
+			if node.Style == 0 {
+				node.Tag = ""
+			}
+		}
+	}
+	walk(node)
+
+	return errs
+}
+
+// Replaces the variables in a string, with the braced syntax of the shell:
+//   - ${NAME} is replaced by the value of the variable
+//   - ${NAME:-default} uses the default when the variable is unset or empty
+//   - ${NAME:?message} fails with the message when the variable is unset or empty
+//   - $$ is replaced by a single $
+//
+// Any other $, including $NAME without braces, is kept as-is,
+// which allows to use it in regexps or xpaths.
+// The returned names are the ones of the unset variables replaced by an empty string.
+func expandEnv(value string, lookup func(name string) (string, bool)) (string, []string, error) {
+	result := strings.Builder{}
+	unsetNames := make([]string, 0)
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		next := value[i+1]
+		switch {
+		case next == '$':
+			result.WriteByte('$')
+			i++
+		case next == '{':
+			end := strings.IndexByte(value[i+2:], '}')
+			if end == -1 {
+				return "", nil, fmt.Errorf("Missing '}' after '%v'.", value[i:])
+			}
+
+			expression := value[i+2 : i+2+end]
+			replacement, unset, err := expandEnvExpression(expression, lookup)
+			if err != nil {
+				return "", nil, err
+			}
+			if unset {
+				unsetNames = append(unsetNames, expression)
+			}
+
+			result.WriteString(replacement)
+			i += 2 + end
+		default:
+			result.WriteByte('$')
+		}
+	}
+
+	return result.String(), unsetNames, nil
+}
+
+// Expands the content of a ${...} expression. The returned
+// boolean is true when an unset variable has been replaced
+// by an empty string, without any default value.
+func expandEnvExpression(expression string, lookup func(name string) (string, bool)) (string, bool, error) {
+	name := expression
+	operator := ""
+	argument := ""
+	if separator := strings.Index(expression, ":"); separator != -1 {
+		if len(expression) < separator+2 {
+			return "", false, fmt.Errorf("Invalid expression '${%v}'. The supported syntaxes are ${NAME}, ${NAME:-default} and ${NAME:?message}.", expression)
+		}
+
+		name = expression[:separator]
+		operator = expression[separator : separator+2]
+		argument = expression[separator+2:]
+		if operator != ":-" && operator != ":?" {
+			return "", false, fmt.Errorf("Invalid expression '${%v}'. The supported syntaxes are ${NAME}, ${NAME:-default} and ${NAME:?message}.", expression)
+		}
+	}
+
+	if name == "" || !isEnvNameStart(name[0]) {
+		return "", false, fmt.Errorf("Invalid variable name '%v' in '${%v}'.", name, expression)
+	}
+	for i := 1; i < len(name); i++ {
+		if !isEnvNameCharacter(name[i]) {
+			return "", false, fmt.Errorf("Invalid variable name '%v' in '${%v}'.", name, expression)
+		}
+	}
+
+	variable, exists := lookup(name)
+	switch operator {
+	case ":-":
+		if variable == "" {
+			return argument, false, nil
+		}
+	case ":?":
+		if variable == "" {
+			if argument == "" {
+				argument = "The variable is not set."
+			}
+			return "", false, fmt.Errorf("Environment variable %v: %v", name, argument)
+		}
+	}
+
+	return variable, !exists, nil
+}
+
+func isEnvNameStart(character byte) bool {
+	return character == '_' || (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+}
+
+func isEnvNameCharacter(character byte) bool {
+	return isEnvNameStart(character) || (character >= '0' && character <= '9')
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/service"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		value, exists := map[string]string{
+			"FOO":   "foo",
+			"EMPTY": "",
+		}[name]
+		return value, exists
+	}
+
+	for _, testCase := range []struct {
+		value  string
+		expect string
+		unset  int
+	}{
+		{"$FOO", "$FOO", 0},
+		{"a${FOO}b", "afoob", 0},
+		{"${FOO}.bar", "foo.bar", 0},
+		{"${BAR}", "", 1},
+		{"$BAR", "$BAR", 0},
+		{"${BAR:-default}", "default", 0},
+		{"${EMPTY:-default}", "default", 0},
+		{"${FOO:-default}", "foo", 0},
+		{"${BAR:-}", "", 0},
+		{"${FOO:?missing}", "foo", 0},
+		{"$$FOO", "$FOO", 0},
+		{"a$$", "a$", 0},
+		{"^[0-9]+$", "^[0-9]+$", 0},
+		{"$1 and $", "$1 and $", 0},
+		{"^a$b", "^a$b", 0},
+		{"//item[@id=$id]", "//item[@id=$id]", 0},
+	} {
+		t.Run(testCase.value, func(t *testing.T) {
+			got, unsetNames, err := expandEnv(testCase.value, lookup)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+			if len(unsetNames) != testCase.unset {
+				t.Fatalf("Expected '%v' unset variables, got '%v'", testCase.unset, unsetNames)
+			}
+		})
+	}
+
+	for _, value := range []string{
+		"${BAR:?missing}",
+		"${EMPTY:?}",
+		"${FOO",
+		"${FOO:+x}",
+		"${1FOO}",
+		"${}",
+	} {
+		t.Run(value, func(t *testing.T) {
+			if _, _, err := expandEnv(value, lookup); err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadYamlFileEnv(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "data.csv")
+	if err := ioutil.WriteFile(csvPath, []byte("id,name\n1,a\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	os.Setenv("RODB_TEST_DIR", dir)
+	defer os.Unsetenv("RODB_TEST_DIR")
+	os.Setenv("RODB_TEST_CACHE_SIZE", "2048")
+	defer os.Unsetenv("RODB_TEST_CACHE_SIZE")
+
+	writeConfig := func(content string) string {
+		path := filepath.Join(dir, "rodb.yaml")
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		return path
+	}
+
+	t.Run("values", func(t *testing.T) {
+		path := writeConfig(`
+inputs:
+  - name: data
+    type: csv
+    path: ${RODB_TEST_DIR}/data.csv
+    dieOnInputChange: yes
+    columns:
+      - name: id
+        parser: ${RODB_TEST_PARSER:-integer}
+      - name: $$name
+services:
+  - name: http
+    type: http
+    http:
+      listen: "${RODB_TEST_LISTEN:-:8080}"
+    cache:
+      maxSize: ${RODB_TEST_CACHE_SIZE}
+    routes:
+      - path: /
+        output: list
+outputs:
+  - name: list
+    type: jsonArray
+    input: data
+`)
+		config, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if len(errs) > 0 {
+			t.Fatalf("Unexpected errors: '%+v'", errs)
+		}
+
+		csvConfig := config.Inputs["data"].(*input.CsvConfig)
+		if expect, got := csvPath, csvConfig.Path; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if !csvConfig.ShouldDieOnInputChange() {
+			t.Fatalf("Expected dieOnInputChange to be true")
+		}
+		if expect, got := "integer", csvConfig.Columns[0].Parser; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if expect, got := "$name", csvConfig.Columns[1].Name; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+
+		httpConfig := config.Services["http"].(*service.HttpConfig)
+		if expect, got := ":8080", httpConfig.Http.Listen; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if expect, got := int64(2048), *httpConfig.Cache.MaxSize; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("required", func(t *testing.T) {
+		path := writeConfig(`
+inputs:
+  - name: data
+    type: csv
+    path: ${RODB_TEST_MISSING:?The data directory is required}
+`)
+		_, errs := LoadYamlFile(path, logrus.StandardLogger())
+		if expect, got := 1, len(errs); got != expect {
+			t.Fatalf("Expected '%v' errors, got '%v': '%+v'", expect, got, errs)
+		}
+		if expect, got := path+":5:11: Environment variable RODB_TEST_MISSING: The data directory is required", errs[0].Error(); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
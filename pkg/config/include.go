@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/util"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The extensions of the files loaded when the config path is a directory
+var configFileExtensions = []string{".yaml", ".yml"}
+
+// Loads the given config file, or all the config files of the
+// given directory, and the files they include. Every file is
+// only loaded once, even when it's included multiple times.
+func loadConfigFiles(configPath string, log *logrus.Logger) (*configParser, []error) {
+	paths, err := getConfigFilePaths(configPath)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	loader := &configFileLoader{
+		merged: &configParser{},
+		loaded: make(map[string]bool),
+		log:    log,
+		errs:   make([]error, 0),
+	}
+	for _, path := range paths {
+		loader.load(path)
+	}
+
+	return loader.merged, loader.errs
+}
+
+func getConfigFilePaths(configPath string) ([]string, error) {
+	stat, err := os.Stat(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read config file %v: %w", configPath, err)
+	}
+	if !stat.IsDir() {
+		return []string{configPath}, nil
+	}
+
+	files, err := ioutil.ReadDir(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read config directory %v: %w", configPath, err)
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		extension := strings.ToLower(filepath.Ext(file.Name()))
+		if !file.IsDir() && util.IsInArray(extension, configFileExtensions) {
+			paths = append(paths, filepath.Join(configPath, file.Name()))
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("The config directory %v does not contain any yaml file.", configPath)
+	}
+
+	return paths, nil
+}
+
+type configFileLoader struct {
+	merged *configParser
+	loaded map[string]bool
+	log    *logrus.Logger
+	errs   []error
+}
+
+func (loader *configFileLoader) load(path string) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		loader.errs = append(loader.errs, fmt.Errorf("Cannot read config file %v: %w", path, err))
+		return
+	}
+	if loader.loaded[absolutePath] {
+		return
+	}
+	loader.loaded[absolutePath] = true
+
+	parsedConfig, errs := parseConfigFile(path, loader.log)
+	if len(errs) > 0 {
+		loader.errs = append(loader.errs, errs...)
+		return
+	}
+	loader.merged.merge(parsedConfig)
+
+	// The included paths are relative to the including file
+	for _, pattern := range parsedConfig.Include {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(path), pattern)
+		}
+
+		includedPaths, err := filepath.Glob(pattern)
+		if err != nil {
+			loader.errs = append(loader.errs, &ValidationError{
+				File: path,
+				Path: "include",
+				Err:  fmt.Errorf("Invalid pattern '%v': %w", pattern, err),
+			})
+			continue
+		}
+		if len(includedPaths) == 0 && !isGlobPattern(pattern) {
+			loader.errs = append(loader.errs, &ValidationError{
+				File: path,
+				Path: "include",
+				Err:  fmt.Errorf("The included file %v does not exist.", pattern),
+			})
+			continue
+		}
+
+		sort.Strings(includedPaths)
+		for _, includedPath := range includedPaths {
+			loader.load(includedPath)
+		}
+	}
+}
+
+func isGlobPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
@@ -7,7 +7,8 @@ import (
 )
 
 type indexParser struct {
-	index index.Config
+	index   index.Config
+	locator *yamlLocator
 }
 
 func (config *indexParser) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -16,23 +17,11 @@ func (config *indexParser) UnmarshalYAML(unmarshal func(interface{}) error) erro
 		return fmt.Errorf("Error in index config: %w", err)
 	}
 
-	switch objectType {
-	case "map":
-		config.index = &index.MapConfig{}
-		return unmarshal(config.index)
-	case "wildcard":
-		config.index = &index.WildcardConfig{}
-		return unmarshal(config.index)
-	case "sqlite":
-		config.index = &index.SqliteConfig{}
-		return unmarshal(config.index)
-	case "fts5":
-		config.index = &index.Fts5Config{}
-		return unmarshal(config.index)
-	case "noop":
-		config.index = &index.NoopConfig{}
-		return unmarshal(config.index)
-	default:
+	emptyConfig, typeExists := index.NewConfig(objectType)
+	if !typeExists {
 		return fmt.Errorf("Error in index config: Unknown type '%v'", objectType)
 	}
+
+	config.index = emptyConfig
+	return unmarshal(config.index)
 }
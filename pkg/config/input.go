@@ -7,7 +7,8 @@ import (
 )
 
 type inputParser struct {
-	input input.Config
+	input   input.Config
+	locator *yamlLocator
 }
 
 func (config *inputParser) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -16,17 +17,11 @@ func (config *inputParser) UnmarshalYAML(unmarshal func(interface{}) error) erro
 		return fmt.Errorf("Error in input config: %w", err)
 	}
 
-	switch objectType {
-	case "csv":
-		config.input = &input.CsvConfig{}
-		return unmarshal(config.input)
-	case "xml":
-		config.input = &input.XmlConfig{}
-		return unmarshal(config.input)
-	case "json":
-		config.input = &input.JsonConfig{}
-		return unmarshal(config.input)
-	default:
+	emptyConfig, typeExists := input.NewConfig(objectType)
+	if !typeExists {
 		return fmt.Errorf("Error in input config: Unknown type '%v'", objectType)
 	}
+
+	config.input = emptyConfig
+	return unmarshal(config.input)
 }
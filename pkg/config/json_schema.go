@@ -0,0 +1,177 @@
+package config
+
+import (
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/output"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/rodb-io/rodb/pkg/service"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Generates a JSON Schema of the configuration file, from the
+// config structures and their yaml tags. It only describes the
+// structure, while the documented schemas in docs/schema also
+// describe the meaning and constraints of each property.
+func GenerateJsonSchema() map[string]interface{} {
+	generator := &jsonSchemaGenerator{
+		definitions: make(map[string]interface{}),
+	}
+
+	properties := map[string]interface{}{
+		"include": map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		},
+		"parsers":  generator.getSectionSchema(parserConfigTypesAsValues()),
+		"inputs":   generator.getSectionSchema(inputConfigTypesAsValues()),
+		"indexes":  generator.getSectionSchema(indexConfigTypesAsValues()),
+		"services": generator.getSectionSchema(serviceConfigTypesAsValues()),
+		"outputs":  generator.getSectionSchema(outputConfigTypesAsValues()),
+	}
+
+	return map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"title":                "RODB configuration",
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           properties,
+		"definitions":          generator.definitions,
+	}
+}
+
+type jsonSchemaGenerator struct {
+	definitions map[string]interface{}
+}
+
+func (generator *jsonSchemaGenerator) getSectionSchema(configs map[string]interface{}) map[string]interface{} {
+	typeNames := make([]string, 0, len(configs))
+	for typeName := range configs {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	oneOf := make([]interface{}, 0, len(typeNames))
+	for _, typeName := range typeNames {
+		configType := reflect.TypeOf(configs[typeName]).Elem()
+		ref := generator.getDefinitionRef(configType)
+
+		schema := generator.definitions[configType.String()].(map[string]interface{})
+		schema["required"] = []string{"name", "type"}
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			properties["type"] = map[string]interface{}{"const": typeName}
+		}
+
+		oneOf = append(oneOf, ref)
+	}
+
+	return map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"oneOf": oneOf,
+		},
+	}
+}
+
+// Each struct is described once in the definitions, which
+// also allows to describe the recursive structures
+func (generator *jsonSchemaGenerator) getDefinitionRef(structType reflect.Type) map[string]interface{} {
+	definitionName := structType.String()
+	ref := map[string]interface{}{"$ref": "#/definitions/" + definitionName}
+	if _, exists := generator.definitions[definitionName]; exists {
+		return ref
+	}
+
+	properties := make(map[string]interface{})
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           properties,
+	}
+	generator.definitions[definitionName] = schema
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		propertyName := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if propertyName == "" || propertyName == "-" {
+			continue
+		}
+
+		properties[propertyName] = generator.getTypeSchema(field.Type)
+	}
+
+	return ref
+}
+
+func (generator *jsonSchemaGenerator) getTypeSchema(fieldType reflect.Type) map[string]interface{} {
+	switch fieldType.Kind() {
+	case reflect.Ptr:
+		return generator.getTypeSchema(fieldType.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": generator.getTypeSchema(fieldType.Elem()),
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": generator.getTypeSchema(fieldType.Elem()),
+		}
+	case reflect.Struct:
+		return generator.getDefinitionRef(fieldType)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func parserConfigTypesAsValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, typeName := range parser.TypeNames() {
+		values[typeName], _ = parser.NewConfig(typeName)
+	}
+	return values
+}
+
+func inputConfigTypesAsValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, typeName := range input.TypeNames() {
+		values[typeName], _ = input.NewConfig(typeName)
+	}
+	return values
+}
+
+func indexConfigTypesAsValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, typeName := range index.TypeNames() {
+		values[typeName], _ = index.NewConfig(typeName)
+	}
+	return values
+}
+
+func serviceConfigTypesAsValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, typeName := range service.TypeNames() {
+		values[typeName], _ = service.NewConfig(typeName)
+	}
+	return values
+}
+
+func outputConfigTypesAsValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, typeName := range output.TypeNames() {
+		values[typeName], _ = output.NewConfig(typeName)
+	}
+	return values
+}
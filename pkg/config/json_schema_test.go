@@ -0,0 +1,40 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateJsonSchema(t *testing.T) {
+	schema := GenerateJsonSchema()
+
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	definitions := schema["definitions"].(map[string]interface{})
+	csv, exists := definitions["input.CsvConfig"].(map[string]interface{})
+	if !exists {
+		t.Fatalf("Expected a definition for the csv input, got '%+v'", definitions)
+	}
+
+	properties := csv["properties"].(map[string]interface{})
+	t.Run("type", func(t *testing.T) {
+		typeSchema := properties["type"].(map[string]interface{})
+		if expect, got := "csv", typeSchema["const"]; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("array", func(t *testing.T) {
+		columns := properties["columns"].(map[string]interface{})
+		if expect, got := "array", columns["type"]; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("required", func(t *testing.T) {
+		required := csv["required"].([]string)
+		if len(required) != 2 || required[0] != "name" || required[1] != "type" {
+			t.Fatalf("Expected name and type to be required, got '%v'", required)
+		}
+	})
+}
@@ -7,7 +7,8 @@ import (
 )
 
 type outputParser struct {
-	output output.Config
+	output  output.Config
+	locator *yamlLocator
 }
 
 func (config *outputParser) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -16,17 +17,11 @@ func (config *outputParser) UnmarshalYAML(unmarshal func(interface{}) error) err
 		return fmt.Errorf("Error in output config: %w", err)
 	}
 
-	switch objectType {
-	case "graphql":
-		config.output = &output.GraphQLConfig{}
-		return unmarshal(config.output)
-	case "jsonArray":
-		config.output = &output.JsonArrayConfig{}
-		return unmarshal(config.output)
-	case "jsonObject":
-		config.output = &output.JsonObjectConfig{}
-		return unmarshal(config.output)
-	default:
+	emptyConfig, typeExists := output.NewConfig(objectType)
+	if !typeExists {
 		return fmt.Errorf("Error in output config: Unknown type '%v'", objectType)
 	}
+
+	config.output = emptyConfig
+	return unmarshal(config.output)
 }
@@ -7,7 +7,8 @@ import (
 )
 
 type parserParser struct {
-	parser parser.Config
+	parser  parser.Config
+	locator *yamlLocator
 }
 
 func (config *parserParser) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -16,26 +17,11 @@ func (config *parserParser) UnmarshalYAML(unmarshal func(interface{}) error) err
 		return fmt.Errorf("Error in parser config: %w", err)
 	}
 
-	switch objectType {
-	case "integer":
-		config.parser = &parser.IntegerConfig{}
-		return unmarshal(config.parser)
-	case "float":
-		config.parser = &parser.FloatConfig{}
-		return unmarshal(config.parser)
-	case "boolean":
-		config.parser = &parser.BooleanConfig{}
-		return unmarshal(config.parser)
-	case "string":
-		config.parser = &parser.StringConfig{}
-		return unmarshal(config.parser)
-	case "json":
-		config.parser = &parser.JsonConfig{}
-		return unmarshal(config.parser)
-	case "split":
-		config.parser = &parser.SplitConfig{}
-		return unmarshal(config.parser)
-	default:
+	emptyConfig, typeExists := parser.NewConfig(objectType)
+	if !typeExists {
 		return fmt.Errorf("Error in parser config: Unknown type '%v'", objectType)
 	}
+
+	config.parser = emptyConfig
+	return unmarshal(config.parser)
 }
@@ -8,6 +8,7 @@ import (
 
 type serviceParser struct {
 	service service.Config
+	locator *yamlLocator
 }
 
 func (config *serviceParser) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -16,11 +17,11 @@ func (config *serviceParser) UnmarshalYAML(unmarshal func(interface{}) error) er
 		return fmt.Errorf("Error in service config: %w", err)
 	}
 
-	switch objectType {
-	case "http":
-		config.service = &service.HttpConfig{}
-		return unmarshal(config.service)
-	default:
+	emptyConfig, typeExists := service.NewConfig(objectType)
+	if !typeExists {
 		return fmt.Errorf("Error in service config: Unknown type '%v'", objectType)
 	}
+
+	config.service = emptyConfig
+	return unmarshal(config.service)
 }
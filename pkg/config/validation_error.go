@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+)
+
+// An error in the configuration, located in the
+// configuration file as precisely as possible
+type ValidationError struct {
+	File   string
+	Line   int
+	Column int
+	// The component having the error, for example "inputs.users"
+	Path string
+	Err  error
+}
+
+func (err *ValidationError) Error() string {
+	message := err.Err.Error()
+	if err.Path != "" {
+		message = err.Path + ": " + message
+	}
+
+	if err.File != "" && err.Line > 0 {
+		return fmt.Sprintf("%v:%v:%v: %v", err.File, err.Line, err.Column, message)
+	}
+	if err.File != "" {
+		return err.File + ": " + message
+	}
+
+	return message
+}
+
+func (err *ValidationError) Unwrap() error {
+	return err.Err
+}
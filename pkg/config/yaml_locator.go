@@ -0,0 +1,209 @@
+package config
+
+import (
+	"errors"
+	yamlv2 "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Keeps the nodes of the configuration file, to find
+// the line and column matching a configuration error
+type yamlLocator struct {
+	file string
+	root *yamlv3.Node
+
+	// The configuration is decoded from an encoded copy of the file
+	// (after replacing the environment variables), so the lines of the
+	// decoding errors are mapped to the original nodes.
+	nodesByDecodedLine map[int]*yamlv3.Node
+}
+
+// The document must be the parsed file, and the decodedData the encoded
+// copy of the document from which the configuration is decoded
+func newYamlLocator(file string, document *yamlv3.Node, decodedData []byte) *yamlLocator {
+	locator := &yamlLocator{
+		file:               file,
+		nodesByDecodedLine: make(map[int]*yamlv3.Node),
+	}
+	if document == nil || len(document.Content) == 0 {
+		return locator
+	}
+	locator.root = document.Content[0]
+
+	decodedDocument := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(decodedData, decodedDocument); err == nil {
+		locator.mapDecodedLines(document, decodedDocument)
+	}
+
+	return locator
+}
+
+// Both trees have the same structure, so they are walked together,
+// to map the first node of each decoded line to it's original node
+func (locator *yamlLocator) mapDecodedLines(original *yamlv3.Node, decoded *yamlv3.Node) {
+	if _, exists := locator.nodesByDecodedLine[decoded.Line]; !exists {
+		locator.nodesByDecodedLine[decoded.Line] = original
+	}
+
+	for i := 0; i < len(original.Content) && i < len(decoded.Content); i++ {
+		locator.mapDecodedLines(original.Content[i], decoded.Content[i])
+	}
+}
+
+// Creates an error located on the given component (for example "inputs" and "users"),
+// as close as possible to the given dot-separated path in it's configuration.
+// The locator may be nil when the configuration does not come from a file.
+func (locator *yamlLocator) newError(
+	section string,
+	name string,
+	configPath string,
+	err error,
+) *ValidationError {
+	validationError := &ValidationError{
+		Path: section + "." + name,
+		Err:  err,
+	}
+	if locator == nil {
+		return validationError
+	}
+
+	validationError.File = locator.file
+	if component := locator.findComponent(section, name); component != nil {
+		node := component
+		if configPath != "" {
+			node = findYamlNode(component, strings.Split(configPath, "."))
+		}
+		validationError.Line = node.Line
+		validationError.Column = node.Column
+	}
+
+	return validationError
+}
+
+// The lines are included in the messages of the yaml parser,
+// but not in a structured way
+var yamlErrorLineRegexp = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// Converts the errors of the yaml parser, which may contain multiple
+// errors at once, to a list of errors with their line numbers
+func (locator *yamlLocator) newParsingErrors(err error) []error {
+	messages := []string{err.Error()}
+	if typeError, isTypeError := err.(*yamlv2.TypeError); isTypeError {
+		messages = typeError.Errors
+	}
+
+	validationErrors := make([]error, len(messages))
+	for i, message := range messages {
+		validationError := &ValidationError{
+			File: locator.file,
+			Err:  errors.New(message),
+		}
+		if match := yamlErrorLineRegexp.FindStringSubmatch(message); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			validationError.Line, validationError.Column = locator.getOriginalPosition(line)
+			validationError.Err = errors.New(match[2])
+		}
+		validationErrors[i] = validationError
+	}
+
+	return validationErrors
+}
+
+// The parser only gives the line, so the position is the one
+// of the original node of the first node starting on that line.
+// When the errors come from the original file, there is no mapping.
+func (locator *yamlLocator) getOriginalPosition(decodedLine int) (int, int) {
+	if len(locator.nodesByDecodedLine) == 0 {
+		return decodedLine, 1
+	}
+
+	for line := decodedLine; line > 0; line-- {
+		if node, exists := locator.nodesByDecodedLine[line]; exists {
+			return node.Line, node.Column
+		}
+	}
+
+	return decodedLine, 1
+}
+
+func (locator *yamlLocator) findComponent(section string, name string) *yamlv3.Node {
+	if locator.root == nil {
+		return nil
+	}
+
+	sectionNode, _ := findYamlChild(locator.root, section)
+	if sectionNode == nil || sectionNode.Kind != yamlv3.SequenceNode {
+		return nil
+	}
+
+	for _, component := range sectionNode.Content {
+		if nameNode, _ := findYamlChild(component, "name"); nameNode != nil && nameNode.Value == name {
+			return component
+		}
+	}
+
+	return nil
+}
+
+// Follows the given path as far as possible, and
+// returns the node where the path was interrupted
+func findYamlNode(node *yamlv3.Node, path []string) *yamlv3.Node {
+	located := node
+	for _, key := range path {
+		child, childLocation := findYamlChild(node, key)
+		if child == nil {
+			break
+		}
+		node = child
+		located = childLocation
+	}
+
+	return located
+}
+
+// Returns the child matching the key, and the node that should be used to
+// locate it (the key of a mapping is more relevant than it's value).
+// In sequences, the key can either be an index, or the name of an item.
+func findYamlChild(node *yamlv3.Node, key string) (*yamlv3.Node, *yamlv3.Node) {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], node.Content[i]
+			}
+		}
+	case yamlv3.SequenceNode:
+		if index, err := strconv.Atoi(key); err == nil {
+			if index >= 0 && index < len(node.Content) {
+				return node.Content[index], node.Content[index]
+			}
+			return nil, nil
+		}
+		for _, item := range node.Content {
+			if nameNode, _ := findYamlChild(item, "name"); nameNode != nil && nameNode.Value == key {
+				return item, item
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// The validation errors start with the path of the invalid property,
+// prefixed by the type of component. For example "csv.columns[0].parser: ..."
+var validationErrorPathRegexp = regexp.MustCompile(`^[a-zA-Z0-9]+\.([^\s:]+)`)
+
+func getConfigPathFromError(err error) string {
+	match := validationErrorPathRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return ""
+	}
+
+	path := strings.ReplaceAll(match[1], "[", ".")
+	path = strings.ReplaceAll(path, "]", "")
+
+	return strings.TrimSuffix(path, ".")
+}
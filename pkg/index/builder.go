@@ -0,0 +1,88 @@
+package index
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/util"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// Fills an index with the records of it's input.
+// The builders of all the indexes using the same input share a
+// single iteration, so that the input is only read and parsed once.
+type builder interface {
+	input() input.Input
+	logger() *logrus.Entry
+	add(record record.Record) error
+	finish() error
+}
+
+// Iterates the input once, and sends each record to all the builders,
+// which are all expected to use the same input.
+func build(builders []builder) error {
+	if len(builders) == 0 {
+		return nil
+	}
+
+	input := builders[0].input()
+	updateProgress := util.TrackProgress(input, builders[0].logger())
+
+	inputIterator, end, err := input.IterateAll()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := end(); err != nil {
+			builders[0].logger().Errorf("Error while closing the input iterator: %v", err)
+		}
+	}()
+
+	for {
+		record, err := inputIterator()
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			break
+		}
+
+		updateProgress(record.Position())
+
+		for _, builder := range builders {
+			if err := builder.add(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, builder := range builders {
+		if err := builder.finish(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Runs the builders of each input in parallel, since they are independent
+func buildAll(builders map[input.Input][]builder) error {
+	buildErrors := make(chan error, len(builders))
+	wait := sync.WaitGroup{}
+	for _, inputBuilders := range builders {
+		wait.Add(1)
+		go func(inputBuilders []builder) {
+			defer wait.Done()
+			if err := build(inputBuilders); err != nil {
+				inputName := inputBuilders[0].input().Name()
+				buildErrors <- fmt.Errorf("Error while creating the indexes of the input '%v': %w", inputName, err)
+			}
+		}(inputBuilders)
+	}
+
+	wait.Wait()
+	close(buildErrors)
+
+	return <-buildErrors
+}
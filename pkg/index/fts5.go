@@ -8,7 +8,7 @@ import (
 	sqlitePackage "github.com/rodb-io/rodb/pkg/index/sqlite"
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
-	"github.com/rodb-io/rodb/pkg/util"
+	"github.com/sirupsen/logrus"
 	"reflect"
 	"strconv"
 	"strings"
@@ -20,20 +20,69 @@ type Fts5 struct {
 	db     *sql.DB
 }
 
+// Orders the results of an fts5 index by relevance
+type Fts5Ranking struct {
+	// The bm25 weight of each property. The
+	// properties not listed have a weight of 1.
+	Weights    map[string]float64
+	Highlights []Fts5Highlight
+}
+
+// Returns the text of a property with the matching terms
+// surrounded by the start and end markers
+type Fts5Highlight struct {
+	Property string
+	// When true, only returns the fragment of the text having the most
+	// matching terms, with at most the given number of tokens.
+	// The ellipsis is added where the text has been cut.
+	Snippet  bool
+	Start    string
+	End      string
+	Ellipsis string
+	Tokens   int
+}
+
+type Fts5RankedRecord struct {
+	Position record.Position
+	// The bm25 score. The lower, the more relevant.
+	Score float64
+	// The highlighted texts, in the same order as the highlights of
+	// the ranking. They are nil when the property has no value.
+	Highlights []interface{}
+}
+
 func NewFts5(
 	config *Fts5Config,
 	inputs input.List,
 ) (*Fts5, error) {
+	sqlite, indexBuilder, err := newFts5(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if indexBuilder != nil {
+		if err := build([]builder{indexBuilder}); err != nil {
+			return nil, fmt.Errorf("Error while creating the index: %w", err)
+		}
+	}
+
+	return sqlite, nil
+}
+
+func newFts5(
+	config *Fts5Config,
+	inputs input.List,
+) (*Fts5, builder, error) {
 	input, inputExists := inputs[config.Input]
 	if !inputExists {
-		return nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
+		return nil, nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
 	}
 
 	// We use the driver directly rather than the database/sql interfaces, because the generic
 	// way tries to autodetect and normalize the return types. But we want to get the real types.
 	db, err := sqlitePackage.Open(config.Dsn)
 	if err != nil {
-		return nil, fmt.Errorf("Error while opening the sqlite DSN: %w", err)
+		return nil, nil, fmt.Errorf("Error while opening the sqlite DSN: %w", err)
 	}
 
 	sqlite := &Fts5{
@@ -44,66 +93,59 @@ func NewFts5(
 
 	metadataExists, err := sqlitePackage.HasMetadata(sqlite.db, sqlite.config.Name)
 	if err != nil {
-		return nil, fmt.Errorf("Error while checking metadata from the index: %w", err)
+		return nil, nil, fmt.Errorf("Error while checking metadata from the index: %w", err)
 	}
 	if metadataExists {
 		metadata, err := sqlitePackage.LoadMetadata(sqlite.db, sqlite.config.Name)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		if err := metadata.AssertValid(sqlite.input); err != nil {
-			return nil, err
+		if err := metadata.AssertValid(sqlite.input, sqlite.config.getMetadataProperties()); err != nil {
+			return nil, nil, err
 		}
 	} else {
-		if err := sqlite.createIndex(); err != nil {
-			return nil, fmt.Errorf("Error while creating the index: %w", err)
+		indexBuilder, err := sqlite.createIndex()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error while creating the index: %w", err)
 		}
+
+		return sqlite, indexBuilder, nil
 	}
 
-	return sqlite, nil
+	return sqlite, nil, nil
 }
 
 func (sqlite *Fts5) Name() string {
 	return sqlite.config.Name
 }
 
-func (sqlite *Fts5) createIndex() error {
+// Creates the index table, which is filled by the returned builder
+func (sqlite *Fts5) createIndex() (*fts5Builder, error) {
 	metadata, err := sqlitePackage.NewMetadata(
 		sqlite.db,
 		sqlite.config.Name,
 		sqlite.input,
+		sqlite.config.getMetadataProperties(),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := metadata.Save(); err != nil {
-		return err
-	}
-
-	updateProgress := util.TrackProgress(sqlite.input, sqlite.config.Logger)
-
-	inputIterator, end, err := sqlite.input.IterateAll()
-	if err != nil {
-		return err
+		return nil, err
 	}
-	defer func() {
-		if err := end(); err != nil {
-			sqlite.config.Logger.Errorf("Error while closing the input iterator: %v", err)
-		}
-	}()
 
 	columnIdentifiers := make([]string, len(sqlite.config.Properties))
 	insertPlaceholders := make([]string, len(sqlite.config.Properties))
 	for propertyIndex, property := range sqlite.config.Properties {
 		if property == "__offset" {
-			return errors.New("__offset is a reserved property name for the fts5 index.")
+			return nil, errors.New("__offset is a reserved property name for the fts5 index.")
 		}
 
 		propertyIdentifier, err := sqlitePackage.SanitizeIdentifier(sqlite.db, property)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		columnIdentifiers[propertyIndex] = propertyIdentifier
@@ -112,7 +154,7 @@ func (sqlite *Fts5) createIndex() error {
 
 	tableIdentifier, err := sqlite.getIndexTableIdentifier()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	prefixString := ""
@@ -125,7 +167,7 @@ func (sqlite *Fts5) createIndex() error {
 
 	tokenizeString, err := sqlitePackage.SanitizeIdentifier(sqlite.db, sqlite.config.Tokenize)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	_, err = sqlite.db.Exec(`
@@ -137,7 +179,7 @@ func (sqlite *Fts5) createIndex() error {
 		);
 	`)
 	if err != nil {
-		return fmt.Errorf("Error while creating index table: %w", err)
+		return nil, fmt.Errorf("Error while creating index table: %w", err)
 	}
 
 	preparedInsert, err := sqlite.db.Prepare(`
@@ -147,56 +189,77 @@ func (sqlite *Fts5) createIndex() error {
 		) VALUES (?, ` + strings.Join(insertPlaceholders, ", ") + `);
 	`)
 	if err != nil {
-		return fmt.Errorf("Error while preparing index table insert query: %w", err)
+		return nil, fmt.Errorf("Error while preparing index table insert query: %w", err)
 	}
 
-	valuesToInsert := make([]interface{}, 1+len(sqlite.config.Properties))
-	for {
-		record, err := inputIterator()
+	return &fts5Builder{
+		sqlite:          sqlite,
+		metadata:        metadata,
+		tableIdentifier: tableIdentifier,
+		preparedInsert:  preparedInsert,
+		valuesToInsert:  make([]interface{}, 1+len(sqlite.config.Properties)),
+	}, nil
+}
+
+type fts5Builder struct {
+	sqlite          *Fts5
+	metadata        *sqlitePackage.Metadata
+	tableIdentifier string
+	preparedInsert  *sql.Stmt
+	valuesToInsert  []interface{}
+}
+
+func (builder *fts5Builder) input() input.Input {
+	return builder.sqlite.input
+}
+
+func (builder *fts5Builder) logger() *logrus.Entry {
+	return builder.sqlite.config.Logger
+}
+
+func (builder *fts5Builder) add(record record.Record) error {
+	builder.valuesToInsert[0] = record.Position()
+	for propertyIndex, propertyName := range builder.sqlite.config.Properties {
+		value, err := record.Get(propertyName)
 		if err != nil {
 			return err
 		}
-		if record == nil {
-			break
-		}
 
-		updateProgress(record.Position())
+		if value != nil {
+			value = reflect.ValueOf(value).Interface()
+		}
 
-		valuesToInsert[0] = record.Position()
-		for propertyIndex, propertyName := range sqlite.config.Properties {
-			value, err := record.Get(propertyName)
-			if err != nil {
-				return err
-			}
+		builder.valuesToInsert[propertyIndex+1] = value
+	}
 
-			if value != nil {
-				value = reflect.ValueOf(value).Interface()
-			}
+	if _, err := builder.preparedInsert.Exec(builder.valuesToInsert...); err != nil {
+		return err
+	}
 
-			valuesToInsert[propertyIndex+1] = value
-		}
+	return nil
+}
 
-		if _, err = preparedInsert.Exec(valuesToInsert...); err != nil {
-			return err
-		}
+func (builder *fts5Builder) finish() error {
+	if err := builder.preparedInsert.Close(); err != nil {
+		return err
 	}
 
-	metadata.SetCompleted(true)
-	if err := metadata.Save(); err != nil {
+	builder.metadata.SetCompleted(true)
+	if err := builder.metadata.Save(); err != nil {
 		return err
 	}
 
-	row := sqlite.db.QueryRow(`SELECT COUNT(1) FROM ` + tableIdentifier + `;`)
+	row := builder.sqlite.db.QueryRow(`SELECT COUNT(1) FROM ` + builder.tableIdentifier + `;`)
 	if err := row.Err(); err != nil {
 		return err
 	}
 
 	var indexedRows int64
-	if err = row.Scan(&indexedRows); err != nil {
+	if err := row.Scan(&indexedRows); err != nil {
 		return err
 	}
 
-	sqlite.config.Logger.WithField("indexedRows", indexedRows).Infof("Successfully finished indexing")
+	builder.sqlite.config.Logger.WithField("indexedRows", indexedRows).Infof("Successfully finished indexing")
 
 	return nil
 }
@@ -260,6 +323,121 @@ func (sqlite *Fts5) GetRecordPositions(
 	}, nil
 }
 
+// Returns all the records matching the filters, which are the same as
+// GetRecordPositions, ordered from the most relevant to the least relevant.
+func (sqlite *Fts5) GetRankedRecords(
+	input input.Input,
+	filters map[string]interface{},
+	ranking Fts5Ranking,
+) ([]Fts5RankedRecord, error) {
+	if input != sqlite.input {
+		return nil, fmt.Errorf("This index does not handle the input '%v'.", input.Name())
+	}
+
+	if len(filters) != 1 {
+		return nil, fmt.Errorf("This index can only have one filter at a time.")
+	}
+	if _, filterExists := filters["match"]; !filterExists {
+		return nil, fmt.Errorf("This index must receive a single filter named 'match'.")
+	}
+
+	tableIdentifier, err := sqlite.getIndexTableIdentifier()
+	if err != nil {
+		return nil, err
+	}
+
+	// The first column is the offset, which is not indexed
+	weightPlaceholders := make([]string, 1+len(sqlite.config.Properties))
+	arguments := make([]interface{}, 0, len(weightPlaceholders)+len(ranking.Highlights)*6+1)
+	weightPlaceholders[0] = "?"
+	arguments = append(arguments, float64(0))
+	for propertyIndex, property := range sqlite.config.Properties {
+		weight, weightExists := ranking.Weights[property]
+		if !weightExists {
+			weight = 1
+		}
+		weightPlaceholders[propertyIndex+1] = "?"
+		arguments = append(arguments, weight)
+	}
+	for property := range ranking.Weights {
+		if sqlite.getColumnIndex(property) < 0 {
+			return nil, fmt.Errorf("The property '%v' is not indexed by the index '%v'.", property, sqlite.config.Name)
+		}
+	}
+
+	highlightColumns := ""
+	for _, highlight := range ranking.Highlights {
+		columnIndex := sqlite.getColumnIndex(highlight.Property)
+		if columnIndex < 0 {
+			return nil, fmt.Errorf("The property '%v' is not indexed by the index '%v'.", highlight.Property, sqlite.config.Name)
+		}
+
+		if highlight.Snippet {
+			highlightColumns += `, snippet(` + tableIdentifier + `, ?, ?, ?, ?, ?)`
+			arguments = append(arguments, columnIndex, highlight.Start, highlight.End, highlight.Ellipsis, highlight.Tokens)
+		} else {
+			highlightColumns += `, highlight(` + tableIdentifier + `, ?, ?, ?)`
+			arguments = append(arguments, columnIndex, highlight.Start, highlight.End)
+		}
+	}
+	arguments = append(arguments, filters["match"])
+
+	rows, err := sqlite.db.Query(`
+		SELECT
+			"__offset",
+			bm25(`+tableIdentifier+`, `+strings.Join(weightPlaceholders, ", ")+`) AS "score"
+			`+highlightColumns+`
+		FROM `+tableIdentifier+`
+		WHERE `+tableIdentifier+` MATCH ?
+		ORDER BY "score", "__offset"
+	`, arguments...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rankedRecords := make([]Fts5RankedRecord, 0)
+	for rows.Next() {
+		rankedRecord := Fts5RankedRecord{
+			Highlights: make([]interface{}, len(ranking.Highlights)),
+		}
+		destinations := []interface{}{&rankedRecord.Position, &rankedRecord.Score}
+		highlights := make([]sql.NullString, len(ranking.Highlights))
+		for i := range highlights {
+			destinations = append(destinations, &highlights[i])
+		}
+
+		if err := rows.Scan(destinations...); err != nil {
+			return nil, err
+		}
+
+		for i, highlight := range highlights {
+			if highlight.Valid {
+				rankedRecord.Highlights[i] = highlight.String
+			}
+		}
+
+		rankedRecords = append(rankedRecords, rankedRecord)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rankedRecords, nil
+}
+
+// Returns the index of the column of the property in
+// the fts5 table, or -1 if it is not indexed
+func (sqlite *Fts5) getColumnIndex(property string) int {
+	for propertyIndex, indexedProperty := range sqlite.config.Properties {
+		if indexedProperty == property {
+			return propertyIndex + 1
+		}
+	}
+
+	return -1
+}
+
 func (sqlite *Fts5) Close() error {
 	return sqlite.db.Close()
 }
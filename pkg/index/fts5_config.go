@@ -67,6 +67,24 @@ func (config *Fts5Config) DoesHandleProperty(propertyName string) bool {
 	return propertyName == "match"
 }
 
+func (config *Fts5Config) IsSearchFilter(propertyName string) bool {
+	return config.DoesHandleProperty(propertyName)
+}
+
+func (config *Fts5Config) GetPropertyReferences() []input.PropertyReference {
+	return input.NewPropertyReferences(config.Input, config.Properties, "properties")
+}
+
 func (config *Fts5Config) DoesHandleInput(input input.Config) bool {
 	return input.GetName() == config.Input
 }
+
+// The properties recorded in the metadata, to detect
+// when the index table does not match the configuration
+func (config *Fts5Config) getMetadataProperties() []string {
+	properties := make([]string, 0, len(config.Properties)+2)
+	properties = append(properties, config.Properties...)
+	properties = append(properties, "tokenize="+config.Tokenize, fmt.Sprintf("prefix=%v", config.Prefix))
+
+	return properties
+}
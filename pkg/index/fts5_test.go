@@ -8,6 +8,7 @@ import (
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/rodb-io/rodb/pkg/parser"
 	"github.com/sirupsen/logrus"
+	"reflect"
 	"testing"
 )
 
@@ -100,7 +101,7 @@ func TestFts5(t *testing.T) {
 		}
 		defer db.Close()
 
-		metadata, err := sqlitePackage.NewMetadata(db, "testIndex", inputs["input"])
+		metadata, err := sqlitePackage.NewMetadata(db, "testIndex", inputs["input"], config.getMetadataProperties())
 		if err != nil {
 			t.Fatalf("Unexpected error: '%v'", err)
 		}
@@ -268,3 +269,113 @@ func TestFts5GetRecordPositions(t *testing.T) {
 		}
 	})
 }
+
+func TestFts5GetRankedRecords(t *testing.T) {
+	mockInput := input.NewMock(parser.NewMock(), []record.Record{
+		record.NewStringPropertiesMockRecord(map[string]string{
+			"title":   "Cooking pasta",
+			"content": "Boil the water, then add the pasta and some salt",
+		}, 0),
+		record.NewStringPropertiesMockRecord(map[string]string{
+			"title":   "Salt",
+			"content": "Salt is a mineral. Too much salt is bad for your health",
+		}, 1),
+		record.NewStringPropertiesMockRecord(map[string]string{
+			"title":   "Water",
+			"content": "Drinking water is good for your health",
+		}, 2),
+	})
+	index, err := NewFts5(
+		&Fts5Config{
+			Name:       "testIndex",
+			Properties: []string{"title", "content"},
+			Dsn:        ":memory:",
+			Input:      "input",
+			Logger:     logrus.NewEntry(logrus.StandardLogger()),
+		},
+		input.List{
+			"input": mockInput,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getPositions := func(rankedRecords []Fts5RankedRecord) record.PositionList {
+		positions := make(record.PositionList, len(rankedRecords))
+		for i, rankedRecord := range rankedRecords {
+			positions[i] = rankedRecord.Position
+		}
+		return positions
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		rankedRecords, err := index.GetRankedRecords(mockInput, map[string]interface{}{"match": "salt"}, Fts5Ranking{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if expect, got := (record.PositionList{1, 0}), getPositions(rankedRecords); !reflect.DeepEqual(got, expect) {
+			t.Fatalf("Expected %v, got %v", expect, got)
+		}
+		if rankedRecords[0].Score >= rankedRecords[1].Score {
+			t.Fatalf("Expected the scores to be increasing, got %+v", rankedRecords)
+		}
+	})
+	t.Run("weights", func(t *testing.T) {
+		rankedRecords, err := index.GetRankedRecords(mockInput, map[string]interface{}{"match": "cooking OR drinking"}, Fts5Ranking{
+			Weights: map[string]float64{"title": 0, "content": 1},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if expect, got := (record.PositionList{2, 0}), getPositions(rankedRecords); !reflect.DeepEqual(got, expect) {
+			t.Fatalf("Expected %v, got %v", expect, got)
+		}
+
+		rankedRecords, err = index.GetRankedRecords(mockInput, map[string]interface{}{"match": "cooking OR drinking"}, Fts5Ranking{
+			Weights: map[string]float64{"title": 100},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if expect, got := (record.PositionList{0, 2}), getPositions(rankedRecords); !reflect.DeepEqual(got, expect) {
+			t.Fatalf("Expected %v, got %v", expect, got)
+		}
+	})
+	t.Run("highlights", func(t *testing.T) {
+		rankedRecords, err := index.GetRankedRecords(mockInput, map[string]interface{}{"match": "health"}, Fts5Ranking{
+			Highlights: []Fts5Highlight{
+				{Property: "title", Start: "<b>", End: "</b>"},
+				{Property: "content", Snippet: true, Start: "[", End: "]", Ellipsis: "...", Tokens: 4},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expect := []Fts5RankedRecord{
+			{Position: 2, Highlights: []interface{}{"Water", "...good for your [health]"}},
+			{Position: 1, Highlights: []interface{}{"Salt", "...bad for your [health]"}},
+		}
+		for i := range rankedRecords {
+			rankedRecords[i].Score = 0
+		}
+		if !reflect.DeepEqual(rankedRecords, expect) {
+			t.Fatalf("Expected %+v, got %+v", expect, rankedRecords)
+		}
+	})
+	t.Run("wrong property", func(t *testing.T) {
+		for _, ranking := range []Fts5Ranking{
+			{Weights: map[string]float64{"wrong": 1}},
+			{Highlights: []Fts5Highlight{{Property: "wrong"}}},
+		} {
+			if _, err := index.GetRankedRecords(mockInput, map[string]interface{}{"match": "salt"}, ranking); err == nil {
+				t.Fatalf("Expected an error for %+v, got nil", ranking)
+			}
+		}
+	})
+	t.Run("wrong filter", func(t *testing.T) {
+		if _, err := index.GetRankedRecords(mockInput, map[string]interface{}{"not_match": "salt"}, Fts5Ranking{}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
@@ -0,0 +1,460 @@
+package index
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/index/fuzzyfile"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/sirupsen/logrus"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Marks the start and end of the values, so that their
+// first and last characters are part of three trigrams.
+const fuzzyPadding = "\x00\x00"
+
+// Finds the values within a given edit (Levenshtein) distance of the
+// searched value. The candidate values are the ones sharing enough
+// trigrams with it, and their distance is then checked one by one.
+type Fuzzy struct {
+	config *FuzzyConfig
+	input  input.Input
+	index  map[string]*fuzzyfile.PropertyIndex
+}
+
+func NewFuzzy(
+	config *FuzzyConfig,
+	inputs input.List,
+) (*Fuzzy, error) {
+	fuzzy, indexBuilder, err := newFuzzy(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if indexBuilder != nil {
+		if err := build([]builder{indexBuilder}); err != nil {
+			return nil, fmt.Errorf("Error while creating the index: %w", err)
+		}
+	}
+
+	return fuzzy, nil
+}
+
+func newFuzzy(
+	config *FuzzyConfig,
+	inputs input.List,
+) (*Fuzzy, builder, error) {
+	input, inputExists := inputs[config.Input]
+	if !inputExists {
+		return nil, nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
+	}
+
+	fuzzy := &Fuzzy{
+		config: config,
+		input:  input,
+	}
+
+	loaded, err := fuzzy.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	if loaded {
+		return fuzzy, nil, nil
+	}
+
+	values := make(map[string]map[string]record.PositionList)
+	for _, property := range config.Properties {
+		values[property] = make(map[string]record.PositionList)
+	}
+
+	return fuzzy, &fuzzyBuilder{
+		fuzzy:  fuzzy,
+		values: values,
+	}, nil
+}
+
+// Loads the index from it's file. Returns false when the file
+// does not exist or cannot be used, meaning that the index must be rebuilt.
+func (fuzzy *Fuzzy) load() (bool, error) {
+	_, err := os.Stat(fuzzy.config.Path)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	index, err := fuzzyfile.Load(fuzzy.config.Path, fuzzy.getMetadataInput())
+	if err != nil {
+		fuzzy.config.Logger.Warnf("The index file cannot be loaded and will be rebuilt: %v", err)
+		return false, nil
+	}
+
+	fuzzy.index = index
+	fuzzy.config.Logger.Infof("Successfully loaded the index from '%v'", fuzzy.config.Path)
+
+	return true, nil
+}
+
+func (fuzzy *Fuzzy) save() error {
+	metadata, err := fuzzyfile.NewMetadata(fuzzy.getMetadataInput())
+	if err != nil {
+		return err
+	}
+
+	return fuzzyfile.Save(fuzzy.config.Path, metadata, fuzzy.index)
+}
+
+func (fuzzy *Fuzzy) getMetadataInput() fuzzyfile.MetadataInput {
+	return fuzzyfile.MetadataInput{
+		Input:      fuzzy.input,
+		Properties: fuzzy.config.Properties,
+		IgnoreCase: fuzzy.config.ShouldIgnoreCase(),
+	}
+}
+
+func (fuzzy *Fuzzy) Name() string {
+	return fuzzy.config.Name
+}
+
+type fuzzyBuilder struct {
+	fuzzy *Fuzzy
+	// The positions of each value, per property
+	values map[string]map[string]record.PositionList
+}
+
+func (builder *fuzzyBuilder) input() input.Input {
+	return builder.fuzzy.input
+}
+
+func (builder *fuzzyBuilder) logger() *logrus.Entry {
+	return builder.fuzzy.config.Logger
+}
+
+func (builder *fuzzyBuilder) add(record record.Record) error {
+	for _, property := range builder.fuzzy.config.Properties {
+		value, err := record.Get(property)
+		if err != nil {
+			return err
+		}
+
+		stringValues, err := builder.fuzzy.getStringValues(property, value)
+		if err != nil {
+			return err
+		}
+
+		propertyValues := builder.values[property]
+		for _, stringValue := range stringValues {
+			positions := propertyValues[stringValue]
+			// The same value can be found several times in an array
+			if len(positions) > 0 && positions[len(positions)-1] == record.Position() {
+				continue
+			}
+			propertyValues[stringValue] = append(positions, record.Position())
+		}
+	}
+
+	return nil
+}
+
+func (builder *fuzzyBuilder) finish() error {
+	index := make(map[string]*fuzzyfile.PropertyIndex, len(builder.values))
+	for property, propertyValues := range builder.values {
+		values := make([]string, 0, len(propertyValues))
+		for value := range propertyValues {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+
+		propertyIndex := &fuzzyfile.PropertyIndex{
+			Values:    values,
+			Positions: make([]record.PositionList, len(values)),
+			Trigrams:  make(map[string][]uint32),
+		}
+		for valueId, value := range values {
+			propertyIndex.Positions[valueId] = propertyValues[value]
+			for _, trigram := range getFuzzyTrigrams([]rune(value)) {
+				propertyIndex.Trigrams[trigram] = append(propertyIndex.Trigrams[trigram], uint32(valueId))
+			}
+		}
+
+		index[property] = propertyIndex
+	}
+
+	builder.fuzzy.index = index
+	builder.fuzzy.config.Logger.Infof("Successfully finished indexing")
+
+	if err := builder.fuzzy.save(); err != nil {
+		return fmt.Errorf("Error while saving the index: %w", err)
+	}
+
+	return nil
+}
+
+// Returns the normalized strings of the given value, which
+// can be a string or an array of strings. The null values
+// are not indexed, and cannot be found.
+func (fuzzy *Fuzzy) getStringValues(property string, value interface{}) ([]string, error) {
+	if value == nil {
+		return []string{}, nil
+	}
+
+	if valueArray, valueIsArray := value.([]interface{}); valueIsArray {
+		stringValues := make([]string, 0, len(valueArray))
+		for _, valueArrayValue := range valueArray {
+			arrayStringValues, err := fuzzy.getStringValues(property, valueArrayValue)
+			if err != nil {
+				return nil, err
+			}
+			stringValues = append(stringValues, arrayStringValues...)
+		}
+		return stringValues, nil
+	}
+
+	stringValue, valueIsString := value.(string)
+	if !valueIsString {
+		return nil, fmt.Errorf("Cannot index the value '%v' from property '%v' because it is not a string.", value, property)
+	}
+
+	return []string{fuzzy.normalize(stringValue)}, nil
+}
+
+func (fuzzy *Fuzzy) normalize(value string) string {
+	if fuzzy.config.ShouldIgnoreCase() {
+		return strings.ToLower(value)
+	}
+
+	return value
+}
+
+// Returns the distinct trigrams of the padded value
+func getFuzzyTrigrams(value []rune) []string {
+	padded := []rune(fuzzyPadding + string(value) + fuzzyPadding)
+	trigrams := make([]string, 0, len(padded)-2)
+	alreadyAdded := make(map[string]bool, len(padded)-2)
+	for i := 0; i+3 <= len(padded); i++ {
+		trigram := string(padded[i : i+3])
+		if !alreadyAdded[trigram] {
+			alreadyAdded[trigram] = true
+			trigrams = append(trigrams, trigram)
+		}
+	}
+
+	return trigrams
+}
+
+// Returns the edit distance between both values, or any
+// value greater than maxDistance if it exceeds it.
+func getFuzzyDistance(a []rune, b []rune, maxDistance int) int {
+	if len(a)-len(b) > maxDistance || len(b)-len(a) > maxDistance {
+		return maxDistance + 1
+	}
+
+	previousRow := make([]int, len(b)+1)
+	currentRow := make([]int, len(b)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		currentRow[0] = i
+		rowMinimum := currentRow[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			currentRow[j] = minInt(
+				previousRow[j]+1,
+				currentRow[j-1]+1,
+				previousRow[j-1]+cost,
+			)
+			if currentRow[j] < rowMinimum {
+				rowMinimum = currentRow[j]
+			}
+		}
+		if rowMinimum > maxDistance {
+			return maxDistance + 1
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(b)]
+}
+
+func minInt(values ...int) int {
+	minimum := values[0]
+	for _, value := range values[1:] {
+		if value < minimum {
+			minimum = value
+		}
+	}
+
+	return minimum
+}
+
+// Returns the identifiers of the values which may be within the
+// maximum distance. Each edit changes at most three trigrams, so
+// the other values cannot be within this distance.
+func (fuzzy *Fuzzy) getCandidates(propertyIndex *fuzzyfile.PropertyIndex, searchedValue []rune, maxDistance int) []uint32 {
+	trigrams := getFuzzyTrigrams(searchedValue)
+	minSharedTrigrams := len(trigrams) - 3*maxDistance
+
+	if minSharedTrigrams <= 0 {
+		candidates := make([]uint32, len(propertyIndex.Values))
+		for i := range candidates {
+			candidates[i] = uint32(i)
+		}
+		return candidates
+	}
+
+	sharedTrigrams := make(map[uint32]int)
+	for _, trigram := range trigrams {
+		for _, valueId := range propertyIndex.Trigrams[trigram] {
+			sharedTrigrams[valueId]++
+		}
+	}
+
+	candidates := make([]uint32, 0)
+	for valueId, count := range sharedTrigrams {
+		if count >= minSharedTrigrams {
+			candidates = append(candidates, valueId)
+		}
+	}
+
+	return candidates
+}
+
+func (fuzzy *Fuzzy) GetRecordPositions(
+	input input.Input,
+	filters map[string]interface{},
+) (record.PositionIterator, error) {
+	if input != fuzzy.input {
+		return nil, fmt.Errorf("This index does not handle the input '%v'.", input.Name())
+	}
+
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("This index requires at least one filter.")
+	}
+
+	individualFiltersResults := make([]record.PositionIterator, 0, len(filters))
+	for propertyName, filter := range filters {
+		if !fuzzy.config.DoesHandleProperty(propertyName) {
+			return nil, fmt.Errorf("This index does not handle the property '%v'.", propertyName)
+		}
+
+		propertyIndex, foundIndexedValues := fuzzy.index[propertyName]
+		if !foundIndexedValues {
+			return record.EmptyIterator, nil
+		}
+
+		stringFilter, filterIsString := filter.(string)
+		if !filterIsString {
+			return nil, fmt.Errorf("Cannot filter the value '%v' from property '%v' because it is not a string.", filter, propertyName)
+		}
+
+		searchedValue := []rune(fuzzy.normalize(stringFilter))
+		maxDistance := fuzzy.config.GetMaxDistance(searchedValue)
+
+		positions := make(record.PositionList, 0)
+		for _, valueId := range fuzzy.getCandidates(propertyIndex, searchedValue, maxDistance) {
+			value := []rune(propertyIndex.Values[valueId])
+			if getFuzzyDistance(searchedValue, value, maxDistance) <= maxDistance {
+				positions = append(positions, propertyIndex.Positions[valueId]...)
+			}
+		}
+
+		individualFiltersResults = append(individualFiltersResults, sortUniquePositions(positions).Iterate())
+	}
+
+	return record.JoinPositionIterators(individualFiltersResults...), nil
+}
+
+func sortUniquePositions(positions record.PositionList) record.PositionList {
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i] < positions[j]
+	})
+
+	uniquePositions := positions[:0]
+	for i, position := range positions {
+		if i == 0 || position != positions[i-1] {
+			uniquePositions = append(uniquePositions, position)
+		}
+	}
+
+	return uniquePositions
+}
+
+// Returns the number of edits between the record and the searched
+// values, summed for all the filtered properties. When a property
+// contains several values, the nearest one is used. Returns nil
+// when the record has no value for the filtered properties.
+func (fuzzy *Fuzzy) GetDistance(record record.Record, filters map[string]interface{}) (*float64, error) {
+	var distance *float64
+	for propertyName, filter := range filters {
+		if !fuzzy.config.DoesHandleProperty(propertyName) {
+			continue
+		}
+
+		stringFilter, filterIsString := filter.(string)
+		if !filterIsString {
+			return nil, fmt.Errorf("Cannot filter the value '%v' from property '%v' because it is not a string.", filter, propertyName)
+		}
+		searchedValue := []rune(fuzzy.normalize(stringFilter))
+
+		value, err := record.Get(propertyName)
+		if err != nil {
+			return nil, err
+		}
+		stringValues, err := fuzzy.getStringValues(propertyName, value)
+		if err != nil {
+			return nil, err
+		}
+
+		var propertyDistance *int
+		for _, stringValue := range stringValues {
+			valueRunes := []rune(stringValue)
+			valueDistance := getFuzzyDistance(searchedValue, valueRunes, len(searchedValue)+len(valueRunes))
+			if propertyDistance == nil || valueDistance < *propertyDistance {
+				propertyDistance = &valueDistance
+			}
+		}
+		if propertyDistance == nil {
+			continue
+		}
+
+		if distance == nil {
+			distance = new(float64)
+		}
+		*distance += float64(*propertyDistance)
+	}
+
+	return distance, nil
+}
+
+// Returns the distances of the records at the given positions,
+// computed as GetDistance, which requires reading them
+func (fuzzy *Fuzzy) GetDistances(positions record.PositionList, filters map[string]interface{}) (map[record.Position]float64, error) {
+	distances := make(map[record.Position]float64, len(positions))
+	for _, position := range positions {
+		positionRecord, err := fuzzy.input.Get(position)
+		if err != nil {
+			return nil, err
+		}
+
+		distance, err := fuzzy.GetDistance(positionRecord, filters)
+		if err != nil {
+			return nil, err
+		}
+		if distance != nil {
+			distances[position] = *distance
+		}
+	}
+
+	return distances, nil
+}
+
+func (fuzzy *Fuzzy) Close() error {
+	return nil
+}
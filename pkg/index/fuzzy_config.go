@@ -0,0 +1,116 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/sirupsen/logrus"
+	"os"
+)
+
+type FuzzyConfig struct {
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type"`
+	Path        string   `yaml:"path"`
+	Input       string   `yaml:"input"`
+	Properties  []string `yaml:"properties"`
+	IgnoreCase  *bool    `yaml:"ignoreCase"`
+	MaxDistance *int     `yaml:"maxDistance"`
+	Logger      *logrus.Entry
+}
+
+func (config *FuzzyConfig) ShouldIgnoreCase() bool {
+	return config.IgnoreCase != nil && *config.IgnoreCase
+}
+
+func (config *FuzzyConfig) Validate(inputs map[string]input.Config, log *logrus.Entry) error {
+	config.Logger = log
+
+	if config.Name == "" {
+		return errors.New("fuzzy.name is required")
+	}
+
+	if config.Path == "" {
+		return errors.New("fuzzy.path is required")
+	}
+	fileInfo, err := os.Stat(config.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("fuzzy.path: Error checking the path: %w", err)
+	}
+	if err == nil && fileInfo.IsDir() {
+		return errors.New("fuzzy.path: This path already exists and is a directory")
+	}
+
+	_, inputExists := inputs[config.Input]
+	if !inputExists {
+		return fmt.Errorf("fuzzy.input: Input '%v' not found in inputs list.", config.Input)
+	}
+
+	alreadyExistingProperties := make(map[string]bool)
+	for _, propertyName := range config.Properties {
+		if _, alreadyExists := alreadyExistingProperties[propertyName]; alreadyExists {
+			return fmt.Errorf("fuzzy.properties: Duplicate property '%v' in array.", propertyName)
+		}
+		alreadyExistingProperties[propertyName] = true
+	}
+
+	if config.IgnoreCase == nil {
+		log.Debug("fuzzy.ignoreCase not set. Assuming true")
+		trueValue := true
+		config.IgnoreCase = &trueValue
+	}
+
+	if config.MaxDistance == nil {
+		log.Debug("fuzzy.maxDistance not set. The maximum distance will depend on the length of the searched value")
+	} else if *config.MaxDistance < 0 {
+		return errors.New("fuzzy.maxDistance must be positive or zero")
+	}
+
+	// The properties will be validated at runtime
+
+	return nil
+}
+
+// Returns the maximum number of edits for the searched value
+func (config *FuzzyConfig) GetMaxDistance(searchedValue []rune) int {
+	if config.MaxDistance != nil {
+		return *config.MaxDistance
+	}
+
+	switch {
+	case len(searchedValue) <= 2:
+		return 0
+	case len(searchedValue) <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (config *FuzzyConfig) GetName() string {
+	return config.Name
+}
+
+func (config *FuzzyConfig) DoesHandleProperty(property string) bool {
+	isHandled := false
+	for _, handledProperty := range config.Properties {
+		if property == handledProperty {
+			isHandled = true
+			break
+		}
+	}
+
+	return isHandled
+}
+
+func (config *FuzzyConfig) GetPropertyReferences() []input.PropertyReference {
+	return input.NewPropertyReferences(config.Input, config.Properties, "properties")
+}
+
+func (config *FuzzyConfig) HasDistance() bool {
+	return true
+}
+
+func (config *FuzzyConfig) DoesHandleInput(input input.Config) bool {
+	return input.GetName() == config.Input
+}
@@ -0,0 +1,264 @@
+package index
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func getFuzzyPositionsForTests(t *testing.T, index *Fuzzy, mockInput input.Input, filters map[string]interface{}) record.PositionList {
+	nextPosition, err := index.GetRecordPositions(mockInput, filters)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	positions := make(record.PositionList, 0)
+	for {
+		position, err := nextPosition()
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if position == nil {
+			break
+		}
+		positions = append(positions, *position)
+	}
+
+	return positions
+}
+
+func TestFuzzy(t *testing.T) {
+	t.Run("persisted", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.rodb")
+		mockInput := input.NewMock(parser.NewMock(), []record.Record{
+			record.NewStringPropertiesMockRecord(map[string]string{"word": "apple"}, 0),
+			record.NewStringPropertiesMockRecord(map[string]string{"word": "banana"}, 1),
+		})
+		trueValue := true
+		config := &FuzzyConfig{
+			Properties: []string{"word"},
+			Input:      "input",
+			Path:       path,
+			IgnoreCase: &trueValue,
+			Logger:     logrus.NewEntry(logrus.StandardLogger()),
+		}
+
+		if _, err := NewFuzzy(config, input.List{"input": mockInput}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("Expected the index file to exist, got '%v'", err)
+		}
+
+		// The input data is changed without changing it's size and date,
+		// to make sure that the index is loaded instead of being rebuilt
+		emptyInput := input.NewMock(parser.NewMock(), make([]record.Record, 2))
+		index, err := NewFuzzy(config, input.List{"input": emptyInput})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := getFuzzyPositionsForTests(t, index, emptyInput, map[string]interface{}{"word": "banan"})
+		if expect := (record.PositionList{1}); !reflect.DeepEqual(got, expect) {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("not a string", func(t *testing.T) {
+		falseValue := false
+		_, err := NewFuzzy(
+			&FuzzyConfig{
+				Properties: []string{"word"},
+				Input:      "input",
+				Path:       filepath.Join(t.TempDir(), "index.rodb"),
+				IgnoreCase: &falseValue,
+				Logger:     logrus.NewEntry(logrus.StandardLogger()),
+			},
+			input.List{
+				"input": input.NewMock(parser.NewMock(), []record.Record{
+					record.NewMockRecord(map[string]string{}, map[string]int{"word": 1}, map[string]float64{}, map[string]bool{}, 0),
+				}),
+			},
+		)
+		if err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
+
+func TestFuzzyGetRecordPositions(t *testing.T) {
+	intPointer := func(value int) *int {
+		return &value
+	}
+
+	mockInput := input.NewMock(parser.NewMock(), []record.Record{
+		record.NewStringPropertiesMockRecord(map[string]string{"word": "Necessary", "language": "en"}, 0),
+		record.NewStringPropertiesMockRecord(map[string]string{"word": "accommodate", "language": "en"}, 1),
+		record.NewStringPropertiesMockRecord(map[string]string{"word": "nécessaire", "language": "fr"}, 2),
+		record.NewStringPropertiesMockRecord(map[string]string{"word": "cat", "language": "en"}, 3),
+		record.NewStringPropertiesMockRecord(map[string]string{"word": "cot", "language": "en"}, 4),
+		record.NewStringPropertiesMockRecord(map[string]string{"word": "accommodate", "language": "fr"}, 5),
+	})
+	trueValue := true
+	config := &FuzzyConfig{
+		Properties: []string{"word", "language"},
+		Input:      "input",
+		Path:       filepath.Join(t.TempDir(), "index.rodb"),
+		IgnoreCase: &trueValue,
+		Logger:     logrus.NewEntry(logrus.StandardLogger()),
+	}
+	index, err := NewFuzzy(config, input.List{"input": mockInput})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		name        string
+		maxDistance *int
+		filters     map[string]interface{}
+		expect      record.PositionList
+	}{
+		{"exact", nil, map[string]interface{}{"word": "necessary"}, record.PositionList{0}},
+		{"ignore case", nil, map[string]interface{}{"word": "NECESSARY"}, record.PositionList{0}},
+		{"missing letter", nil, map[string]interface{}{"word": "neccessary"}, record.PositionList{0}},
+		{"two typos", nil, map[string]interface{}{"word": "acommodat"}, record.PositionList{1, 5}},
+		{"too many typos", nil, map[string]interface{}{"word": "acomodat"}, record.PositionList{}},
+		{"unicode", nil, map[string]interface{}{"word": "nécesaire"}, record.PositionList{2}},
+		{"short value", nil, map[string]interface{}{"word": "cit"}, record.PositionList{3, 4}},
+		{"very short value", nil, map[string]interface{}{"word": "ca"}, record.PositionList{}},
+		{"configured distance", intPointer(1), map[string]interface{}{"word": "acommodate"}, record.PositionList{1, 5}},
+		{"configured distance exceeded", intPointer(1), map[string]interface{}{"word": "acommodat"}, record.PositionList{}},
+		{"zero distance", intPointer(0), map[string]interface{}{"word": "cat"}, record.PositionList{3}},
+		{"several properties", nil, map[string]interface{}{"word": "acommodate", "language": "fr"}, record.PositionList{5}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			config.MaxDistance = testCase.maxDistance
+			defer func() { config.MaxDistance = nil }()
+
+			if got := getFuzzyPositionsForTests(t, index, mockInput, testCase.filters); !reflect.DeepEqual(got, testCase.expect) {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, filters := range []map[string]interface{}{
+			{},
+			{"word": int64(1)},
+			{"wrong": "cat"},
+		} {
+			if _, err := index.GetRecordPositions(mockInput, filters); err == nil {
+				t.Fatalf("Expected an error for '%v', got nil", filters)
+			}
+		}
+	})
+	t.Run("same as brute force", func(t *testing.T) {
+		random := rand.New(rand.NewSource(42))
+		randomWord := func() string {
+			word := make([]rune, 1+random.Intn(8))
+			for i := range word {
+				word[i] = []rune("abcdé")[random.Intn(5)]
+			}
+			return string(word)
+		}
+
+		words := make([]string, 500)
+		records := make([]record.Record, len(words))
+		for i := range records {
+			words[i] = randomWord()
+			records[i] = record.NewStringPropertiesMockRecord(map[string]string{"word": words[i]}, record.Position(i))
+		}
+		randomInput := input.NewMock(parser.NewMock(), records)
+		randomIndex, err := NewFuzzy(
+			&FuzzyConfig{
+				Properties: []string{"word"},
+				Input:      "input",
+				Path:       filepath.Join(t.TempDir(), "random.rodb"),
+				IgnoreCase: &trueValue,
+				Logger:     logrus.NewEntry(logrus.StandardLogger()),
+			},
+			input.List{"input": randomInput},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 100; i++ {
+			searched := []rune(randomWord())
+			maxDistance := randomIndex.config.GetMaxDistance(searched)
+
+			expect := make(record.PositionList, 0)
+			for position, word := range words {
+				if getFuzzyDistance(searched, []rune(word), 100) <= maxDistance {
+					expect = append(expect, record.Position(position))
+				}
+			}
+
+			got := getFuzzyPositionsForTests(t, randomIndex, randomInput, map[string]interface{}{
+				"word": string(searched),
+			})
+			if !reflect.DeepEqual(got, expect) {
+				t.Fatalf("Expected '%v' for '%v', got '%v'", expect, string(searched), got)
+			}
+		}
+	})
+}
+
+func TestFuzzyGetDistance(t *testing.T) {
+	floatPointer := func(value float64) *float64 {
+		return &value
+	}
+
+	trueValue := true
+	index := &Fuzzy{
+		config: &FuzzyConfig{
+			Properties: []string{"word", "language"},
+			IgnoreCase: &trueValue,
+		},
+	}
+	necessary := record.NewStringPropertiesMockRecord(map[string]string{"word": "Necessary", "language": "en"}, 0)
+
+	for _, testCase := range []struct {
+		name    string
+		filters map[string]interface{}
+		expect  *float64
+	}{
+		{"exact", map[string]interface{}{"word": "necessary"}, floatPointer(0)},
+		{"typos", map[string]interface{}{"word": "nesesary"}, floatPointer(2)},
+		{"several properties", map[string]interface{}{"word": "nesesary", "language": "fr"}, floatPointer(4)},
+		{"no filter", map[string]interface{}{}, nil},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := index.GetDistance(necessary, testCase.filters)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if !reflect.DeepEqual(got, testCase.expect) {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestGetFuzzyDistance(t *testing.T) {
+	for _, testCase := range []struct {
+		a           string
+		b           string
+		maxDistance int
+		expect      int
+	}{
+		{"kitten", "sitting", 10, 3},
+		{"kitten", "sitting", 1, 2},
+		{"", "abc", 10, 3},
+		{"abc", "abc", 0, 0},
+		{"né", "ne", 10, 1},
+	} {
+		if got := getFuzzyDistance([]rune(testCase.a), []rune(testCase.b), testCase.maxDistance); got != testCase.expect {
+			t.Fatalf("Expected '%v' for '%v' and '%v', got '%v'", testCase.expect, testCase.a, testCase.b, got)
+		}
+	}
+}
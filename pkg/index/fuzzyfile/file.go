@@ -0,0 +1,191 @@
+package fuzzyfile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"github.com/rodb-io/rodb/pkg/index/indexfile"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"io"
+	"os"
+	"sort"
+)
+
+// Index for the values of a single property
+type PropertyIndex struct {
+	// The distinct indexed values
+	Values []string
+	// The positions of the records having each value
+	Positions []record.PositionList
+	// The identifiers (index in Values) of the values containing each trigram
+	Trigrams map[string][]uint32
+}
+
+// Writes the index to the given path. The data is written to a temporary
+// file first, so that an interrupted process never leaves a partial file.
+func Save(path string, metadata *Metadata, index map[string]*PropertyIndex) error {
+	temporaryPath := path + ".tmp"
+	file, err := os.Create(temporaryPath)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	if err := write(writer, metadata, index); err != nil {
+		file.Close()
+		os.Remove(temporaryPath)
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		os.Remove(temporaryPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(temporaryPath)
+		return err
+	}
+
+	return os.Rename(temporaryPath, path)
+}
+
+func write(data io.Writer, metadata *Metadata, index map[string]*PropertyIndex) error {
+	if err := metadata.Serialize(data); err != nil {
+		return err
+	}
+
+	for _, property := range metadata.Properties() {
+		propertyIndex := index[property]
+
+		if err := binary.Write(data, binary.BigEndian, int64(len(propertyIndex.Values))); err != nil {
+			return err
+		}
+		for i, value := range propertyIndex.Values {
+			if err := indexfile.WriteString(data, value); err != nil {
+				return err
+			}
+			if err := binary.Write(data, binary.BigEndian, int64(len(propertyIndex.Positions[i]))); err != nil {
+				return err
+			}
+			if err := binary.Write(data, binary.BigEndian, []record.Position(propertyIndex.Positions[i])); err != nil {
+				return err
+			}
+		}
+
+		// Sorted, so that the same index always produces the same file
+		trigrams := make([]string, 0, len(propertyIndex.Trigrams))
+		for trigram := range propertyIndex.Trigrams {
+			trigrams = append(trigrams, trigram)
+		}
+		sort.Strings(trigrams)
+
+		if err := binary.Write(data, binary.BigEndian, int64(len(trigrams))); err != nil {
+			return err
+		}
+		for _, trigram := range trigrams {
+			if err := indexfile.WriteString(data, trigram); err != nil {
+				return err
+			}
+			valueIds := propertyIndex.Trigrams[trigram]
+			if err := binary.Write(data, binary.BigEndian, int64(len(valueIds))); err != nil {
+				return err
+			}
+			if err := binary.Write(data, binary.BigEndian, valueIds); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Loads only the metadata of the index file
+func LoadMetadata(path string) (*Metadata, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	metadata, err := unserializeMetadata(bufio.NewReader(file))
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// Loads the index from the given path, after checking
+// that it matches the expected input and configuration
+func Load(path string, expect MetadataInput) (map[string]*PropertyIndex, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	metadata, err := unserializeMetadata(reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.AssertValid(expect); err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]*PropertyIndex, len(metadata.Properties()))
+	for _, property := range metadata.Properties() {
+		var valuesCount int64
+		if err := binary.Read(reader, binary.BigEndian, &valuesCount); err != nil {
+			return nil, err
+		}
+
+		propertyIndex := &PropertyIndex{
+			Values:    make([]string, int(valuesCount)),
+			Positions: make([]record.PositionList, int(valuesCount)),
+		}
+		for i := range propertyIndex.Values {
+			value, err := indexfile.ReadString(reader)
+			if err != nil {
+				return nil, err
+			}
+			propertyIndex.Values[i] = value
+
+			var positionsCount int64
+			if err := binary.Read(reader, binary.BigEndian, &positionsCount); err != nil {
+				return nil, err
+			}
+			positions := make([]record.Position, int(positionsCount))
+			if err := binary.Read(reader, binary.BigEndian, positions); err != nil {
+				return nil, err
+			}
+			propertyIndex.Positions[i] = positions
+		}
+
+		var trigramsCount int64
+		if err := binary.Read(reader, binary.BigEndian, &trigramsCount); err != nil {
+			return nil, err
+		}
+		propertyIndex.Trigrams = make(map[string][]uint32, int(trigramsCount))
+		for i := int64(0); i < trigramsCount; i++ {
+			trigram, err := indexfile.ReadString(reader)
+			if err != nil {
+				return nil, err
+			}
+
+			var valueIdsCount int64
+			if err := binary.Read(reader, binary.BigEndian, &valueIdsCount); err != nil {
+				return nil, err
+			}
+			valueIds := make([]uint32, int(valueIdsCount))
+			if err := binary.Read(reader, binary.BigEndian, valueIds); err != nil {
+				return nil, err
+			}
+			propertyIndex.Trigrams[trigram] = valueIds
+		}
+
+		index[property] = propertyIndex
+	}
+
+	return index, nil
+}
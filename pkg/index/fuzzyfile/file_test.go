@@ -0,0 +1,98 @@
+package fuzzyfile
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.rodb")
+
+	mockInput := input.NewMock(parser.NewMock(), make([]record.Record, 42))
+	mockInput.SetModTime(time.Unix(1234, 0))
+	metadataInput := MetadataInput{
+		Input:      mockInput,
+		Properties: []string{"a", "b"},
+		IgnoreCase: true,
+	}
+
+	index := map[string]*PropertyIndex{
+		"a": {
+			Values:    []string{"cat", "cot"},
+			Positions: []record.PositionList{{1, 2}, {3}},
+			Trigrams: map[string][]uint32{
+				"\x00\x00c": {0, 1},
+				"cat":       {0},
+			},
+		},
+		"b": {
+			Values:    []string{},
+			Positions: []record.PositionList{},
+			Trigrams:  map[string][]uint32{},
+		},
+	}
+
+	metadata, err := NewMetadata(metadataInput)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if err := Save(path, metadata, index); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("Expected the temporary file to be removed, got '%v'", err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		loaded, err := Load(path, metadataInput)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if !reflect.DeepEqual(index, loaded) {
+			t.Fatalf("Expected '%v', got '%v'", index, loaded)
+		}
+	})
+	t.Run("modified input", func(t *testing.T) {
+		mockInput.SetModTime(time.Unix(1235, 0))
+		defer mockInput.SetModTime(time.Unix(1234, 0))
+
+		if _, err := Load(path, metadataInput); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("different properties", func(t *testing.T) {
+		if _, err := Load(path, MetadataInput{
+			Input:      mockInput,
+			Properties: []string{"b", "a"},
+			IgnoreCase: true,
+		}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("different case sensitivity", func(t *testing.T) {
+		if _, err := Load(path, MetadataInput{
+			Input:      mockInput,
+			Properties: []string{"a", "b"},
+			IgnoreCase: false,
+		}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("different parser", func(t *testing.T) {
+		otherInput := input.NewMock(parser.NewInteger(&parser.IntegerConfig{}), make([]record.Record, 42))
+		otherInput.SetModTime(time.Unix(1234, 0))
+		if _, err := Load(path, MetadataInput{
+			Input:      otherInput,
+			Properties: []string{"a", "b"},
+			IgnoreCase: true,
+		}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package fuzzyfile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/index/indexfile"
+	"github.com/rodb-io/rodb/pkg/input"
+	"io"
+)
+
+// Current version of the file format
+const CurrentVersion = uint16(2)
+
+// Default magic bytes
+const ExpectedMagicBytes = "RODB/INDEX/FUZZY"
+
+var format = indexfile.Format{
+	Name:       "fuzzy",
+	MagicBytes: ExpectedMagicBytes,
+	Version:    CurrentVersion,
+}
+
+// The common metadata of the index files, followed by the fuzzy options
+type Metadata struct {
+	common     *indexfile.Metadata
+	ignoreCase bool
+}
+
+type MetadataInput struct {
+	Input      input.Input
+	Properties []string
+	IgnoreCase bool
+}
+
+func (input MetadataInput) getCommon() indexfile.MetadataInput {
+	return indexfile.MetadataInput{
+		Input:      input.Input,
+		Properties: input.Properties,
+	}
+}
+
+func NewMetadata(input MetadataInput) (*Metadata, error) {
+	common, err := indexfile.NewMetadata(format, input.getCommon())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metadata{
+		common:     common,
+		ignoreCase: input.IgnoreCase,
+	}, nil
+}
+
+// The indexed properties, in the order in which they are stored
+func (metadata *Metadata) Properties() []string {
+	return metadata.common.Properties()
+}
+
+func (metadata *Metadata) Serialize(data io.Writer) error {
+	if err := metadata.common.Serialize(data); err != nil {
+		return err
+	}
+
+	return binary.Write(data, binary.BigEndian, metadata.ignoreCase)
+}
+
+func unserializeMetadata(data io.Reader) (*Metadata, error) {
+	common, err := indexfile.Unserialize(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{common: common}
+	if err := binary.Read(data, binary.BigEndian, &metadata.ignoreCase); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// Validates that the metadata of the file is an RODB fuzzy index
+// and matches the given configuration as well as the current version
+func (metadata *Metadata) AssertValid(expect MetadataInput) error {
+	if err := metadata.common.AssertValid(expect.getCommon()); err != nil {
+		return err
+	}
+
+	if metadata.ignoreCase != expect.IgnoreCase {
+		return fmt.Errorf("The configured ignoreCase does not match the index file contents.")
+	}
+
+	return nil
+}
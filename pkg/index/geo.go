@@ -0,0 +1,566 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/index/geofile"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"math"
+	"os"
+	"sort"
+)
+
+// The maximum number of grid cells scanned for each searched box.
+// A higher number scans less points outside of the box,
+// but requires more lookups.
+const geoMaxCellsPerBox = 16
+
+// Indexes geographic points, by storing them in the order of a
+// Z-order curve (the same as a geohash), so that the points of the
+// same area are stored together.
+type Geo struct {
+	config          *GeoConfig
+	input           input.Input
+	entries         []geofile.Entry
+	entryByPosition map[record.Position]int
+}
+
+// A box in which the points are searched, which
+// does not cross the antimeridian
+type geoBox struct {
+	minLatitude  float64
+	maxLatitude  float64
+	minLongitude float64
+	maxLongitude float64
+}
+
+func NewGeo(
+	config *GeoConfig,
+	inputs input.List,
+) (*Geo, error) {
+	geo, indexBuilder, err := newGeo(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if indexBuilder != nil {
+		if err := build([]builder{indexBuilder}); err != nil {
+			return nil, err
+		}
+	}
+
+	return geo, nil
+}
+
+func newGeo(
+	config *GeoConfig,
+	inputs input.List,
+) (*Geo, builder, error) {
+	input, inputExists := inputs[config.Input]
+	if !inputExists {
+		return nil, nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
+	}
+
+	geo := &Geo{
+		config: config,
+		input:  input,
+	}
+
+	if config.Path != "" {
+		loaded, err := geo.load()
+		if err != nil {
+			return nil, nil, err
+		}
+		if loaded {
+			return geo, nil, nil
+		}
+	}
+
+	return geo, &geoBuilder{
+		geo:     geo,
+		entries: make([]geofile.Entry, 0),
+	}, nil
+}
+
+// Loads the index from it's file. Returns false when the file
+// does not exist or cannot be used, meaning that the index must be rebuilt.
+func (geo *Geo) load() (bool, error) {
+	_, err := os.Stat(geo.config.Path)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	entries, err := geofile.Load(geo.config.Path, geo.getMetadataInput())
+	if err != nil {
+		geo.config.Logger.Warnf("The index file cannot be loaded and will be rebuilt: %v", err)
+		return false, nil
+	}
+
+	geo.setEntries(entries)
+	geo.config.Logger.Infof("Successfully loaded the index from '%v'", geo.config.Path)
+
+	return true, nil
+}
+
+// The entries must be sorted by code
+func (geo *Geo) setEntries(entries []geofile.Entry) {
+	geo.entries = entries
+	geo.entryByPosition = make(map[record.Position]int, len(entries))
+	for i, entry := range entries {
+		geo.entryByPosition[entry.Position] = i
+	}
+}
+
+func (geo *Geo) save() error {
+	metadata, err := geofile.NewMetadata(geo.getMetadataInput())
+	if err != nil {
+		return err
+	}
+
+	return geofile.Save(geo.config.Path, metadata, geo.entries)
+}
+
+func (geo *Geo) getMetadataInput() geofile.MetadataInput {
+	return geofile.MetadataInput{
+		Input:      geo.input,
+		Properties: geo.config.Properties(),
+	}
+}
+
+func (geo *Geo) Name() string {
+	return geo.config.Name
+}
+
+type geoBuilder struct {
+	geo     *Geo
+	entries []geofile.Entry
+}
+
+func (builder *geoBuilder) input() input.Input {
+	return builder.geo.input
+}
+
+func (builder *geoBuilder) logger() *logrus.Entry {
+	return builder.geo.config.Logger
+}
+
+func (builder *geoBuilder) add(record record.Record) error {
+	point, err := builder.geo.getRecordPoint(record)
+	if err != nil {
+		return err
+	}
+	if point == nil {
+		return nil
+	}
+
+	builder.entries = append(builder.entries, geofile.Entry{
+		Code:      geoCode(point.Latitude(), point.Longitude()),
+		Latitude:  point.Latitude(),
+		Longitude: point.Longitude(),
+		Position:  record.Position(),
+	})
+
+	return nil
+}
+
+func (builder *geoBuilder) finish() error {
+	sort.SliceStable(builder.entries, func(i, j int) bool {
+		return builder.entries[i].Code < builder.entries[j].Code
+	})
+
+	builder.geo.setEntries(builder.entries)
+	builder.geo.config.Logger.Infof("Successfully finished indexing")
+
+	if builder.geo.config.Path != "" {
+		if err := builder.geo.save(); err != nil {
+			return fmt.Errorf("Error while saving the index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Returns the point of the record, or nil if it does not have one
+func (geo *Geo) getRecordPoint(record record.Record) (*parser.GeoPointValue, error) {
+	if geo.config.Property != "" {
+		value, err := record.Get(geo.config.Property)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, nil
+		}
+
+		point, isPoint := value.(parser.GeoPointValue)
+		if !isPoint {
+			return nil, fmt.Errorf("Cannot index the property '%v': The value '%v' is not a geographic point.", geo.config.Property, value)
+		}
+
+		return &point, nil
+	}
+
+	latitude, err := getGeoRecordCoordinate(record, geo.config.LatitudeProperty)
+	if err != nil || latitude == nil {
+		return nil, err
+	}
+
+	longitude, err := getGeoRecordCoordinate(record, geo.config.LongitudeProperty)
+	if err != nil || longitude == nil {
+		return nil, err
+	}
+
+	point, err := parser.NewGeoPointValue(*latitude, *longitude)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot index the properties '%v' and '%v': %w", geo.config.LatitudeProperty, geo.config.LongitudeProperty, err)
+	}
+
+	return &point, nil
+}
+
+func getGeoRecordCoordinate(record record.Record, property string) (*float64, error) {
+	value, err := record.Get(property)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	coordinate, err := getGeoNumber(value)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot index the property '%v': %w", property, err)
+	}
+
+	return &coordinate, nil
+}
+
+func getGeoNumber(value interface{}) (float64, error) {
+	switch value.(type) {
+	case float64:
+		return value.(float64), nil
+	case int64:
+		return float64(value.(int64)), nil
+	case int:
+		return float64(value.(int)), nil
+	case parser.DecimalValue:
+		number, _ := value.(parser.DecimalValue).Rat().Float64()
+		return number, nil
+	default:
+		return 0, fmt.Errorf("The value '%v' is not a number.", value)
+	}
+}
+
+// Returns the point of the filters, or nil if there is none
+func getGeoFilterPoint(filters map[string]interface{}, filterName string) (*parser.GeoPointValue, error) {
+	value, exists := filters[filterName]
+	if !exists {
+		return nil, nil
+	}
+
+	point, isPoint := value.(parser.GeoPointValue)
+	if !isPoint {
+		return nil, fmt.Errorf("The '%v' filter must be a geographic point, got '%v'.", filterName, value)
+	}
+
+	return &point, nil
+}
+
+func (geo *Geo) GetRecordPositions(
+	input input.Input,
+	filters map[string]interface{},
+) (record.PositionIterator, error) {
+	if input != geo.input {
+		return nil, fmt.Errorf("This index does not handle the input '%v'.", input.Name())
+	}
+
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("This index requires at least one filter.")
+	}
+
+	for propertyName := range filters {
+		if !geo.config.DoesHandleProperty(propertyName) {
+			return nil, fmt.Errorf("This index does not handle the property '%v'.", propertyName)
+		}
+	}
+
+	accept, boxes, err := getGeoSearch(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(record.PositionList, 0)
+	for _, box := range boxes {
+		for _, codeRange := range box.getCodeRanges() {
+			i := sort.Search(len(geo.entries), func(i int) bool {
+				return geo.entries[i].Code >= codeRange[0]
+			})
+			for ; i < len(geo.entries) && geo.entries[i].Code <= codeRange[1]; i++ {
+				entry := geo.entries[i]
+				if box.contains(entry.Latitude, entry.Longitude) && accept(entry.Latitude, entry.Longitude) {
+					positions = append(positions, entry.Position)
+				}
+			}
+		}
+	}
+
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i] < positions[j]
+	})
+
+	return positions.Iterate(), nil
+}
+
+// Returns a function checking if the point matches the filters,
+// and the boxes that contain all the matching points
+func getGeoSearch(filters map[string]interface{}) (func(latitude float64, longitude float64) bool, []geoBox, error) {
+	point, err := getGeoFilterPoint(filters, "point")
+	if err != nil {
+		return nil, nil, err
+	}
+	southWest, err := getGeoFilterPoint(filters, "southWest")
+	if err != nil {
+		return nil, nil, err
+	}
+	northEast, err := getGeoFilterPoint(filters, "northEast")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	radiusValue, radiusExists := filters["radius"]
+	if (point == nil) != !radiusExists {
+		return nil, nil, errors.New("The 'point' and 'radius' filters must be used together.")
+	}
+	if (southWest == nil) != (northEast == nil) {
+		return nil, nil, errors.New("The 'southWest' and 'northEast' filters must be used together.")
+	}
+
+	var radius float64
+	if radiusExists {
+		radius, err = getGeoNumber(radiusValue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("The 'radius' filter is invalid: %w", err)
+		}
+		if radius < 0 || math.IsNaN(radius) {
+			return nil, nil, fmt.Errorf("The 'radius' filter cannot be negative.")
+		}
+	}
+
+	if southWest != nil && southWest.Latitude() > northEast.Latitude() {
+		return nil, nil, errors.New("The 'southWest' point must be south of the 'northEast' point.")
+	}
+
+	accept := func(latitude float64, longitude float64) bool {
+		if southWest != nil && !geoBoundsContain(*southWest, *northEast, latitude, longitude) {
+			return false
+		}
+		if point != nil {
+			entryPoint, err := parser.NewGeoPointValue(latitude, longitude)
+			if err != nil || point.DistanceTo(entryPoint) > radius {
+				return false
+			}
+		}
+		return true
+	}
+
+	if point != nil {
+		return accept, getGeoRadiusBoxes(*point, radius), nil
+	}
+
+	return accept, getGeoBoundsBoxes(*southWest, *northEast), nil
+}
+
+func geoBoundsContain(southWest parser.GeoPointValue, northEast parser.GeoPointValue, latitude float64, longitude float64) bool {
+	for _, box := range getGeoBoundsBoxes(southWest, northEast) {
+		if box.contains(latitude, longitude) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The bounds cross the antimeridian when the west
+// longitude is greater than the east one.
+func getGeoBoundsBoxes(southWest parser.GeoPointValue, northEast parser.GeoPointValue) []geoBox {
+	if southWest.Longitude() <= northEast.Longitude() {
+		return []geoBox{{
+			minLatitude:  southWest.Latitude(),
+			maxLatitude:  northEast.Latitude(),
+			minLongitude: southWest.Longitude(),
+			maxLongitude: northEast.Longitude(),
+		}}
+	}
+
+	return []geoBox{
+		{
+			minLatitude:  southWest.Latitude(),
+			maxLatitude:  northEast.Latitude(),
+			minLongitude: southWest.Longitude(),
+			maxLongitude: 180,
+		},
+		{
+			minLatitude:  southWest.Latitude(),
+			maxLatitude:  northEast.Latitude(),
+			minLongitude: -180,
+			maxLongitude: northEast.Longitude(),
+		},
+	}
+}
+
+// Returns boxes containing the whole circle around the point
+func getGeoRadiusBoxes(point parser.GeoPointValue, radius float64) []geoBox {
+	deltaLatitude := radius / parser.EarthRadius * 180 / math.Pi
+	minLatitude := point.Latitude() - deltaLatitude
+	maxLatitude := point.Latitude() + deltaLatitude
+
+	// Around the poles, the circle covers all the longitudes
+	if minLatitude <= -90 || maxLatitude >= 90 {
+		return []geoBox{{
+			minLatitude:  math.Max(minLatitude, -90),
+			maxLatitude:  math.Min(maxLatitude, 90),
+			minLongitude: -180,
+			maxLongitude: 180,
+		}}
+	}
+
+	deltaLongitude := math.Asin(
+		math.Sin(radius/parser.EarthRadius)/math.Cos(point.Latitude()*math.Pi/180),
+	) * 180 / math.Pi
+	if math.IsNaN(deltaLongitude) || radius/parser.EarthRadius >= math.Pi/2 {
+		deltaLongitude = 180
+	}
+
+	southWest, _ := parser.NewGeoPointValue(minLatitude, normalizeGeoLongitude(point.Longitude()-deltaLongitude))
+	northEast, _ := parser.NewGeoPointValue(maxLatitude, normalizeGeoLongitude(point.Longitude()+deltaLongitude))
+	if deltaLongitude >= 180 {
+		southWest, _ = parser.NewGeoPointValue(minLatitude, -180)
+		northEast, _ = parser.NewGeoPointValue(maxLatitude, 180)
+	}
+
+	return getGeoBoundsBoxes(southWest, northEast)
+}
+
+func normalizeGeoLongitude(longitude float64) float64 {
+	if longitude < -180 {
+		return longitude + 360
+	}
+	if longitude > 180 {
+		return longitude - 360
+	}
+	return longitude
+}
+
+func (box geoBox) contains(latitude float64, longitude float64) bool {
+	return latitude >= box.minLatitude && latitude <= box.maxLatitude &&
+		longitude >= box.minLongitude && longitude <= box.maxLongitude
+}
+
+// Returns the ranges of codes (inclusive) of the grid cells covering
+// the box. The size of the cells is chosen so that there is at most
+// geoMaxCellsPerBox cells.
+func (box geoBox) getCodeRanges() [][2]uint64 {
+	minLatitudeBits := geoQuantize(box.minLatitude, -90, 180)
+	maxLatitudeBits := geoQuantize(box.maxLatitude, -90, 180)
+	minLongitudeBits := geoQuantize(box.minLongitude, -180, 360)
+	maxLongitudeBits := geoQuantize(box.maxLongitude, -180, 360)
+
+	level := uint(0)
+	for nextLevel := uint(1); nextLevel <= 32; nextLevel++ {
+		shift := 32 - nextLevel
+		cellsCount := uint64((maxLatitudeBits>>shift)-(minLatitudeBits>>shift)+1) *
+			uint64((maxLongitudeBits>>shift)-(minLongitudeBits>>shift)+1)
+		if cellsCount > geoMaxCellsPerBox {
+			break
+		}
+		level = nextLevel
+	}
+
+	if level == 0 {
+		return [][2]uint64{{0, math.MaxUint64}}
+	}
+
+	shift := 32 - level
+	codeShift := 64 - 2*level
+	ranges := make([][2]uint64, 0, geoMaxCellsPerBox)
+	for latitudeCell := uint64(minLatitudeBits >> shift); latitudeCell <= uint64(maxLatitudeBits>>shift); latitudeCell++ {
+		for longitudeCell := uint64(minLongitudeBits >> shift); longitudeCell <= uint64(maxLongitudeBits>>shift); longitudeCell++ {
+			start := geoInterleave(uint32(latitudeCell<<shift), uint32(longitudeCell<<shift))
+			ranges = append(ranges, [2]uint64{start, start | (1<<codeShift - 1)})
+		}
+	}
+
+	return ranges
+}
+
+// Returns the position of the point in the Z-order curve,
+// with 32 bits of precision for each coordinate.
+func geoCode(latitude float64, longitude float64) uint64 {
+	return geoInterleave(
+		geoQuantize(latitude, -90, 180),
+		geoQuantize(longitude, -180, 360),
+	)
+}
+
+func geoQuantize(value float64, min float64, size float64) uint32 {
+	quantized := math.Floor((value - min) / size * (1 << 32))
+	if quantized <= 0 {
+		return 0
+	}
+	if quantized >= math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(quantized)
+}
+
+// The longitude bits are at the even positions, and the latitude
+// ones at the odd positions, starting from the most significant bit.
+func geoInterleave(latitudeBits uint32, longitudeBits uint32) uint64 {
+	return geoSpreadBits(longitudeBits)<<1 | geoSpreadBits(latitudeBits)
+}
+
+// Inserts a zero bit before each bit of the value
+func geoSpreadBits(value uint32) uint64 {
+	spread := uint64(value)
+	spread = (spread | spread<<16) & 0x0000FFFF0000FFFF
+	spread = (spread | spread<<8) & 0x00FF00FF00FF00FF
+	spread = (spread | spread<<4) & 0x0F0F0F0F0F0F0F0F
+	spread = (spread | spread<<2) & 0x3333333333333333
+	spread = (spread | spread<<1) & 0x5555555555555555
+	return spread
+}
+
+// Returns the distances in meters between the indexed points of the
+// records and the point given in the filters, without reading the
+// records from the input. It is nil if there is no point in the filters.
+func (geo *Geo) GetDistances(positions record.PositionList, filters map[string]interface{}) (map[record.Position]float64, error) {
+	point, err := getGeoFilterPoint(filters, "point")
+	if err != nil || point == nil {
+		return nil, err
+	}
+
+	distances := make(map[record.Position]float64, len(positions))
+	for _, position := range positions {
+		entryIndex, entryExists := geo.entryByPosition[position]
+		if !entryExists {
+			continue
+		}
+
+		entry := geo.entries[entryIndex]
+		recordPoint, err := parser.NewGeoPointValue(entry.Latitude, entry.Longitude)
+		if err != nil {
+			return nil, err
+		}
+		distances[position] = point.DistanceTo(recordPoint)
+	}
+
+	return distances, nil
+}
+
+func (geo *Geo) Close() error {
+	return nil
+}
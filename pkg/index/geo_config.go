@@ -0,0 +1,125 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/sirupsen/logrus"
+	"os"
+)
+
+// The names of the filters handled by the geo index
+var GeoFilters = []string{"point", "radius", "southWest", "northEast"}
+
+type GeoConfig struct {
+	Name              string `yaml:"name"`
+	Type              string `yaml:"type"`
+	Input             string `yaml:"input"`
+	Path              string `yaml:"path"`
+	Property          string `yaml:"property"`
+	LatitudeProperty  string `yaml:"latitudeProperty"`
+	LongitudeProperty string `yaml:"longitudeProperty"`
+	Logger            *logrus.Entry
+}
+
+func (config *GeoConfig) Validate(inputs map[string]input.Config, log *logrus.Entry) error {
+	config.Logger = log
+
+	if config.Name == "" {
+		return errors.New("geo.name is required")
+	}
+
+	if config.Path == "" {
+		log.Debug("geo.path not defined. The index will not be persisted")
+	} else {
+		fileInfo, err := os.Stat(config.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("geo.path: Error checking the path: %w", err)
+		}
+		if err == nil && fileInfo.IsDir() {
+			return errors.New("geo.path: This path already exists and is a directory")
+		}
+	}
+
+	_, inputExists := inputs[config.Input]
+	if !inputExists {
+		return fmt.Errorf("geo.input: Input '%v' not found in inputs list.", config.Input)
+	}
+
+	if config.Property == "" {
+		if config.LatitudeProperty == "" || config.LongitudeProperty == "" {
+			return errors.New("geo: Either property or both latitudeProperty and longitudeProperty are required")
+		}
+		if config.LatitudeProperty == config.LongitudeProperty {
+			return errors.New("geo: latitudeProperty and longitudeProperty must be different")
+		}
+	} else if config.LatitudeProperty != "" || config.LongitudeProperty != "" {
+		return errors.New("geo: property cannot be used at the same time as latitudeProperty and longitudeProperty")
+	}
+
+	// The properties will be validated at runtime
+
+	return nil
+}
+
+func (config *GeoConfig) GetName() string {
+	return config.Name
+}
+
+// Returns the indexed properties, which are either
+// a geographic point, or a latitude and a longitude
+func (config *GeoConfig) Properties() []string {
+	if config.Property != "" {
+		return []string{config.Property}
+	}
+
+	return []string{config.LatitudeProperty, config.LongitudeProperty}
+}
+
+func (config *GeoConfig) DoesHandleProperty(property string) bool {
+	for _, filter := range GeoFilters {
+		if property == filter {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All the handled properties are search filters
+func (config *GeoConfig) IsSearchFilter(property string) bool {
+	return config.DoesHandleProperty(property)
+}
+
+func (config *GeoConfig) GetPropertyReferences() []input.PropertyReference {
+	if config.Property != "" {
+		return []input.PropertyReference{
+			{
+				Input:      config.Input,
+				Property:   config.Property,
+				ConfigPath: "property",
+			},
+		}
+	}
+
+	return []input.PropertyReference{
+		{
+			Input:      config.Input,
+			Property:   config.LatitudeProperty,
+			ConfigPath: "latitudeProperty",
+		},
+		{
+			Input:      config.Input,
+			Property:   config.LongitudeProperty,
+			ConfigPath: "longitudeProperty",
+		},
+	}
+}
+
+func (config *GeoConfig) HasDistance() bool {
+	return true
+}
+
+func (config *GeoConfig) DoesHandleInput(input input.Config) bool {
+	return input.GetName() == config.Input
+}
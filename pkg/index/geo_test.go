@@ -0,0 +1,306 @@
+package index
+
+import (
+	"github.com/rodb-io/rodb/pkg/index/geofile"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newGeoMockRecord(latitude float64, longitude float64, position record.Position) record.Record {
+	return record.NewMockRecord(
+		map[string]string{},
+		map[string]int{},
+		map[string]float64{"lat": latitude, "lon": longitude},
+		map[string]bool{},
+		position,
+	)
+}
+
+func newGeoPointForTests(t *testing.T, latitude float64, longitude float64) parser.GeoPointValue {
+	point, err := parser.NewGeoPointValue(latitude, longitude)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	return point
+}
+
+func getGeoPositionsForTests(t *testing.T, index *Geo, mockInput input.Input, filters map[string]interface{}) record.PositionList {
+	nextPosition, err := index.GetRecordPositions(mockInput, filters)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	positions := make(record.PositionList, 0)
+	for {
+		position, err := nextPosition()
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if position == nil {
+			break
+		}
+		positions = append(positions, *position)
+	}
+
+	return positions
+}
+
+func TestGeo(t *testing.T) {
+	t.Run("persisted", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.rodb")
+		mockInput := input.NewMock(parser.NewMock(), []record.Record{
+			newGeoMockRecord(48.8566, 2.3522, 0),
+			newGeoMockRecord(51.5074, -0.1278, 1),
+		})
+		config := &GeoConfig{
+			Input:             "input",
+			Path:              path,
+			LatitudeProperty:  "lat",
+			LongitudeProperty: "lon",
+			Logger:            logrus.NewEntry(logrus.StandardLogger()),
+		}
+
+		if _, err := NewGeo(config, input.List{"input": mockInput}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("Expected the index file to exist, got '%v'", err)
+		}
+
+		// The input data is changed without changing it's size and date,
+		// to make sure that the index is loaded instead of being rebuilt
+		emptyInput := input.NewMock(parser.NewMock(), make([]record.Record, 2))
+		index, err := NewGeo(config, input.List{"input": emptyInput})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect, got := 2, len(index.entries); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+
+		// The same input with another parser must rebuild the index
+		otherInput := input.NewMock(parser.NewFloat(&parser.FloatConfig{DecimalSeparator: ","}), []record.Record{
+			newGeoMockRecord(35.6762, 139.6503, 0),
+			newGeoMockRecord(40.7128, -74.0060, 1),
+		})
+		index, err = NewGeo(config, input.List{"input": otherInput})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect, got := 35.6762, index.entries[index.entryByPosition[0]].Latitude; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("not a point", func(t *testing.T) {
+		_, err := NewGeo(
+			&GeoConfig{
+				Input:    "input",
+				Property: "lat",
+				Logger:   logrus.NewEntry(logrus.StandardLogger()),
+			},
+			input.List{
+				"input": input.NewMock(parser.NewMock(), []record.Record{
+					newGeoMockRecord(48.8566, 2.3522, 0),
+				}),
+			},
+		)
+		if err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
+
+func TestGeoGetRecordPositions(t *testing.T) {
+	mockInput := input.NewMock(parser.NewMock(), []record.Record{
+		newGeoMockRecord(48.8566, 2.3522, 0),   // Paris
+		newGeoMockRecord(48.8049, 2.1204, 1),   // Versailles
+		newGeoMockRecord(51.5074, -0.1278, 2),  // London
+		newGeoMockRecord(-17.7134, 178.065, 3), // Fiji
+		newGeoMockRecord(-13.7590, -172.10, 4), // Samoa
+		newGeoMockRecord(89.9, 0, 5),           // North pole
+		newGeoMockRecord(89.9, 180, 6),         // North pole
+	})
+	index, err := NewGeo(
+		&GeoConfig{
+			Input:             "input",
+			LatitudeProperty:  "lat",
+			LongitudeProperty: "lon",
+			Logger:            logrus.NewEntry(logrus.StandardLogger()),
+		},
+		input.List{
+			"input": mockInput,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		name    string
+		filters map[string]interface{}
+		expect  record.PositionList
+	}{
+		{
+			name:    "radius",
+			filters: map[string]interface{}{"point": newGeoPointForTests(t, 48.85, 2.35), "radius": float64(20000)},
+			expect:  record.PositionList{0, 1},
+		}, {
+			name:    "small radius",
+			filters: map[string]interface{}{"point": newGeoPointForTests(t, 48.85, 2.35), "radius": int64(5000)},
+			expect:  record.PositionList{0},
+		}, {
+			name:    "big radius",
+			filters: map[string]interface{}{"point": newGeoPointForTests(t, 48.85, 2.35), "radius": float64(500000)},
+			expect:  record.PositionList{0, 1, 2},
+		}, {
+			name:    "radius across the antimeridian",
+			filters: map[string]interface{}{"point": newGeoPointForTests(t, -16, 180), "radius": float64(1500000)},
+			expect:  record.PositionList{3, 4},
+		}, {
+			name:    "radius around the pole",
+			filters: map[string]interface{}{"point": newGeoPointForTests(t, 90, 0), "radius": float64(20000)},
+			expect:  record.PositionList{5, 6},
+		}, {
+			name: "bounding box",
+			filters: map[string]interface{}{
+				"southWest": newGeoPointForTests(t, 48, 2.2),
+				"northEast": newGeoPointForTests(t, 52, 3),
+			},
+			expect: record.PositionList{0},
+		}, {
+			name: "bounding box across the antimeridian",
+			filters: map[string]interface{}{
+				"southWest": newGeoPointForTests(t, -20, 170),
+				"northEast": newGeoPointForTests(t, -10, -170),
+			},
+			expect: record.PositionList{3, 4},
+		}, {
+			name: "radius and bounding box",
+			filters: map[string]interface{}{
+				"point":     newGeoPointForTests(t, 48.85, 2.35),
+				"radius":    float64(500000),
+				"southWest": newGeoPointForTests(t, 48, -1),
+				"northEast": newGeoPointForTests(t, 52, 2.2),
+			},
+			expect: record.PositionList{1, 2},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := getGeoPositionsForTests(t, index, mockInput, testCase.filters); !reflect.DeepEqual(got, testCase.expect) {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, filters := range []map[string]interface{}{
+			{},
+			{"point": newGeoPointForTests(t, 0, 0)},
+			{"radius": float64(10)},
+			{"point": newGeoPointForTests(t, 0, 0), "radius": float64(-10)},
+			{"point": "0,0", "radius": float64(10)},
+			{"southWest": newGeoPointForTests(t, 0, 0)},
+			{"southWest": newGeoPointForTests(t, 10, 0), "northEast": newGeoPointForTests(t, 0, 10)},
+			{"lat": float64(0)},
+		} {
+			if _, err := index.GetRecordPositions(mockInput, filters); err == nil {
+				t.Fatalf("Expected an error for '%v', got nil", filters)
+			}
+		}
+	})
+	t.Run("same as brute force", func(t *testing.T) {
+		random := rand.New(rand.NewSource(42))
+		records := make([]record.Record, 2000)
+		for i := range records {
+			records[i] = newGeoMockRecord(random.Float64()*180-90, random.Float64()*360-180, record.Position(i))
+		}
+		randomInput := input.NewMock(parser.NewMock(), records)
+		randomIndex, err := NewGeo(
+			&GeoConfig{
+				Input:             "input",
+				LatitudeProperty:  "lat",
+				LongitudeProperty: "lon",
+				Logger:            logrus.NewEntry(logrus.StandardLogger()),
+			},
+			input.List{"input": randomInput},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 50; i++ {
+			point := newGeoPointForTests(t, random.Float64()*180-90, random.Float64()*360-180)
+			radius := random.Float64() * 3000000
+
+			expect := make(record.PositionList, 0)
+			for _, record := range records {
+				recordPoint, err := randomIndex.getRecordPoint(record)
+				if err != nil {
+					t.Fatalf("Unexpected error: '%+v'", err)
+				}
+				if point.DistanceTo(*recordPoint) <= radius {
+					expect = append(expect, record.Position())
+				}
+			}
+
+			got := getGeoPositionsForTests(t, randomIndex, randomInput, map[string]interface{}{
+				"point":  point,
+				"radius": radius,
+			})
+			if !reflect.DeepEqual(got, expect) {
+				t.Fatalf("Expected '%v' around '%v' (%vm), got '%v'", expect, point, radius, got)
+			}
+		}
+	})
+}
+
+func TestGeoGetDistances(t *testing.T) {
+	index := &Geo{
+		config: &GeoConfig{
+			LatitudeProperty:  "lat",
+			LongitudeProperty: "lon",
+		},
+	}
+	index.setEntries([]geofile.Entry{
+		{Latitude: 48.8566, Longitude: 2.3522, Position: 1},
+		{Latitude: 51.5074, Longitude: -0.1278, Position: 2},
+	})
+
+	t.Run("normal", func(t *testing.T) {
+		distances, err := index.GetDistances(record.PositionList{2, 1, 3}, map[string]interface{}{
+			"point":  newGeoPointForTests(t, 51.5074, -0.1278),
+			"radius": float64(1000000),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if len(distances) != 2 {
+			t.Fatalf("Expected 2 distances, got '%v'", distances)
+		}
+		if distance := distances[1]; distance < 343000 || distance > 344000 {
+			t.Fatalf("Expected about 343.5km, got '%v'", distance)
+		}
+		if distance := distances[2]; distance != 0 {
+			t.Fatalf("Expected 0, got '%v'", distance)
+		}
+	})
+	t.Run("without point", func(t *testing.T) {
+		distances, err := index.GetDistances(record.PositionList{1, 2}, map[string]interface{}{
+			"southWest": newGeoPointForTests(t, 48, 2),
+			"northEast": newGeoPointForTests(t, 49, 3),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if distances != nil {
+			t.Fatalf("Expected nil, got '%v'", distances)
+		}
+	})
+}
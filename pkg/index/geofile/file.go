@@ -0,0 +1,106 @@
+package geofile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"io"
+	"os"
+)
+
+// An indexed point. The entries are stored in the order of their codes,
+// which identify the cell of the point in a grid covering the earth.
+type Entry struct {
+	Code      uint64
+	Latitude  float64
+	Longitude float64
+	Position  record.Position
+}
+
+// Writes the index to the given path. The data is written to a temporary
+// file first, so that an interrupted process never leaves a partial file.
+func Save(path string, metadata *Metadata, entries []Entry) error {
+	temporaryPath := path + ".tmp"
+	file, err := os.Create(temporaryPath)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	if err := write(writer, metadata, entries); err != nil {
+		file.Close()
+		os.Remove(temporaryPath)
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		os.Remove(temporaryPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(temporaryPath)
+		return err
+	}
+
+	return os.Rename(temporaryPath, path)
+}
+
+func write(data io.Writer, metadata *Metadata, entries []Entry) error {
+	if err := metadata.Serialize(data); err != nil {
+		return err
+	}
+
+	if err := binary.Write(data, binary.BigEndian, int64(len(entries))); err != nil {
+		return err
+	}
+
+	return binary.Write(data, binary.BigEndian, entries)
+}
+
+// Loads only the metadata of the index file
+func LoadMetadata(path string) (*Metadata, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	metadata, err := unserializeMetadata(bufio.NewReader(file))
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// Loads the index from the given path, after checking
+// that it matches the expected input and configuration
+func Load(path string, expect MetadataInput) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	metadata, err := unserializeMetadata(reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.AssertValid(expect); err != nil {
+		return nil, err
+	}
+
+	var entriesCount int64
+	if err := binary.Read(reader, binary.BigEndian, &entriesCount); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, int(entriesCount))
+	if err := binary.Read(reader, binary.BigEndian, entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
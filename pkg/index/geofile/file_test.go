@@ -0,0 +1,84 @@
+package geofile
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.rodb")
+
+	mockInput := input.NewMock(parser.NewMock(), make([]record.Record, 42))
+	mockInput.SetModTime(time.Unix(1234, 0))
+	metadataInput := MetadataInput{
+		Input:      mockInput,
+		Properties: []string{"latitude", "longitude"},
+	}
+
+	entries := []Entry{
+		{Code: 1, Latitude: -12.5, Longitude: 42.25, Position: 3},
+		{Code: 7, Latitude: 48.8566, Longitude: 2.3522, Position: 1},
+	}
+
+	metadata, err := NewMetadata(metadataInput)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if err := Save(path, metadata, entries); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("Expected the temporary file to be removed, got '%v'", err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		loaded, err := Load(path, metadataInput)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if !reflect.DeepEqual(entries, loaded) {
+			t.Fatalf("Expected '%v', got '%v'", entries, loaded)
+		}
+	})
+	t.Run("metadata", func(t *testing.T) {
+		loaded, err := LoadMetadata(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if err := loaded.AssertValid(metadataInput); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+	})
+	t.Run("modified input", func(t *testing.T) {
+		mockInput.SetModTime(time.Unix(1235, 0))
+		defer mockInput.SetModTime(time.Unix(1234, 0))
+
+		if _, err := Load(path, metadataInput); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("different properties", func(t *testing.T) {
+		if _, err := Load(path, MetadataInput{
+			Input:      mockInput,
+			Properties: []string{"point"},
+		}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("different parser", func(t *testing.T) {
+		otherInput := input.NewMock(parser.NewFloat(&parser.FloatConfig{DecimalSeparator: ","}), make([]record.Record, 42))
+		otherInput.SetModTime(time.Unix(1234, 0))
+		if _, err := Load(path, MetadataInput{
+			Input:      otherInput,
+			Properties: []string{"latitude", "longitude"},
+		}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
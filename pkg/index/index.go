@@ -5,6 +5,8 @@ import (
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/sirupsen/logrus"
+	"reflect"
+	"sort"
 )
 
 type Index interface {
@@ -18,46 +20,126 @@ type Index interface {
 	Close() error
 }
 
+// Indexes whose results can be sorted by distance
+type DistanceIndex interface {
+	Index
+
+	// Returns the distances between the records at the given positions and
+	// the searched value of the filters. The positions whose distance cannot
+	// be computed are missing from the result, which is nil when the filters
+	// do not contain the required values.
+	GetDistances(positions record.PositionList, filters map[string]interface{}) (map[record.Position]float64, error)
+}
+
 type Config interface {
 	Validate(inputs map[string]input.Config, log *logrus.Entry) error
 	GetName() string
 	DoesHandleProperty(property string) bool
 	DoesHandleInput(input input.Config) bool
+	// Returns the indexed properties
+	GetPropertyReferences() []input.PropertyReference
+}
+
+// Indexes which can search for the null values, with a nil filter
+type NullValuesConfig interface {
+	Config
+	DoesHandleNullValues() bool
+}
+
+// Implemented by the configs of the indexes which are a DistanceIndex
+type DistanceConfig interface {
+	Config
+	HasDistance() bool
+}
+
+// Indexes handling some properties which are search
+// filters, instead of properties of their input
+type SearchFilterConfig interface {
+	Config
+	IsSearchFilter(property string) bool
 }
 
 type List = map[string]Index
 
+// Returns true if the given property is a search filter of the index
+func IsSearchFilter(config Config, property string) bool {
+	searchFilterConfig, isSearchFilterConfig := config.(SearchFilterConfig)
+	return isSearchFilterConfig && searchFilterConfig.IsSearchFilter(property)
+}
+
+// Returns true if the index created from this config can search for the null values
+func HandlesNullValues(config Config) bool {
+	nullValuesConfig, isNullValuesConfig := config.(NullValuesConfig)
+	return isNullValuesConfig && nullValuesConfig.DoesHandleNullValues()
+}
+
+// Returns true if the index created from this config is a DistanceIndex
+func HasDistance(config Config) bool {
+	distanceConfig, isDistanceConfig := config.(DistanceConfig)
+	return isDistanceConfig && distanceConfig.HasDistance()
+}
+
 func NewFromConfig(
 	config Config,
 	inputs input.List,
 ) (Index, error) {
-	switch config.(type) {
-	case *MapConfig:
-		return NewMap(config.(*MapConfig), inputs)
-	case *WildcardConfig:
-		return NewWildcard(config.(*WildcardConfig), inputs)
-	case *SqliteConfig:
-		return NewSqlite(config.(*SqliteConfig), inputs)
-	case *Fts5Config:
-		return NewFts5(config.(*Fts5Config), inputs)
-	case *NoopConfig:
-		return NewNoop(config.(*NoopConfig), inputs), nil
-	default:
+	registration, exists := registrationsByConfigType[reflect.TypeOf(config)]
+	if !exists {
 		return nil, fmt.Errorf("Unknown index config type: %#v", config)
 	}
+
+	return registration.constructor(config, inputs)
 }
 
+// Creates the index without filling it. The returned builder
+// is nil when the index does not need to be built.
+func newUnbuiltFromConfig(
+	config Config,
+	inputs input.List,
+) (Index, builder, error) {
+	registration, exists := registrationsByConfigType[reflect.TypeOf(config)]
+	if !exists {
+		return nil, nil, fmt.Errorf("Unknown index config type: %#v", config)
+	}
+
+	if registration.unbuiltConstructor == nil {
+		index, err := registration.constructor(config, inputs)
+		return index, nil, err
+	}
+
+	return registration.unbuiltConstructor(config, inputs)
+}
+
+// Creates all the indexes. The indexes using the same input are
+// built together, and the ones using different inputs in parallel.
 func NewFromConfigs(
 	configs map[string]Config,
 	inputs input.List,
 ) (List, error) {
+	indexNames := make([]string, 0, len(configs))
+	for indexName := range configs {
+		indexNames = append(indexNames, indexName)
+	}
+	sort.Strings(indexNames)
+
 	indexes := make(List)
-	for indexName, indexConfig := range configs {
-		index, err := NewFromConfig(indexConfig, inputs)
+	builders := make(map[input.Input][]builder)
+	for _, indexName := range indexNames {
+		index, builder, err := newUnbuiltFromConfig(configs[indexName], inputs)
 		if err != nil {
+			Close(indexes)
 			return nil, err
 		}
 		indexes[indexName] = index
+
+		if builder != nil {
+			builders[builder.input()] = append(builders[builder.input()], builder)
+		}
+	}
+
+	if err := buildAll(builders); err != nil {
+		Close(indexes)
+		return nil, err
 	}
 
 	return indexes, nil
@@ -0,0 +1,179 @@
+package index
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+type iterationCountingInput struct {
+	*input.Mock
+	iterations int32
+}
+
+func (countingInput *iterationCountingInput) IterateAll() (record.Iterator, func() error, error) {
+	atomic.AddInt32(&countingInput.iterations, 1)
+	return countingInput.Mock.IterateAll()
+}
+
+// A distance index type which could be registered outside of this package
+type distanceConfigForTests struct {
+	NoopConfig
+}
+
+func (config *distanceConfigForTests) HasDistance() bool {
+	return true
+}
+
+func TestHasDistance(t *testing.T) {
+	for _, testCase := range []struct {
+		name   string
+		config Config
+		expect bool
+	}{
+		{"geo", &GeoConfig{}, true},
+		{"fuzzy", &FuzzyConfig{}, true},
+		{"map", &MapConfig{}, false},
+		{"noop", &NoopConfig{}, false},
+		{"custom", &distanceConfigForTests{}, true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := HasDistance(testCase.config); got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestNewFromConfigs(t *testing.T) {
+	newInput := func(values ...string) *iterationCountingInput {
+		records := make([]record.Record, len(values))
+		for i, value := range values {
+			records[i] = record.NewStringPropertiesMockRecord(map[string]string{
+				"col": value,
+			}, record.Position(i))
+		}
+
+		return &iterationCountingInput{
+			Mock: input.NewMock(parser.NewMock(), records),
+		}
+	}
+
+	inputA := newInput("a", "b", "a")
+	inputB := newInput("c")
+	inputs := input.List{"a": inputA, "b": inputB}
+
+	logger := logrus.NewEntry(logrus.StandardLogger())
+	indexes, err := NewFromConfigs(map[string]Config{
+		"a1": &MapConfig{Name: "a1", Input: "a", Properties: []string{"col"}, Logger: logger},
+		"a2": &MapConfig{Name: "a2", Input: "a", Properties: []string{"col"}, Logger: logger},
+		"b1": &MapConfig{Name: "b1", Input: "b", Properties: []string{"col"}, Logger: logger},
+		"b2": &NoopConfig{Name: "b2"},
+	}, inputs)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	t.Run("single scan", func(t *testing.T) {
+		if expect, got := int32(1), inputA.iterations; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if expect, got := int32(1), inputB.iterations; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("built", func(t *testing.T) {
+		for indexName, expect := range map[string]int{"a1": 2, "a2": 2} {
+			if got := len(indexes[indexName].(*Map).index["col"]["a"]); got != expect {
+				t.Fatalf("Expected '%v' for '%v', got '%v'", expect, indexName, got)
+			}
+		}
+		if expect, got := 1, len(indexes["b1"].(*Map).index["col"]["c"]); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
+
+func TestGetRecordPositionsOfParsedValues(t *testing.T) {
+	newDecimal := func(value string) parser.DecimalValue {
+		decimal, err := parser.NewDecimalValue(value, -1, false)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		return decimal
+	}
+
+	mockInput := input.NewMock(parser.NewMock(), []record.Record{
+		record.NewValuesMockRecord(map[string]interface{}{"price": newDecimal("0.30"), "email": "alice@example.com"}, 0),
+		record.NewValuesMockRecord(map[string]interface{}{"price": newDecimal("1.10"), "email": nil}, 1),
+		record.NewValuesMockRecord(map[string]interface{}{"price": newDecimal("0.3"), "email": nil}, 2),
+	})
+	inputs := input.List{"input": mockInput}
+	logger := logrus.NewEntry(logrus.StandardLogger())
+
+	mapIndex, err := NewMap(&MapConfig{
+		Name:       "map",
+		Input:      "input",
+		Properties: []string{"price", "email"},
+		Logger:     logger,
+	}, inputs)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	sqliteIndex, err := NewSqlite(&SqliteConfig{
+		Name:  "sqlite",
+		Input: "input",
+		Dsn:   ":memory:",
+		Properties: []*SqlitePropertyConfig{
+			{Name: "price", Collate: "BINARY"},
+			{Name: "email", Collate: "BINARY"},
+		},
+		Logger: logger,
+	}, inputs)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	defer sqliteIndex.Close()
+
+	for indexName, index := range map[string]Index{
+		"noop":   NewNoop(&NoopConfig{}, inputs),
+		"map":    mapIndex,
+		"sqlite": sqliteIndex,
+	} {
+		for _, testCase := range []struct {
+			name    string
+			filters map[string]interface{}
+			expect  record.PositionList
+		}{
+			{"decimal", map[string]interface{}{"price": newDecimal("0.3")}, record.PositionList{0, 2}},
+			{"null", map[string]interface{}{"email": nil}, record.PositionList{1, 2}},
+		} {
+			t.Run(indexName+" "+testCase.name, func(t *testing.T) {
+				nextPosition, err := index.GetRecordPositions(mockInput, testCase.filters)
+				if err != nil {
+					t.Fatalf("Unexpected error: '%+v'", err)
+				}
+
+				positions := record.PositionList{}
+				for {
+					position, err := nextPosition()
+					if err != nil {
+						t.Fatalf("Unexpected error: '%+v'", err)
+					}
+					if position == nil {
+						break
+					}
+					positions = append(positions, *position)
+				}
+
+				if !reflect.DeepEqual(positions, testCase.expect) {
+					t.Fatalf("Expected '%v', got '%v'", testCase.expect, positions)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package indexfile
+
+import (
+	"encoding/binary"
+	"fmt"
+	inputPackage "github.com/rodb-io/rodb/pkg/input"
+	"io"
+	"time"
+)
+
+// Identifies the type of index stored in a file
+type Format struct {
+	// The type of index, used in the error messages
+	Name       string
+	MagicBytes string
+	// Current version of the file format
+	Version uint16
+}
+
+// The header of the index files, describing
+// the input and properties they were built from
+type Metadata struct {
+	format                    Format
+	magicBytes                []byte
+	version                   uint16
+	inputFileModificationTime time.Time
+	inputFileSize             int64
+	properties                []string
+	// The hashes of the parsers of the properties, in the same order
+	parserHashes []string
+}
+
+type MetadataInput struct {
+	Input      inputPackage.Input
+	Properties []string
+}
+
+func NewMetadata(format Format, input MetadataInput) (*Metadata, error) {
+	size, err := input.Input.Size()
+	if err != nil {
+		return nil, err
+	}
+
+	modTime, err := input.Input.ModTime()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metadata{
+		format:                    format,
+		magicBytes:                []byte(format.MagicBytes),
+		version:                   format.Version,
+		inputFileModificationTime: modTime,
+		inputFileSize:             size,
+		properties:                input.Properties,
+		parserHashes:              input.getParserHashes(),
+	}, nil
+}
+
+func (input MetadataInput) getParserHashes() []string {
+	hashes := make([]string, len(input.Properties))
+	for i, property := range input.Properties {
+		hashes[i] = inputPackage.GetPropertyParserHash(input.Input, property)
+	}
+
+	return hashes
+}
+
+// The indexed properties, in the order in which they are stored
+func (metadata *Metadata) Properties() []string {
+	return metadata.properties
+}
+
+func (metadata *Metadata) Serialize(data io.Writer) error {
+	if err := binary.Write(data, binary.BigEndian, metadata.magicBytes); err != nil {
+		return err
+	}
+	if err := binary.Write(data, binary.BigEndian, metadata.version); err != nil {
+		return err
+	}
+	if err := binary.Write(data, binary.BigEndian, int64(metadata.inputFileModificationTime.Unix())); err != nil {
+		return err
+	}
+	if err := binary.Write(data, binary.BigEndian, metadata.inputFileSize); err != nil {
+		return err
+	}
+	if err := binary.Write(data, binary.BigEndian, int64(len(metadata.properties))); err != nil {
+		return err
+	}
+	for _, property := range metadata.properties {
+		if err := WriteString(data, property); err != nil {
+			return err
+		}
+	}
+	for _, parserHash := range metadata.parserHashes {
+		if err := WriteString(data, parserHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reads the metadata of a file of the given format
+func Unserialize(format Format, data io.Reader) (*Metadata, error) {
+	metadata := &Metadata{
+		format:     format,
+		magicBytes: make([]byte, len(format.MagicBytes)),
+	}
+	if err := binary.Read(data, binary.BigEndian, &metadata.magicBytes); err != nil {
+		return nil, err
+	}
+	if string(metadata.magicBytes) != format.MagicBytes {
+		return nil, fmt.Errorf("The given file is not a %v index.", format.Name)
+	}
+
+	if err := binary.Read(data, binary.BigEndian, &metadata.version); err != nil {
+		return nil, err
+	}
+	if metadata.version != format.Version {
+		return nil, fmt.Errorf("The index file is not compatible with the current version of this software.")
+	}
+
+	var inputFileModificationTimeUnix int64
+	if err := binary.Read(data, binary.BigEndian, &inputFileModificationTimeUnix); err != nil {
+		return nil, err
+	}
+	metadata.inputFileModificationTime = time.Unix(inputFileModificationTimeUnix, 0)
+
+	if err := binary.Read(data, binary.BigEndian, &metadata.inputFileSize); err != nil {
+		return nil, err
+	}
+
+	var propertiesCount int64
+	if err := binary.Read(data, binary.BigEndian, &propertiesCount); err != nil {
+		return nil, err
+	}
+	metadata.properties = make([]string, int(propertiesCount))
+	for i := range metadata.properties {
+		property, err := ReadString(data)
+		if err != nil {
+			return nil, err
+		}
+		metadata.properties[i] = property
+	}
+	metadata.parserHashes = make([]string, int(propertiesCount))
+	for i := range metadata.parserHashes {
+		parserHash, err := ReadString(data)
+		if err != nil {
+			return nil, err
+		}
+		metadata.parserHashes[i] = parserHash
+	}
+
+	return metadata, nil
+}
+
+// Validates that the metadata of the file is an RODB index of it's format
+// and matches the given configuration as well as the current version
+func (metadata *Metadata) AssertValid(expect MetadataInput) error {
+	if metadata.version != metadata.format.Version {
+		return fmt.Errorf("The index file is not compatible with the current version of this software.")
+	}
+
+	if string(metadata.magicBytes) != metadata.format.MagicBytes {
+		return fmt.Errorf("The given file is not a %v index.", metadata.format.Name)
+	}
+
+	modTime, err := expect.Input.ModTime()
+	if err != nil {
+		return err
+	}
+	if metadata.inputFileModificationTime.Unix() != modTime.Unix() {
+		return fmt.Errorf("The input file has been modified since the index generation.")
+	}
+
+	size, err := expect.Input.Size()
+	if err != nil {
+		return err
+	}
+	if metadata.inputFileSize != size {
+		return fmt.Errorf("The input file size has changed since the index generation.")
+	}
+
+	if len(metadata.properties) != len(expect.Properties) {
+		return fmt.Errorf("The configured properties does not match the index file contents.")
+	}
+	for i, property := range metadata.properties {
+		if property != expect.Properties[i] {
+			return fmt.Errorf("The configured properties does not match the index file contents.")
+		}
+	}
+
+	for i, parserHash := range expect.getParserHashes() {
+		if metadata.parserHashes[i] != parserHash {
+			return fmt.Errorf("The parser of the property '%v' has changed since the index generation.", expect.Properties[i])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package indexfile
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+func WriteString(data io.Writer, value string) error {
+	if err := binary.Write(data, binary.BigEndian, uint32(len(value))); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(data, value)
+	return err
+}
+
+func ReadString(data io.Reader) (string, error) {
+	var length uint32
+	if err := binary.Read(data, binary.BigEndian, &length); err != nil {
+		return "", err
+	}
+
+	bytes := make([]byte, length)
+	if _, err := io.ReadFull(data, bytes); err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
@@ -3,14 +3,17 @@ package index
 import (
 	"errors"
 	"fmt"
+	"github.com/rodb-io/rodb/pkg/index/mapfile"
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
-	"github.com/rodb-io/rodb/pkg/util"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"os"
 	"reflect"
 )
 
 // Index for the values of a single property
-type mapPropertyIndex = map[interface{}]record.PositionList
+type mapPropertyIndex = mapfile.PropertyIndex
 
 type Map struct {
 	config *MapConfig
@@ -22,9 +25,27 @@ func NewMap(
 	config *MapConfig,
 	inputs input.List,
 ) (*Map, error) {
+	mapIndex, indexBuilder, err := newMap(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if indexBuilder != nil {
+		if err := build([]builder{indexBuilder}); err != nil {
+			return nil, err
+		}
+	}
+
+	return mapIndex, nil
+}
+
+func newMap(
+	config *MapConfig,
+	inputs input.List,
+) (*Map, builder, error) {
 	input, inputExists := inputs[config.Input]
 	if !inputExists {
-		return nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
+		return nil, nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
 	}
 
 	mapIndex := &Map{
@@ -32,65 +53,111 @@ func NewMap(
 		input:  input,
 	}
 
-	if err := mapIndex.reindex(); err != nil {
-		return nil, err
+	if config.Path != "" {
+		loaded, err := mapIndex.load()
+		if err != nil {
+			return nil, nil, err
+		}
+		if loaded {
+			return mapIndex, nil, nil
+		}
 	}
 
-	return mapIndex, nil
-}
-
-func (mapIndex *Map) Name() string {
-	return mapIndex.config.Name
-}
-
-func (mapIndex *Map) reindex() error {
 	index := make(map[string]mapPropertyIndex)
 	for _, property := range mapIndex.config.Properties {
 		index[property] = make(mapPropertyIndex)
 	}
 
-	updateProgress := util.TrackProgress(mapIndex.input, mapIndex.config.Logger)
+	return mapIndex, &mapBuilder{
+		mapIndex: mapIndex,
+		index:    index,
+	}, nil
+}
+
+// Loads the index from it's file. Returns false when the file
+// does not exist or cannot be used, meaning that the index must be rebuilt.
+func (mapIndex *Map) load() (bool, error) {
+	_, err := os.Stat(mapIndex.config.Path)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	index, err := mapfile.Load(mapIndex.config.Path, mapIndex.getMetadataInput())
+	if err != nil {
+		mapIndex.config.Logger.Warnf("The index file cannot be loaded and will be rebuilt: %v", err)
+		return false, nil
+	}
+
+	mapIndex.index = index
+	mapIndex.config.Logger.Infof("Successfully loaded the index from '%v'", mapIndex.config.Path)
+
+	return true, nil
+}
 
-	inputIterator, end, err := mapIndex.input.IterateAll()
+func (mapIndex *Map) save() error {
+	metadata, err := mapfile.NewMetadata(mapIndex.getMetadataInput())
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err := end(); err != nil {
-			mapIndex.config.Logger.Errorf("Error while closing the input iterator: %v", err)
-		}
-	}()
 
-	for {
-		record, err := inputIterator()
+	return mapfile.Save(mapIndex.config.Path, metadata, mapIndex.index)
+}
+
+func (mapIndex *Map) getMetadataInput() mapfile.MetadataInput {
+	return mapfile.MetadataInput{
+		Input:      mapIndex.input,
+		Properties: mapIndex.config.Properties,
+	}
+}
+
+func (mapIndex *Map) Name() string {
+	return mapIndex.config.Name
+}
+
+type mapBuilder struct {
+	mapIndex *Map
+	index    map[string]mapPropertyIndex
+}
+
+func (builder *mapBuilder) input() input.Input {
+	return builder.mapIndex.input
+}
+
+func (builder *mapBuilder) logger() *logrus.Entry {
+	return builder.mapIndex.config.Logger
+}
+
+func (builder *mapBuilder) add(record record.Record) error {
+	for _, property := range builder.mapIndex.config.Properties {
+		value, err := record.Get(property)
 		if err != nil {
 			return err
 		}
-		if record == nil {
-			break
+
+		if value != nil {
+			value = reflect.ValueOf(value).Interface()
 		}
 
-		updateProgress(record.Position())
+		if err := builder.mapIndex.addValueToIndex(builder.index, property, value, record.Position()); err != nil {
+			return fmt.Errorf("Cannot index the property '%v': ", property)
+		}
+	}
 
-		for _, property := range mapIndex.config.Properties {
-			value, err := record.Get(property)
-			if err != nil {
-				return err
-			}
+	return nil
+}
 
-			if value != nil {
-				value = reflect.ValueOf(value).Interface()
-			}
+func (builder *mapBuilder) finish() error {
+	builder.mapIndex.index = builder.index
+	builder.mapIndex.config.Logger.Infof("Successfully finished indexing")
 
-			if err := mapIndex.addValueToIndex(index, property, value, record.Position()); err != nil {
-				return fmt.Errorf("Cannot index the property '%v': ", property)
-			}
+	if builder.mapIndex.config.Path != "" {
+		if err := builder.mapIndex.save(); err != nil {
+			return fmt.Errorf("Error while saving the index: %w", err)
 		}
 	}
 
-	mapIndex.index = index
-	mapIndex.config.Logger.Infof("Successfully finished indexing")
-
 	return nil
 }
 
@@ -112,17 +179,31 @@ func (mapIndex *Map) addValueToIndex(
 		return errors.New("Indexing objects is not supported")
 	}
 
+	key := getMapKey(value)
 	propertyIndex := index[property]
-	valueIndexes, valueIndexesExists := propertyIndex[value]
+	valueIndexes, valueIndexesExists := propertyIndex[key]
 	if valueIndexesExists {
-		propertyIndex[value] = append(valueIndexes, position)
+		propertyIndex[key] = append(valueIndexes, position)
 	} else {
-		propertyIndex[value] = record.PositionList{position}
+		propertyIndex[key] = record.PositionList{position}
 	}
 
 	return nil
 }
 
+// The values representing the same data must be the same map
+// key, whatever the options of the parser which returned them
+func getMapKey(value interface{}) interface{} {
+	if dateTime, isDateTime := value.(parser.DateTimeValue); isDateTime {
+		return dateTime.Key()
+	}
+	if decimal, isDecimal := value.(parser.DecimalValue); isDecimal {
+		return decimal.Key()
+	}
+
+	return value
+}
+
 func (mapIndex *Map) GetRecordPositions(
 	input input.Input,
 	filters map[string]interface{},
@@ -146,7 +227,7 @@ func (mapIndex *Map) GetRecordPositions(
 			return record.EmptyIterator, nil
 		}
 
-		indexedResults, foundIndexedResults := indexedValues[filter]
+		indexedResults, foundIndexedResults := indexedValues[getMapKey(filter)]
 		if !foundIndexedResults {
 			return record.EmptyIterator, nil
 		}
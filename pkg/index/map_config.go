@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/sirupsen/logrus"
+	"os"
 )
 
 type MapConfig struct {
 	Name       string   `yaml:"name"`
 	Type       string   `yaml:"type"`
 	Input      string   `yaml:"input"`
+	Path       string   `yaml:"path"`
 	Properties []string `yaml:"properties"`
 	Logger     *logrus.Entry
 }
@@ -22,6 +24,16 @@ func (config *MapConfig) Validate(inputs map[string]input.Config, log *logrus.En
 		return errors.New("map.name is required")
 	}
 
+	if config.Path != "" {
+		fileInfo, err := os.Stat(config.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("map.path: Error checking the path: %w", err)
+		}
+		if err == nil && fileInfo.IsDir() {
+			return errors.New("map.path: This path already exists and is a directory")
+		}
+	}
+
 	_, inputExists := inputs[config.Input]
 	if !inputExists {
 		return fmt.Errorf("map.input: Input '%v' not found in inputs list.", config.Input)
@@ -56,6 +68,14 @@ func (config *MapConfig) DoesHandleProperty(property string) bool {
 	return isHandled
 }
 
+func (config *MapConfig) DoesHandleNullValues() bool {
+	return true
+}
+
+func (config *MapConfig) GetPropertyReferences() []input.PropertyReference {
+	return input.NewPropertyReferences(config.Input, config.Properties, "properties")
+}
+
 func (config *MapConfig) DoesHandleInput(input input.Config) bool {
 	return input.GetName() == config.Input
 }
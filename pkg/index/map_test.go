@@ -5,7 +5,10 @@ import (
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/rodb-io/rodb/pkg/parser"
 	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestMap(t *testing.T) {
@@ -71,6 +74,40 @@ func TestMap(t *testing.T) {
 			}
 		}
 	})
+	t.Run("persisted", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.rodb")
+		mockInput := input.NewMock(parser.NewMock(), []record.Record{
+			record.NewStringPropertiesMockRecord(map[string]string{"col": "value_a"}, 0),
+			record.NewStringPropertiesMockRecord(map[string]string{"col": "value_b"}, 1),
+		})
+		config := &MapConfig{
+			Properties: []string{"col"},
+			Input:      "input",
+			Path:       path,
+			Logger:     logrus.NewEntry(logrus.StandardLogger()),
+		}
+
+		if _, err := NewMap(config, input.List{"input": mockInput}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("Expected the index file to exist, got '%v'", err)
+		}
+
+		// The input data is changed without changing it's size and date,
+		// to make sure that the index is loaded instead of being rebuilt
+		emptyInput := input.NewMock(parser.NewMock(), make([]record.Record, 2))
+		index, err := NewMap(config, input.List{"input": emptyInput})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect, got := 1, len(index.index["col"]["value_b"]); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if expect, got := record.Position(1), index.index["col"]["value_b"][0]; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
 }
 
 func TestMapGetRecordPositions(t *testing.T) {
@@ -181,3 +218,43 @@ func TestMapGetRecordPositions(t *testing.T) {
 		}
 	})
 }
+
+func TestGetMapKey(t *testing.T) {
+	paris, err := parser.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	instant := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	index := mapPropertyIndex{}
+	indexedKey := getMapKey(parser.NewDateTimeValue(instant, time.UTC, "%Y-%m-%d"))
+	index[indexedKey] = record.PositionList{1}
+
+	filterKey := getMapKey(parser.NewDateTimeValue(instant.In(paris), paris, "%d/%m/%Y %H:%M"))
+	if _, found := index[filterKey]; !found {
+		t.Fatalf("Expected the same instant to be found, got %v for %v", index, filterKey)
+	}
+
+	otherKey := getMapKey(parser.NewDateTimeValue(instant.Add(time.Second), time.UTC, "%Y-%m-%d"))
+	if _, found := index[otherKey]; found {
+		t.Fatalf("Expected another instant not to be found, got %v for %v", index, otherKey)
+	}
+
+	indexedDecimal, err := parser.NewDecimalValue("1.5", 2, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	index[getMapKey(indexedDecimal)] = record.PositionList{2}
+
+	filterDecimal, err := parser.NewDecimalValue("01.50", -1, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if _, found := index[getMapKey(filterDecimal)]; !found {
+		t.Fatalf("Expected the same number to be found, got %v for %v", index, getMapKey(filterDecimal))
+	}
+
+	if got, expect := getMapKey("a"), "a"; got != expect {
+		t.Fatalf("Expected '%v', got '%v'", expect, got)
+	}
+}
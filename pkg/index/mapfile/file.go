@@ -0,0 +1,135 @@
+package mapfile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"io"
+	"os"
+)
+
+// Index for the values of a single property
+type PropertyIndex = map[interface{}]record.PositionList
+
+// Writes the index to the given path. The data is written to a temporary
+// file first, so that an interrupted process never leaves a partial file.
+func Save(path string, metadata *Metadata, index map[string]PropertyIndex) error {
+	temporaryPath := path + ".tmp"
+	file, err := os.Create(temporaryPath)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	if err := write(writer, metadata, index); err != nil {
+		file.Close()
+		os.Remove(temporaryPath)
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		os.Remove(temporaryPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(temporaryPath)
+		return err
+	}
+
+	return os.Rename(temporaryPath, path)
+}
+
+func write(data io.Writer, metadata *Metadata, index map[string]PropertyIndex) error {
+	if err := metadata.Serialize(data); err != nil {
+		return err
+	}
+
+	for _, property := range metadata.Properties() {
+		propertyIndex := index[property]
+		if err := binary.Write(data, binary.BigEndian, int64(len(propertyIndex))); err != nil {
+			return err
+		}
+
+		for value, positions := range propertyIndex {
+			if err := writeValue(data, value); err != nil {
+				return err
+			}
+			if err := binary.Write(data, binary.BigEndian, int64(len(positions))); err != nil {
+				return err
+			}
+			if err := binary.Write(data, binary.BigEndian, []record.Position(positions)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Loads only the metadata of the index file
+func LoadMetadata(path string) (*Metadata, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	metadata, err := unserializeMetadata(bufio.NewReader(file))
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// Loads the index from the given path, after checking
+// that it matches the expected input and configuration
+func Load(path string, expect MetadataInput) (map[string]PropertyIndex, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	metadata, err := unserializeMetadata(reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.AssertValid(expect); err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]PropertyIndex, len(metadata.Properties()))
+	for _, property := range metadata.Properties() {
+		var valuesCount int64
+		if err := binary.Read(reader, binary.BigEndian, &valuesCount); err != nil {
+			return nil, err
+		}
+
+		propertyIndex := make(PropertyIndex, int(valuesCount))
+		for i := int64(0); i < valuesCount; i++ {
+			value, err := readValue(reader)
+			if err != nil {
+				return nil, err
+			}
+
+			var positionsCount int64
+			if err := binary.Read(reader, binary.BigEndian, &positionsCount); err != nil {
+				return nil, err
+			}
+
+			positions := make([]record.Position, int(positionsCount))
+			if err := binary.Read(reader, binary.BigEndian, positions); err != nil {
+				return nil, err
+			}
+
+			propertyIndex[value] = positions
+		}
+
+		index[property] = propertyIndex
+	}
+
+	return index, nil
+}
@@ -0,0 +1,108 @@
+package mapfile
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.rodb")
+
+	mockInput := input.NewMock(parser.NewMock(), make([]record.Record, 42))
+	mockInput.SetModTime(time.Unix(1234, 0))
+	metadataInput := MetadataInput{
+		Input:      mockInput,
+		Properties: []string{"a", "b"},
+	}
+
+	paris, err := parser.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	dateTime := parser.NewDateTimeValue(time.Date(2021, 2, 10, 12, 30, 0, 500, paris), paris, "02/01/2006")
+	decimal, err := parser.NewDecimalValue("-12.5", 2, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	geoPoint, err := parser.NewGeoPointValue(48.8566, 2.3522)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	index := map[string]PropertyIndex{
+		"a": {
+			"foo":         record.PositionList{1, 2},
+			int64(42):     record.PositionList{3},
+			int(43):       record.PositionList{4},
+			float64(-1.5): record.PositionList{5},
+			true:          record.PositionList{6, 7, 8},
+			nil:           record.PositionList{9},
+			"":            record.PositionList{10},
+			dateTime:      record.PositionList{11},
+			decimal:       record.PositionList{12},
+			geoPoint:      record.PositionList{13},
+		},
+		"b": {},
+	}
+
+	metadata, err := NewMetadata(metadataInput)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if err := Save(path, metadata, index); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("Expected the temporary file to be removed, got '%v'", err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		loaded, err := Load(path, metadataInput)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if !reflect.DeepEqual(index, loaded) {
+			t.Fatalf("Expected '%v', got '%v'", index, loaded)
+		}
+	})
+	t.Run("modified input", func(t *testing.T) {
+		mockInput.SetModTime(time.Unix(1235, 0))
+		defer mockInput.SetModTime(time.Unix(1234, 0))
+
+		if _, err := Load(path, metadataInput); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("different properties", func(t *testing.T) {
+		if _, err := Load(path, MetadataInput{
+			Input:      mockInput,
+			Properties: []string{"b", "a"},
+		}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("different parser", func(t *testing.T) {
+		otherInput := input.NewMock(parser.NewInteger(&parser.IntegerConfig{}), make([]record.Record, 42))
+		otherInput.SetModTime(time.Unix(1234, 0))
+		if _, err := Load(path, MetadataInput{
+			Input:      otherInput,
+			Properties: []string{"a", "b"},
+		}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("unsupported value", func(t *testing.T) {
+		err := Save(filepath.Join(t.TempDir(), "index.rodb"), metadata, map[string]PropertyIndex{
+			"a": {struct{}{}: record.PositionList{1}},
+		})
+		if err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
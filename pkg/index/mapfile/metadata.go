@@ -0,0 +1,30 @@
+package mapfile
+
+import (
+	"github.com/rodb-io/rodb/pkg/index/indexfile"
+	"io"
+)
+
+// Current version of the file format
+const CurrentVersion = uint16(4)
+
+// Default magic bytes
+const ExpectedMagicBytes = "RODB/INDEX/MAP"
+
+var format = indexfile.Format{
+	Name:       "map",
+	MagicBytes: ExpectedMagicBytes,
+	Version:    CurrentVersion,
+}
+
+type Metadata = indexfile.Metadata
+
+type MetadataInput = indexfile.MetadataInput
+
+func NewMetadata(input MetadataInput) (*Metadata, error) {
+	return indexfile.NewMetadata(format, input)
+}
+
+func unserializeMetadata(data io.Reader) (*Metadata, error) {
+	return indexfile.Unserialize(format, data)
+}
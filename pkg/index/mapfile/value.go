@@ -0,0 +1,206 @@
+package mapfile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/index/indexfile"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"io"
+	"time"
+)
+
+// Identifies the type of each serialized value,
+// so that it can be unserialized with the same type
+const (
+	valueTypeNil      = byte(0)
+	valueTypeString   = byte(1)
+	valueTypeInt64    = byte(2)
+	valueTypeInt      = byte(3)
+	valueTypeFloat64  = byte(4)
+	valueTypeBool     = byte(5)
+	valueTypeDateTime = byte(6)
+	valueTypeDecimal  = byte(7)
+	valueTypeGeoPoint = byte(8)
+)
+
+func writeValue(data io.Writer, value interface{}) error {
+	switch value.(type) {
+	case nil:
+		return binary.Write(data, binary.BigEndian, valueTypeNil)
+	case string:
+		if err := binary.Write(data, binary.BigEndian, valueTypeString); err != nil {
+			return err
+		}
+		return indexfile.WriteString(data, value.(string))
+	case int64:
+		if err := binary.Write(data, binary.BigEndian, valueTypeInt64); err != nil {
+			return err
+		}
+		return binary.Write(data, binary.BigEndian, value.(int64))
+	case int:
+		if err := binary.Write(data, binary.BigEndian, valueTypeInt); err != nil {
+			return err
+		}
+		return binary.Write(data, binary.BigEndian, int64(value.(int)))
+	case float64:
+		if err := binary.Write(data, binary.BigEndian, valueTypeFloat64); err != nil {
+			return err
+		}
+		return binary.Write(data, binary.BigEndian, value.(float64))
+	case bool:
+		if err := binary.Write(data, binary.BigEndian, valueTypeBool); err != nil {
+			return err
+		}
+		return binary.Write(data, binary.BigEndian, value.(bool))
+	case parser.DateTimeValue:
+		if err := binary.Write(data, binary.BigEndian, valueTypeDateTime); err != nil {
+			return err
+		}
+		return writeDateTime(data, value.(parser.DateTimeValue))
+	case parser.DecimalValue:
+		if err := binary.Write(data, binary.BigEndian, valueTypeDecimal); err != nil {
+			return err
+		}
+		return writeDecimal(data, value.(parser.DecimalValue))
+	case parser.GeoPointValue:
+		if err := binary.Write(data, binary.BigEndian, valueTypeGeoPoint); err != nil {
+			return err
+		}
+		return writeGeoPoint(data, value.(parser.GeoPointValue))
+	default:
+		return fmt.Errorf("Cannot save a value of type %T in a map index file.", value)
+	}
+}
+
+func readValue(data io.Reader) (interface{}, error) {
+	var valueType byte
+	if err := binary.Read(data, binary.BigEndian, &valueType); err != nil {
+		return nil, err
+	}
+
+	switch valueType {
+	case valueTypeNil:
+		return nil, nil
+	case valueTypeString:
+		return indexfile.ReadString(data)
+	case valueTypeInt64:
+		var value int64
+		err := binary.Read(data, binary.BigEndian, &value)
+		return value, err
+	case valueTypeInt:
+		var value int64
+		err := binary.Read(data, binary.BigEndian, &value)
+		return int(value), err
+	case valueTypeFloat64:
+		var value float64
+		err := binary.Read(data, binary.BigEndian, &value)
+		return value, err
+	case valueTypeBool:
+		var value bool
+		err := binary.Read(data, binary.BigEndian, &value)
+		return value, err
+	case valueTypeDateTime:
+		return readDateTime(data)
+	case valueTypeDecimal:
+		return readDecimal(data)
+	case valueTypeGeoPoint:
+		return readGeoPoint(data)
+	default:
+		return nil, fmt.Errorf("Unknown value type %v in the map index file.", valueType)
+	}
+}
+
+// The time zone and output format are saved with the time,
+// so that the loaded value is equal to the parsed one
+func writeDateTime(data io.Writer, value parser.DateTimeValue) error {
+	if err := binary.Write(data, binary.BigEndian, value.Time().Unix()); err != nil {
+		return err
+	}
+	if err := binary.Write(data, binary.BigEndian, int64(value.Time().Nanosecond())); err != nil {
+		return err
+	}
+	if err := indexfile.WriteString(data, value.Time().Location().String()); err != nil {
+		return err
+	}
+	return indexfile.WriteString(data, value.Format())
+}
+
+func readDateTime(data io.Reader) (parser.DateTimeValue, error) {
+	var seconds, nanoseconds int64
+	if err := binary.Read(data, binary.BigEndian, &seconds); err != nil {
+		return parser.DateTimeValue{}, err
+	}
+	if err := binary.Read(data, binary.BigEndian, &nanoseconds); err != nil {
+		return parser.DateTimeValue{}, err
+	}
+
+	locationName, err := indexfile.ReadString(data)
+	if err != nil {
+		return parser.DateTimeValue{}, err
+	}
+	location, err := parser.LoadLocation(locationName)
+	if err != nil {
+		return parser.DateTimeValue{}, err
+	}
+
+	format, err := indexfile.ReadString(data)
+	if err != nil {
+		return parser.DateTimeValue{}, err
+	}
+
+	return parser.NewDateTimeValue(time.Unix(seconds, nanoseconds), location, format), nil
+}
+
+// The scale and JSON format are saved with the number,
+// so that the loaded value is equal to the parsed one
+func writeDecimal(data io.Writer, value parser.DecimalValue) error {
+	number, err := value.Value()
+	if err != nil {
+		return err
+	}
+	if err := indexfile.WriteString(data, number.(string)); err != nil {
+		return err
+	}
+	if err := binary.Write(data, binary.BigEndian, int64(value.Scale())); err != nil {
+		return err
+	}
+	return binary.Write(data, binary.BigEndian, value.JsonAsString())
+}
+
+func readDecimal(data io.Reader) (parser.DecimalValue, error) {
+	number, err := indexfile.ReadString(data)
+	if err != nil {
+		return parser.DecimalValue{}, err
+	}
+
+	var scale int64
+	if err := binary.Read(data, binary.BigEndian, &scale); err != nil {
+		return parser.DecimalValue{}, err
+	}
+
+	var jsonAsString bool
+	if err := binary.Read(data, binary.BigEndian, &jsonAsString); err != nil {
+		return parser.DecimalValue{}, err
+	}
+
+	return parser.NewDecimalValue(number, int(scale), jsonAsString)
+}
+
+func writeGeoPoint(data io.Writer, value parser.GeoPointValue) error {
+	if err := binary.Write(data, binary.BigEndian, value.Latitude()); err != nil {
+		return err
+	}
+	return binary.Write(data, binary.BigEndian, value.Longitude())
+}
+
+func readGeoPoint(data io.Reader) (parser.GeoPointValue, error) {
+	var latitude, longitude float64
+	if err := binary.Read(data, binary.BigEndian, &latitude); err != nil {
+		return parser.GeoPointValue{}, err
+	}
+	if err := binary.Read(data, binary.BigEndian, &longitude); err != nil {
+		return parser.GeoPointValue{}, err
+	}
+
+	return parser.NewGeoPointValue(latitude, longitude)
+}
@@ -60,17 +60,11 @@ func (noop *Noop) GetRecordPositions(
 					return nil, err
 				}
 
-				if value == nil {
-					if filter == nil {
-						continue
-					} else {
-						matches = false
-						break
-					}
+				// A nil filter matches the records where the property is null
+				if value != nil {
+					value = reflect.ValueOf(value).Interface()
 				}
-
-				value = reflect.ValueOf(value).Interface()
-				if value != filter {
+				if getMapKey(value) != getMapKey(filter) {
 					matches = false
 					break
 				}
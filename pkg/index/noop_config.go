@@ -30,6 +30,14 @@ func (config *NoopConfig) DoesHandleProperty(property string) bool {
 	return true
 }
 
+func (config *NoopConfig) DoesHandleNullValues() bool {
+	return true
+}
+
+func (config *NoopConfig) GetPropertyReferences() []input.PropertyReference {
+	return []input.PropertyReference{}
+}
+
 func (config *NoopConfig) DoesHandleInput(input input.Config) bool {
 	return true
 }
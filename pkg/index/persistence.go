@@ -0,0 +1,316 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/index/fuzzyfile"
+	"github.com/rodb-io/rodb/pkg/index/geofile"
+	"github.com/rodb-io/rodb/pkg/index/mapfile"
+	sqlitePackage "github.com/rodb-io/rodb/pkg/index/sqlite"
+	wildcardPackage "github.com/rodb-io/rodb/pkg/index/wildcard"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/util"
+	"os"
+)
+
+// Implemented by the configs of the indexes which can store their data
+// in a file or database, so that it can be built ahead of time and verified.
+type PersistentConfig interface {
+	Config
+	// Returns false when the persistence is optional and not enabled
+	IsPersistent() bool
+	// Returns the file (or database) containing the persisted data
+	FilePath() string
+	// Checks that the persisted data exists, is complete, and matches
+	// the current state of the input, without loading or building it
+	Verify(inputs input.List) error
+	// Removes the persisted data, so that it gets rebuilt
+	Reset() error
+}
+
+// Returns true if the index stores it's data in a file or database,
+// meaning that it can be built ahead of time and verified.
+func IsPersistent(config Config) bool {
+	persistentConfig, isPersistentConfig := config.(PersistentConfig)
+	return isPersistentConfig && persistentConfig.IsPersistent()
+}
+
+// Returns the file (or database) containing the persisted data of the index
+func FilePath(config Config) string {
+	if !IsPersistent(config) {
+		return ""
+	}
+
+	return config.(PersistentConfig).FilePath()
+}
+
+// Checks that the persisted data of the index exists, is complete,
+// and matches the current state of the input, without loading or building it.
+func Verify(config Config, inputs input.List) error {
+	if !IsPersistent(config) {
+		return fmt.Errorf("The index '%v' is not persisted.", config.GetName())
+	}
+
+	return config.(PersistentConfig).Verify(inputs)
+}
+
+// Removes the persisted data of the index,
+// so that it gets rebuilt the next time it is created.
+func Reset(config Config) error {
+	if !IsPersistent(config) {
+		return fmt.Errorf("The index '%v' is not persisted.", config.GetName())
+	}
+
+	return config.(PersistentConfig).Reset()
+}
+
+func getInput(inputName string, inputs input.List) (input.Input, error) {
+	input, inputExists := inputs[inputName]
+	if !inputExists {
+		return nil, fmt.Errorf("Input '%v' not found in inputs list.", inputName)
+	}
+
+	return input, nil
+}
+
+func assertIndexFileExists(path string) error {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return errors.New("The index file does not exist.")
+	}
+
+	return err
+}
+
+func removeIndexFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (config *MapConfig) IsPersistent() bool {
+	return config.Path != ""
+}
+
+func (config *MapConfig) FilePath() string {
+	return config.Path
+}
+
+func (config *MapConfig) Reset() error {
+	return removeIndexFile(config.Path)
+}
+
+func (config *MapConfig) Verify(inputs input.List) error {
+	input, err := getInput(config.Input, inputs)
+	if err != nil {
+		return err
+	}
+
+	if err := assertIndexFileExists(config.Path); err != nil {
+		return err
+	}
+
+	metadata, err := mapfile.LoadMetadata(config.Path)
+	if err != nil {
+		return err
+	}
+
+	return metadata.AssertValid(mapfile.MetadataInput{
+		Input:      input,
+		Properties: config.Properties,
+	})
+}
+
+func (config *GeoConfig) IsPersistent() bool {
+	return config.Path != ""
+}
+
+func (config *GeoConfig) FilePath() string {
+	return config.Path
+}
+
+func (config *GeoConfig) Reset() error {
+	return removeIndexFile(config.Path)
+}
+
+func (config *GeoConfig) Verify(inputs input.List) error {
+	input, err := getInput(config.Input, inputs)
+	if err != nil {
+		return err
+	}
+
+	if err := assertIndexFileExists(config.Path); err != nil {
+		return err
+	}
+
+	metadata, err := geofile.LoadMetadata(config.Path)
+	if err != nil {
+		return err
+	}
+
+	return metadata.AssertValid(geofile.MetadataInput{
+		Input:      input,
+		Properties: config.Properties(),
+	})
+}
+
+func (config *WildcardConfig) IsPersistent() bool {
+	return true
+}
+
+func (config *WildcardConfig) FilePath() string {
+	return config.Path
+}
+
+func (config *WildcardConfig) Reset() error {
+	return removeIndexFile(config.Path)
+}
+
+func (config *WildcardConfig) Verify(inputs input.List) error {
+	input, err := getInput(config.Input, inputs)
+	if err != nil {
+		return err
+	}
+
+	if err := assertIndexFileExists(config.Path); err != nil {
+		return err
+	}
+
+	indexFileStat, err := os.Stat(config.Path)
+	if err != nil {
+		return err
+	}
+
+	indexFile, err := util.OpenReadOnlyFile(config.Path, false, false)
+	if err != nil {
+		return err
+	}
+	defer indexFile.Close()
+
+	metadata, err := wildcardPackage.LoadMetadata(
+		wildcardPackage.NewReadOnlyStream(indexFile, indexFileStat.Size()),
+	)
+	if err != nil {
+		return err
+	}
+
+	return metadata.AssertValid(wildcardPackage.MetadataInput{
+		Input:          input,
+		IgnoreCase:     config.ShouldIgnoreCase(),
+		RootNodesCount: len(config.Properties),
+	})
+}
+
+func (config *FuzzyConfig) IsPersistent() bool {
+	return true
+}
+
+func (config *FuzzyConfig) FilePath() string {
+	return config.Path
+}
+
+func (config *FuzzyConfig) Reset() error {
+	return removeIndexFile(config.Path)
+}
+
+func (config *FuzzyConfig) Verify(inputs input.List) error {
+	input, err := getInput(config.Input, inputs)
+	if err != nil {
+		return err
+	}
+
+	if err := assertIndexFileExists(config.Path); err != nil {
+		return err
+	}
+
+	metadata, err := fuzzyfile.LoadMetadata(config.Path)
+	if err != nil {
+		return err
+	}
+
+	return metadata.AssertValid(fuzzyfile.MetadataInput{
+		Input:      input,
+		Properties: config.Properties,
+		IgnoreCase: config.ShouldIgnoreCase(),
+	})
+}
+
+func (config *SqliteConfig) IsPersistent() bool {
+	return true
+}
+
+func (config *SqliteConfig) FilePath() string {
+	return config.Dsn
+}
+
+func (config *SqliteConfig) Verify(inputs input.List) error {
+	return verifySqliteDatabase(config.Dsn, config.Name, config.Input, config.getMetadataProperties(), inputs)
+}
+
+func (config *SqliteConfig) Reset() error {
+	return resetSqliteDatabase(config.Dsn, config.Name)
+}
+
+func (config *Fts5Config) IsPersistent() bool {
+	return true
+}
+
+func (config *Fts5Config) FilePath() string {
+	return config.Dsn
+}
+
+func (config *Fts5Config) Verify(inputs input.List) error {
+	return verifySqliteDatabase(config.Dsn, config.Name, config.Input, config.getMetadataProperties(), inputs)
+}
+
+func (config *Fts5Config) Reset() error {
+	return resetSqliteDatabase(config.Dsn, config.Name)
+}
+
+func verifySqliteDatabase(dsn string, indexName string, inputName string, properties []string, inputs input.List) error {
+	input, err := getInput(inputName, inputs)
+	if err != nil {
+		return err
+	}
+
+	db, err := sqlitePackage.Open(dsn)
+	if err != nil {
+		return fmt.Errorf("Error while opening the sqlite DSN: %w", err)
+	}
+	defer db.Close()
+
+	metadataExists, err := sqlitePackage.HasMetadata(db, indexName)
+	if err != nil {
+		return fmt.Errorf("Error while checking metadata from the index: %w", err)
+	}
+	if !metadataExists {
+		return errors.New("The index does not exist in the database.")
+	}
+
+	metadata, err := sqlitePackage.LoadMetadata(db, indexName)
+	if err != nil {
+		return err
+	}
+
+	return metadata.AssertValid(input, properties)
+}
+
+func resetSqliteDatabase(dsn string, indexName string) error {
+	db, err := sqlitePackage.Open(dsn)
+	if err != nil {
+		return fmt.Errorf("Error while opening the sqlite DSN: %w", err)
+	}
+	defer db.Close()
+
+	tableIdentifier, err := sqlitePackage.SanitizeIdentifier(db, fmt.Sprintf("rodb_%v_index", indexName))
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec(`DROP TABLE IF EXISTS ` + tableIdentifier + `;`); err != nil {
+		return err
+	}
+
+	return sqlitePackage.DropMetadata(db, indexName)
+}
@@ -0,0 +1,216 @@
+package index
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// An index type which could be registered outside of this package
+type persistentConfigForTests struct {
+	NoopConfig
+	path     string
+	verified bool
+	reset    bool
+}
+
+func (config *persistentConfigForTests) IsPersistent() bool {
+	return config.path != ""
+}
+
+func (config *persistentConfigForTests) FilePath() string {
+	return config.path
+}
+
+func (config *persistentConfigForTests) Verify(inputs input.List) error {
+	config.verified = true
+	return nil
+}
+
+func (config *persistentConfigForTests) Reset() error {
+	config.reset = true
+	return nil
+}
+
+func TestIsPersistent(t *testing.T) {
+	for _, testCase := range []struct {
+		name   string
+		config Config
+		expect bool
+	}{
+		{"map", &MapConfig{}, false},
+		{"map with path", &MapConfig{Path: "index.rodb"}, true},
+		{"geo", &GeoConfig{}, false},
+		{"geo with path", &GeoConfig{Path: "index.rodb"}, true},
+		{"wildcard", &WildcardConfig{}, true},
+		{"fuzzy", &FuzzyConfig{}, true},
+		{"sqlite", &SqliteConfig{}, true},
+		{"fts5", &Fts5Config{}, true},
+		{"noop", &NoopConfig{}, false},
+		{"custom", &persistentConfigForTests{}, false},
+		{"custom with path", &persistentConfigForTests{path: "index.rodb"}, true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := IsPersistent(testCase.config); got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	for _, testCase := range []struct {
+		name   string
+		config Config
+		expect string
+	}{
+		{"map", &MapConfig{Path: "map.rodb"}, "map.rodb"},
+		{"geo", &GeoConfig{Path: "geo.rodb"}, "geo.rodb"},
+		{"wildcard", &WildcardConfig{Path: "wildcard.rodb"}, "wildcard.rodb"},
+		{"fuzzy", &FuzzyConfig{Path: "fuzzy.rodb"}, "fuzzy.rodb"},
+		{"sqlite", &SqliteConfig{Dsn: "sqlite.rodb"}, "sqlite.rodb"},
+		{"fts5", &Fts5Config{Dsn: "fts5.rodb"}, "fts5.rodb"},
+		{"noop", &NoopConfig{}, ""},
+		{"custom", &persistentConfigForTests{path: "custom.rodb"}, "custom.rodb"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := FilePath(testCase.config); got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestVerifyAndReset(t *testing.T) {
+	mockInput := input.NewMock(parser.NewMock(), []record.Record{
+		record.NewMockRecord(
+			map[string]string{"col": "a"},
+			map[string]int{},
+			map[string]float64{"latitude": 48.8566, "longitude": 2.3522},
+			map[string]bool{},
+			0,
+		),
+	})
+	mockInput.SetModTime(time.Unix(1234, 0))
+	inputs := input.List{"input": mockInput}
+
+	falseValue := false
+	logger := logrus.NewEntry(logrus.StandardLogger())
+	directory := t.TempDir()
+	for _, config := range []Config{
+		&MapConfig{
+			Name:       "map",
+			Input:      "input",
+			Path:       filepath.Join(directory, "map.rodb"),
+			Properties: []string{"col"},
+			Logger:     logger,
+		},
+		&GeoConfig{
+			Name:              "geo",
+			Input:             "input",
+			Path:              filepath.Join(directory, "geo.rodb"),
+			LatitudeProperty:  "latitude",
+			LongitudeProperty: "longitude",
+			Logger:            logger,
+		},
+		&WildcardConfig{
+			Name:       "wildcard",
+			Input:      "input",
+			Path:       filepath.Join(directory, "wildcard.rodb"),
+			Properties: []string{"col"},
+			IgnoreCase: &falseValue,
+			Logger:     logger,
+		},
+		&FuzzyConfig{
+			Name:       "fuzzy",
+			Input:      "input",
+			Path:       filepath.Join(directory, "fuzzy.rodb"),
+			Properties: []string{"col"},
+			IgnoreCase: &falseValue,
+			Logger:     logger,
+		},
+		&SqliteConfig{
+			Name:       "sqlite",
+			Input:      "input",
+			Dsn:        filepath.Join(directory, "sqlite.rodb"),
+			Properties: []*SqlitePropertyConfig{{Name: "col", Collate: "binary"}},
+			Logger:     logger,
+		},
+	} {
+		t.Run(config.GetName(), func(t *testing.T) {
+			if err := Verify(config, inputs); err == nil {
+				t.Fatalf("Expected an error before the index is built, got nil")
+			}
+
+			index, err := NewFromConfig(config, inputs)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if err := index.Close(); err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+
+			if err := Verify(config, inputs); err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+
+			mockInput.SetModTime(time.Unix(1235, 0))
+			if err := Verify(config, inputs); err == nil {
+				t.Fatalf("Expected an error after the input is modified, got nil")
+			}
+			mockInput.SetModTime(time.Unix(1234, 0))
+
+			if err := Reset(config); err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if err := Verify(config, inputs); err == nil {
+				t.Fatalf("Expected an error after the index is reset, got nil")
+			}
+			if err := Reset(config); err != nil {
+				t.Fatalf("Unexpected error when resetting twice: '%+v'", err)
+			}
+		})
+	}
+	t.Run("sqlite changed properties", func(t *testing.T) {
+		config := &SqliteConfig{
+			Name:       "sqlite",
+			Input:      "input",
+			Dsn:        filepath.Join(t.TempDir(), "sqlite.rodb"),
+			Properties: []*SqlitePropertyConfig{{Name: "col", Collate: "binary"}},
+			Logger:     logger,
+		}
+		index, err := NewFromConfig(config, inputs)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if err := index.Close(); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		config.Properties = []*SqlitePropertyConfig{{Name: "col", Collate: "nocase"}}
+		if err := Verify(config, inputs); err == nil {
+			t.Fatalf("Expected an error after the properties are changed, got nil")
+		}
+	})
+	t.Run("custom", func(t *testing.T) {
+		config := &persistentConfigForTests{path: "custom.rodb"}
+		if err := Verify(config, inputs); err != nil || !config.verified {
+			t.Fatalf("Expected the config to be verified, got '%+v'", err)
+		}
+		if err := Reset(config); err != nil || !config.reset {
+			t.Fatalf("Expected the config to be reset, got '%+v'", err)
+		}
+	})
+	t.Run("not persisted", func(t *testing.T) {
+		if err := Verify(&NoopConfig{}, inputs); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+		if err := Reset(&NoopConfig{}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
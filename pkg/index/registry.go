@@ -0,0 +1,114 @@
+package index
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"reflect"
+	"sort"
+)
+
+// Creates an index from its validated config, and fills it.
+type Constructor = func(config Config, inputs input.List) (Index, error)
+
+// Creates an index without filling it. The returned builder
+// is nil when the index does not need to be built.
+type unbuiltConstructor = func(config Config, inputs input.List) (Index, builder, error)
+
+type registration struct {
+	newConfig   func() Config
+	constructor Constructor
+	// Only available for the built-in indexes, which
+	// can be built together when using the same input
+	unbuiltConstructor unbuiltConstructor
+}
+
+var registrations = map[string]*registration{}
+var registrationsByConfigType = map[reflect.Type]*registration{}
+
+func init() {
+	register("map", func() Config { return &MapConfig{} }, func(config Config, inputs input.List) (Index, error) {
+		return NewMap(config.(*MapConfig), inputs)
+	}, func(config Config, inputs input.List) (Index, builder, error) {
+		return newMap(config.(*MapConfig), inputs)
+	})
+	register("wildcard", func() Config { return &WildcardConfig{} }, func(config Config, inputs input.List) (Index, error) {
+		return NewWildcard(config.(*WildcardConfig), inputs)
+	}, func(config Config, inputs input.List) (Index, builder, error) {
+		return newWildcard(config.(*WildcardConfig), inputs)
+	})
+	register("geo", func() Config { return &GeoConfig{} }, func(config Config, inputs input.List) (Index, error) {
+		return NewGeo(config.(*GeoConfig), inputs)
+	}, func(config Config, inputs input.List) (Index, builder, error) {
+		return newGeo(config.(*GeoConfig), inputs)
+	})
+	register("fuzzy", func() Config { return &FuzzyConfig{} }, func(config Config, inputs input.List) (Index, error) {
+		return NewFuzzy(config.(*FuzzyConfig), inputs)
+	}, func(config Config, inputs input.List) (Index, builder, error) {
+		return newFuzzy(config.(*FuzzyConfig), inputs)
+	})
+	register("sqlite", func() Config { return &SqliteConfig{} }, func(config Config, inputs input.List) (Index, error) {
+		return NewSqlite(config.(*SqliteConfig), inputs)
+	}, func(config Config, inputs input.List) (Index, builder, error) {
+		return newSqlite(config.(*SqliteConfig), inputs)
+	})
+	register("fts5", func() Config { return &Fts5Config{} }, func(config Config, inputs input.List) (Index, error) {
+		return NewFts5(config.(*Fts5Config), inputs)
+	}, func(config Config, inputs input.List) (Index, builder, error) {
+		return newFts5(config.(*Fts5Config), inputs)
+	})
+	register("noop", func() Config { return &NoopConfig{} }, func(config Config, inputs input.List) (Index, error) {
+		return NewNoop(config.(*NoopConfig), inputs), nil
+	}, nil)
+}
+
+// Makes a type of index available under the given "type" value.
+// Unlike the built-in ones, it is not built together with
+// the other indexes of the same input.
+func Register(typeName string, newConfig func() Config, constructor Constructor) {
+	register(typeName, newConfig, constructor, nil)
+}
+
+func register(
+	typeName string,
+	newConfig func() Config,
+	constructor Constructor,
+	unbuiltConstructor unbuiltConstructor,
+) {
+	if _, exists := registrations[typeName]; exists {
+		panic(fmt.Sprintf("The index type '%v' is already registered.", typeName))
+	}
+
+	configType := reflect.TypeOf(newConfig())
+	if _, exists := registrationsByConfigType[configType]; exists {
+		panic(fmt.Sprintf("The index config type '%v' is already registered.", configType))
+	}
+
+	registration := &registration{
+		newConfig:          newConfig,
+		constructor:        constructor,
+		unbuiltConstructor: unbuiltConstructor,
+	}
+	registrations[typeName] = registration
+	registrationsByConfigType[configType] = registration
+}
+
+// Returns a new, empty config of the given index type
+func NewConfig(typeName string) (Config, bool) {
+	registration, exists := registrations[typeName]
+	if !exists {
+		return nil, false
+	}
+
+	return registration.newConfig(), true
+}
+
+// Returns the sorted names of the registered index types
+func TypeNames() []string {
+	typeNames := make([]string, 0, len(registrations))
+	for typeName := range registrations {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	return typeNames
+}
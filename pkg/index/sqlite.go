@@ -7,7 +7,7 @@ import (
 	sqlitePackage "github.com/rodb-io/rodb/pkg/index/sqlite"
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
-	"github.com/rodb-io/rodb/pkg/util"
+	"github.com/sirupsen/logrus"
 	"reflect"
 	"strings"
 )
@@ -22,16 +22,34 @@ func NewSqlite(
 	config *SqliteConfig,
 	inputs input.List,
 ) (*Sqlite, error) {
+	sqlite, indexBuilder, err := newSqlite(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if indexBuilder != nil {
+		if err := build([]builder{indexBuilder}); err != nil {
+			return nil, fmt.Errorf("Error while creating the index: %w", err)
+		}
+	}
+
+	return sqlite, nil
+}
+
+func newSqlite(
+	config *SqliteConfig,
+	inputs input.List,
+) (*Sqlite, builder, error) {
 	input, inputExists := inputs[config.Input]
 	if !inputExists {
-		return nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
+		return nil, nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
 	}
 
 	// We use the driver directly rather than the database/sql interfaces, because the generic
 	// way tries to autodetect and normalize the return types. But we want to get the real types.
 	db, err := sqlitePackage.Open(config.Dsn)
 	if err != nil {
-		return nil, fmt.Errorf("Error while opening the sqlite DSN: %w", err)
+		return nil, nil, fmt.Errorf("Error while opening the sqlite DSN: %w", err)
 	}
 
 	sqlite := &Sqlite{
@@ -42,55 +60,48 @@ func NewSqlite(
 
 	metadataExists, err := sqlitePackage.HasMetadata(sqlite.db, sqlite.config.Name)
 	if err != nil {
-		return nil, fmt.Errorf("Error while checking metadata from the index: %w", err)
+		return nil, nil, fmt.Errorf("Error while checking metadata from the index: %w", err)
 	}
 	if metadataExists {
 		metadata, err := sqlitePackage.LoadMetadata(sqlite.db, sqlite.config.Name)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		if err := metadata.AssertValid(sqlite.input); err != nil {
-			return nil, err
+		if err := metadata.AssertValid(sqlite.input, sqlite.config.getMetadataProperties()); err != nil {
+			return nil, nil, err
 		}
 	} else {
-		if err := sqlite.createIndex(); err != nil {
-			return nil, fmt.Errorf("Error while creating the index: %w", err)
+		indexBuilder, err := sqlite.createIndex()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error while creating the index: %w", err)
 		}
+
+		return sqlite, indexBuilder, nil
 	}
 
-	return sqlite, nil
+	return sqlite, nil, nil
 }
 
 func (sqlite *Sqlite) Name() string {
 	return sqlite.config.Name
 }
 
-func (sqlite *Sqlite) createIndex() error {
+// Creates the index table, which is filled by the returned builder
+func (sqlite *Sqlite) createIndex() (*sqliteBuilder, error) {
 	metadata, err := sqlitePackage.NewMetadata(
 		sqlite.db,
 		sqlite.config.Name,
 		sqlite.input,
+		sqlite.config.getMetadataProperties(),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := metadata.Save(); err != nil {
-		return err
-	}
-
-	updateProgress := util.TrackProgress(sqlite.input, sqlite.config.Logger)
-
-	inputIterator, end, err := sqlite.input.IterateAll()
-	if err != nil {
-		return err
+		return nil, err
 	}
-	defer func() {
-		if err := end(); err != nil {
-			sqlite.config.Logger.Errorf("Error while closing the input iterator: %v", err)
-		}
-	}()
 
 	columnDefinitions := make([]string, len(sqlite.config.Properties))
 	columnIdentifiers := make([]string, len(sqlite.config.Properties))
@@ -99,11 +110,11 @@ func (sqlite *Sqlite) createIndex() error {
 	for propertyIndex, property := range sqlite.config.Properties {
 		propertyIdentifier, err := sqlite.getPropertyIdentifier(property.Name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		indexIdentifier, err := sqlite.getIndexIdentifier(property.Name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		columnDefinitions[propertyIndex] = propertyIdentifier + " BLOB COLLATE " + property.Collate
@@ -114,7 +125,7 @@ func (sqlite *Sqlite) createIndex() error {
 
 	tableIdentifier, err := sqlite.getIndexTableIdentifier()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	_, err = sqlite.db.Exec(`
@@ -124,7 +135,7 @@ func (sqlite *Sqlite) createIndex() error {
 		);
 	`)
 	if err != nil {
-		return fmt.Errorf("Error while creating index table: %w", err)
+		return nil, fmt.Errorf("Error while creating index table: %w", err)
 	}
 
 	for propertyIndex, indexIdentifier := range indexIdentifiers {
@@ -132,7 +143,7 @@ func (sqlite *Sqlite) createIndex() error {
 			CREATE INDEX ` + indexIdentifier + ` ON ` + tableIdentifier + ` (` + columnIdentifiers[propertyIndex] + `);
 		`)
 		if err != nil {
-			return fmt.Errorf("Error while creating index table index: %w", err)
+			return nil, fmt.Errorf("Error while creating index table index: %w", err)
 		}
 	}
 
@@ -143,56 +154,77 @@ func (sqlite *Sqlite) createIndex() error {
 		) VALUES (?, ` + strings.Join(insertPlaceholders, ", ") + `);
 	`)
 	if err != nil {
-		return fmt.Errorf("Error while preparing index table insert query: %w", err)
+		return nil, fmt.Errorf("Error while preparing index table insert query: %w", err)
 	}
 
-	valuesToInsert := make([]interface{}, 1+len(sqlite.config.Properties))
-	for {
-		record, err := inputIterator()
+	return &sqliteBuilder{
+		sqlite:          sqlite,
+		metadata:        metadata,
+		tableIdentifier: tableIdentifier,
+		preparedInsert:  preparedInsert,
+		valuesToInsert:  make([]interface{}, 1+len(sqlite.config.Properties)),
+	}, nil
+}
+
+type sqliteBuilder struct {
+	sqlite          *Sqlite
+	metadata        *sqlitePackage.Metadata
+	tableIdentifier string
+	preparedInsert  *sql.Stmt
+	valuesToInsert  []interface{}
+}
+
+func (builder *sqliteBuilder) input() input.Input {
+	return builder.sqlite.input
+}
+
+func (builder *sqliteBuilder) logger() *logrus.Entry {
+	return builder.sqlite.config.Logger
+}
+
+func (builder *sqliteBuilder) add(record record.Record) error {
+	builder.valuesToInsert[0] = record.Position()
+	for propertyIndex, property := range builder.sqlite.config.Properties {
+		value, err := record.Get(property.Name)
 		if err != nil {
 			return err
 		}
-		if record == nil {
-			break
-		}
 
-		updateProgress(record.Position())
+		if value != nil {
+			value = reflect.ValueOf(value).Interface()
+		}
 
-		valuesToInsert[0] = record.Position()
-		for propertyIndex, property := range sqlite.config.Properties {
-			value, err := record.Get(property.Name)
-			if err != nil {
-				return err
-			}
+		builder.valuesToInsert[propertyIndex+1] = value
+	}
 
-			if value != nil {
-				value = reflect.ValueOf(value).Interface()
-			}
+	if _, err := builder.preparedInsert.Exec(builder.valuesToInsert...); err != nil {
+		return err
+	}
 
-			valuesToInsert[propertyIndex+1] = value
-		}
+	return nil
+}
 
-		if _, err = preparedInsert.Exec(valuesToInsert...); err != nil {
-			return err
-		}
+func (builder *sqliteBuilder) finish() error {
+	if err := builder.preparedInsert.Close(); err != nil {
+		return err
 	}
 
-	metadata.SetCompleted(true)
-	if err := metadata.Save(); err != nil {
+	builder.metadata.SetCompleted(true)
+	if err := builder.metadata.Save(); err != nil {
 		return err
 	}
 
-	row := sqlite.db.QueryRow(`SELECT COUNT(1) FROM ` + tableIdentifier + `;`)
+	row := builder.sqlite.db.QueryRow(`SELECT COUNT(1) FROM ` + builder.tableIdentifier + `;`)
 	if err := row.Err(); err != nil {
 		return err
 	}
 
 	var indexedRows int64
-	if err = row.Scan(&indexedRows); err != nil {
+	if err := row.Scan(&indexedRows); err != nil {
 		return err
 	}
 
-	sqlite.config.Logger.WithField("indexedRows", indexedRows).Infof("Successfully finished indexing")
+	builder.sqlite.config.Logger.WithField("indexedRows", indexedRows).Infof("Successfully finished indexing")
 
 	return nil
 }
@@ -241,6 +273,12 @@ func (sqlite *Sqlite) GetRecordPositions(
 			return nil, err
 		}
 
+		// "= NULL" never matches, but "IS NULL" can still use the index
+		if filter == nil {
+			clauses = append(clauses, columnIdentifier+" IS NULL")
+			continue
+		}
+
 		clauses = append(clauses, columnIdentifier+" = ?")
 		values = append(values, filter)
 	}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/rodb-io/rodb/pkg/input"
@@ -9,7 +10,7 @@ import (
 )
 
 // Current version of the indexing protocol
-const CurrentVersion = uint16(1)
+const CurrentVersion = uint16(2)
 
 type Metadata struct {
 	db                        *sql.DB
@@ -17,13 +18,16 @@ type Metadata struct {
 	version                   uint16
 	inputFileModificationTime time.Time
 	inputFileSize             int64
-	completed                 bool
+	// The indexed properties, with their indexing options
+	properties []string
+	completed  bool
 }
 
 func NewMetadata(
 	db *sql.DB,
 	indexName string,
 	input input.Input,
+	properties []string,
 ) (*Metadata, error) {
 	size, err := input.Size()
 	if err != nil {
@@ -41,6 +45,7 @@ func NewMetadata(
 		version:                   CurrentVersion,
 		inputFileModificationTime: modTime,
 		inputFileSize:             size,
+		properties:                properties,
 		completed:                 false,
 	}
 
@@ -61,11 +66,23 @@ func LoadMetadata(
 		return nil, err
 	}
 
+	// The columns of the other versions may be different
+	versionRow := metadata.db.QueryRow(`SELECT "version" FROM ` + tableIdentifier + `;`)
+	if err := versionRow.Err(); err != nil {
+		return nil, err
+	}
+	if err = versionRow.Scan(&metadata.version); err != nil {
+		return nil, err
+	}
+	if metadata.version != CurrentVersion {
+		return metadata, nil
+	}
+
 	row := metadata.db.QueryRow(`
 		SELECT
-			"version",
 			"inputFileModificationTime",
 			"inputFileSize",
+			"properties",
 			"completed"
 		FROM ` + tableIdentifier + `;
 	`)
@@ -74,11 +91,16 @@ func LoadMetadata(
 	}
 
 	var modificationTime int64
-	if err = row.Scan(&metadata.version, &modificationTime, &metadata.inputFileSize, &metadata.completed); err != nil {
+	var properties string
+	if err = row.Scan(&modificationTime, &metadata.inputFileSize, &properties, &metadata.completed); err != nil {
 		return nil, err
 	}
 	metadata.inputFileModificationTime = time.Unix(modificationTime, 0)
 
+	if err := json.Unmarshal([]byte(properties), &metadata.properties); err != nil {
+		return nil, fmt.Errorf("Cannot read the properties of the index metadata: %w", err)
+	}
+
 	return metadata, nil
 }
 
@@ -114,6 +136,26 @@ func HasMetadata(
 	return count > 0, nil
 }
 
+// Removes the metadata table if it exists
+func DropMetadata(
+	db *sql.DB,
+	indexName string,
+) error {
+	metadata := &Metadata{
+		db:        db,
+		indexName: indexName,
+	}
+
+	tableIdentifier, err := metadata.GetTableIdentifier()
+	if err != nil {
+		return err
+	}
+
+	_, err = metadata.db.Exec(`DROP TABLE IF EXISTS ` + tableIdentifier + `;`)
+
+	return err
+}
+
 func (metadata *Metadata) GetTableIdentifier() (string, error) {
 	return SanitizeIdentifier(metadata.db, fmt.Sprintf("rodb_%v_metadata", metadata.indexName))
 }
@@ -135,6 +177,7 @@ func (metadata *Metadata) Save() error {
 			"version" INTEGER NOT NULL,
 			"inputFileModificationTime" INTEGER NOT NULL,
 			"inputFileSize" INTEGER NOT NULL,
+			"properties" TEXT NOT NULL,
 			"completed" BOOLEAN NOT NULL
 		);
 	`)
@@ -142,6 +185,11 @@ func (metadata *Metadata) Save() error {
 		return err
 	}
 
+	properties, err := json.Marshal(metadata.properties)
+	if err != nil {
+		return err
+	}
+
 	_, err = metadata.db.Exec(`
 		DELETE FROM ` + tableIdentifier + `;
 	`)
@@ -155,12 +203,14 @@ func (metadata *Metadata) Save() error {
 				"version",
 				"inputFileModificationTime",
 				"inputFileSize",
+				"properties",
 				"completed"
-			) VALUES (?, ?, ?, ?);
+			) VALUES (?, ?, ?, ?, ?);
 		`,
 		int64(metadata.version),
 		metadata.inputFileModificationTime.Unix(),
 		metadata.inputFileSize,
+		string(properties),
 		metadata.completed,
 	)
 	if err != nil {
@@ -172,7 +222,7 @@ func (metadata *Metadata) Save() error {
 
 // Validates that the metadata of the file is a valid RODB index
 // and matches the given configuration as well as the current version
-func (metadata *Metadata) AssertValid(input input.Input) error {
+func (metadata *Metadata) AssertValid(input input.Input, properties []string) error {
 	if metadata.version != CurrentVersion {
 		return fmt.Errorf("The index file is not compatible with the current version of this software.")
 	}
@@ -193,6 +243,15 @@ func (metadata *Metadata) AssertValid(input input.Input) error {
 		return fmt.Errorf("The input file size has changed since the index generation.")
 	}
 
+	if len(metadata.properties) != len(properties) {
+		return fmt.Errorf("The configured properties does not match the index contents.")
+	}
+	for i, property := range metadata.properties {
+		if property != properties[i] {
+			return fmt.Errorf("The configured properties does not match the index contents.")
+		}
+	}
+
 	if !metadata.completed {
 		return fmt.Errorf("The previous indexing process has not ended properly. Please remove the corrupted file and try again.")
 	}
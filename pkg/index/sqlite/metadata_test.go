@@ -6,6 +6,7 @@ import (
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/rodb-io/rodb/pkg/parser"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -21,7 +22,7 @@ func TestMetadataNewMetadata(t *testing.T) {
 		input := input.NewMock(parser.NewMock(), make([]record.Record, 42))
 		input.SetModTime(time.Unix(1234, 0))
 
-		metadata, err := NewMetadata(db, "testIndex", input)
+		metadata, err := NewMetadata(db, "testIndex", input, []string{"a", "b"})
 		if err != nil {
 			t.Fatalf("Unexpected error: '%+v'", err)
 		}
@@ -35,6 +36,9 @@ func TestMetadataNewMetadata(t *testing.T) {
 		if expect, got := int64(42), metadata.inputFileSize; expect != got {
 			t.Fatalf("Expected %v, got %v", expect, got)
 		}
+		if expect, got := []string{"a", "b"}, metadata.properties; !reflect.DeepEqual(expect, got) {
+			t.Fatalf("Expected %v, got %v", expect, got)
+		}
 		if expect, got := false, metadata.completed; expect != got {
 			t.Fatalf("Expected %v, got %v", expect, got)
 		}
@@ -54,6 +58,7 @@ func TestMetadataLoadMetadata(t *testing.T) {
 				"version" INTEGER NOT NULL,
 				"inputFileModificationTime" INTEGER NOT NULL,
 				"inputFileSize" INTEGER NOT NULL,
+				"properties" TEXT NOT NULL,
 				"completed" BOOLEAN NOT NULL
 			);
 		`)
@@ -66,8 +71,9 @@ func TestMetadataLoadMetadata(t *testing.T) {
 				"version",
 				"inputFileModificationTime",
 				"inputFileSize",
+				"properties",
 				"completed"
-			) VALUES (2, 1234, 42, 1);
+			) VALUES (2, 1234, 42, '["a","b"]', 1);
 		`)
 		if err != nil {
 			t.Fatalf("Unexpected error: '%v'", err)
@@ -87,10 +93,36 @@ func TestMetadataLoadMetadata(t *testing.T) {
 		if expect, got := int64(42), metadata.inputFileSize; expect != got {
 			t.Fatalf("Expected %v, got %v", expect, got)
 		}
+		if expect, got := []string{"a", "b"}, metadata.properties; !reflect.DeepEqual(expect, got) {
+			t.Fatalf("Expected %v, got %v", expect, got)
+		}
 		if expect, got := true, metadata.completed; expect != got {
 			t.Fatalf("Expected %v, got %v", expect, got)
 		}
 	})
+	t.Run("previous version", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%v'", err)
+		}
+		defer db.Close()
+
+		_, err = db.Exec(`
+			CREATE TABLE "rodb_testIndex_metadata" ("version" INTEGER NOT NULL);
+			INSERT INTO "rodb_testIndex_metadata" ("version") VALUES (1);
+		`)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%v'", err)
+		}
+
+		metadata, err := LoadMetadata(db, "testIndex")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if err := metadata.AssertValid(input.NewMock(parser.NewMock(), nil), nil); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
 }
 
 func TestMetadataHasMetadata(t *testing.T) {
@@ -106,6 +138,7 @@ func TestMetadataHasMetadata(t *testing.T) {
 				"version" INTEGER NOT NULL,
 				"inputFileModificationTime" INTEGER NOT NULL,
 				"inputFileSize" INTEGER NOT NULL,
+				"properties" TEXT NOT NULL,
 				"completed" BOOLEAN NOT NULL
 			);
 		`)
@@ -118,8 +151,9 @@ func TestMetadataHasMetadata(t *testing.T) {
 				"version",
 				"inputFileModificationTime",
 				"inputFileSize",
+				"properties",
 				"completed"
-			) VALUES (2, 1234, 42, 1);
+			) VALUES (2, 1234, 42, '["a","b"]', 1);
 		`)
 		if err != nil {
 			t.Fatalf("Unexpected error: '%v'", err)
@@ -135,6 +169,44 @@ func TestMetadataHasMetadata(t *testing.T) {
 	})
 }
 
+func TestMetadataDropMetadata(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%v'", err)
+		}
+		defer db.Close()
+
+		_, err = db.Exec(`CREATE TABLE "rodb_testIndex_metadata" ("version" INTEGER NOT NULL);`)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%v'", err)
+		}
+
+		if err := DropMetadata(db, "testIndex"); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		got, err := HasMetadata(db, "testIndex")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect := false; expect != got {
+			t.Fatalf("Expected %v, got %v", expect, got)
+		}
+	})
+	t.Run("not existing", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%v'", err)
+		}
+		defer db.Close()
+
+		if err := DropMetadata(db, "testIndex"); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+	})
+}
+
 func TestMetadataSave(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		db, err := sql.Open("sqlite3", ":memory:")
@@ -146,9 +218,10 @@ func TestMetadataSave(t *testing.T) {
 		metadata := Metadata{
 			db:                        db,
 			indexName:                 "testIndex",
-			version:                   1,
+			version:                   CurrentVersion,
 			inputFileModificationTime: time.Unix(1234, 0),
 			inputFileSize:             42,
+			properties:                []string{"a", "b"},
 			completed:                 false,
 		}
 		if err := metadata.Save(); err != nil {
@@ -160,6 +233,7 @@ func TestMetadataSave(t *testing.T) {
 				"version",
 				"inputFileModificationTime",
 				"inputFileSize",
+				"properties",
 				"completed"
 			FROM "rodb_testIndex_metadata";
 		`)
@@ -170,8 +244,9 @@ func TestMetadataSave(t *testing.T) {
 		var version int64
 		var inputFileModificationTime int64
 		var inputFileSize int64
+		var properties string
 		var completed bool
-		if err = row.Scan(&version, &inputFileModificationTime, &inputFileSize, &completed); err != nil {
+		if err = row.Scan(&version, &inputFileModificationTime, &inputFileSize, &properties, &completed); err != nil {
 			t.Fatalf("Unexpected error: '%+v'", err)
 		}
 
@@ -184,6 +259,9 @@ func TestMetadataSave(t *testing.T) {
 		if expect, got := int64(42), inputFileSize; expect != got {
 			t.Fatalf("Expected %v, got %v", expect, got)
 		}
+		if expect, got := `["a","b"]`, properties; expect != got {
+			t.Fatalf("Expected %v, got %v", expect, got)
+		}
 		if expect, got := false, completed; expect != got {
 			t.Fatalf("Expected %v, got %v", expect, got)
 		}
@@ -200,35 +278,44 @@ func TestMetadataAssertValid(t *testing.T) {
 		version:                   CurrentVersion,
 		inputFileModificationTime: modTime,
 		inputFileSize:             int64(len(data)),
+		properties:                []string{"a", "b"},
 		completed:                 true,
 	}
 
 	t.Run("valid", func(t *testing.T) {
-		if err := metadata.AssertValid(input); err != nil {
+		if err := metadata.AssertValid(input, []string{"a", "b"}); err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
 	})
+	t.Run("wrong properties", func(t *testing.T) {
+		if metadata.AssertValid(input, []string{"a"}) == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+		if metadata.AssertValid(input, []string{"b", "a"}) == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
 	t.Run("wrong version", func(t *testing.T) {
 		metadata.version = CurrentVersion + 1
-		if metadata.AssertValid(input) == nil {
+		if metadata.AssertValid(input, []string{"a", "b"}) == nil {
 			t.Fatalf("Expected an error, got nil")
 		}
 	})
 	t.Run("wrong time", func(t *testing.T) {
 		metadata.inputFileModificationTime = time.Unix(1234, 0)
-		if metadata.AssertValid(input) == nil {
+		if metadata.AssertValid(input, []string{"a", "b"}) == nil {
 			t.Fatalf("Expected an error, got nil")
 		}
 	})
 	t.Run("wrong size", func(t *testing.T) {
 		metadata.inputFileSize = int64(len(data) + 1)
-		if metadata.AssertValid(input) == nil {
+		if metadata.AssertValid(input, []string{"a", "b"}) == nil {
 			t.Fatalf("Expected an error, got nil")
 		}
 	})
 	t.Run("not completed", func(t *testing.T) {
 		metadata.completed = false
-		if metadata.AssertValid(input) == nil {
+		if metadata.AssertValid(input, []string{"a", "b"}) == nil {
 			t.Fatalf("Expected an error, got nil")
 		}
 	})
@@ -20,5 +20,11 @@ func Open(dsn string) (*sql.DB, error) {
 		return nil, err
 	}
 
+	// The indexes of different inputs are built in parallel,
+	// and may be written to the same database file
+	if _, err = db.Exec(`PRAGMA busy_timeout = 60000;`); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
@@ -76,10 +76,38 @@ func (config *SqliteConfig) DoesHandleProperty(propertyName string) bool {
 	return isHandled
 }
 
+func (config *SqliteConfig) DoesHandleNullValues() bool {
+	return true
+}
+
+func (config *SqliteConfig) GetPropertyReferences() []input.PropertyReference {
+	references := make([]input.PropertyReference, len(config.Properties))
+	for propertyIndex, property := range config.Properties {
+		references[propertyIndex] = input.PropertyReference{
+			Input:      config.Input,
+			Property:   property.Name,
+			ConfigPath: fmt.Sprintf("properties.%v.name", propertyIndex),
+		}
+	}
+
+	return references
+}
+
 func (config *SqliteConfig) DoesHandleInput(input input.Config) bool {
 	return input.GetName() == config.Input
 }
 
+// The properties recorded in the metadata, to detect
+// when the index table does not match the configuration
+func (config *SqliteConfig) getMetadataProperties() []string {
+	properties := make([]string, len(config.Properties))
+	for i, property := range config.Properties {
+		properties[i] = property.Name + " COLLATE " + property.Collate
+	}
+
+	return properties
+}
+
 func (config *SqlitePropertyConfig) Validate(log *logrus.Entry, logPrefix string) error {
 	if config.Name == "" {
 		return errors.New("name is required")
@@ -138,7 +138,7 @@ func TestSqlite(t *testing.T) {
 		}
 		defer db.Close()
 
-		metadata, err := sqlitePackage.NewMetadata(db, "testIndex", inputs["input"])
+		metadata, err := sqlitePackage.NewMetadata(db, "testIndex", inputs["input"], config.getMetadataProperties())
 		if err != nil {
 			t.Fatalf("Unexpected error: '%v'", err)
 		}
@@ -6,24 +6,45 @@ import (
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/rodb-io/rodb/pkg/util"
+	"github.com/sirupsen/logrus"
+	"io"
 	"os"
 	"reflect"
 	"strings"
 )
 
 type Wildcard struct {
-	config *WildcardConfig
-	input  input.Input
-	index  map[string]*wildcardPackage.TreeNode
+	config    *WildcardConfig
+	input     input.Input
+	index     map[string]*wildcardPackage.TreeNode
+	indexFile io.Closer
 }
 
 func NewWildcard(
 	config *WildcardConfig,
 	inputs input.List,
 ) (*Wildcard, error) {
+	wildcard, indexBuilder, err := newWildcard(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if indexBuilder != nil {
+		if err := build([]builder{indexBuilder}); err != nil {
+			return nil, fmt.Errorf("Error while creating the index: %w", err)
+		}
+	}
+
+	return wildcard, nil
+}
+
+func newWildcard(
+	config *WildcardConfig,
+	inputs input.List,
+) (*Wildcard, builder, error) {
 	input, inputExists := inputs[config.Input]
 	if !inputExists {
-		return nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
+		return nil, nil, fmt.Errorf("Input '%v' not found in inputs list.", config.Input)
 	}
 
 	wildcard := &Wildcard{
@@ -33,29 +54,34 @@ func NewWildcard(
 
 	_, err := os.Stat(wildcard.config.Path)
 	if os.IsNotExist(err) {
-		if err := wildcard.createIndex(); err != nil {
-			return nil, fmt.Errorf("Error while creating the index: %w", err)
+		indexBuilder, err := wildcard.createIndex()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error while creating the index: %w", err)
 		}
+
+		return wildcard, indexBuilder, nil
 	} else if err != nil {
-		return nil, err
+		return nil, nil, err
 	} else {
 		if err := wildcard.loadIndex(); err != nil {
-			return nil, fmt.Errorf("Error while loading the index: %w", err)
+			return nil, nil, fmt.Errorf("Error while loading the index: %w", err)
 		}
 	}
 
-	return wildcard, nil
+	return wildcard, nil, nil
 }
 
 func (wildcard *Wildcard) Name() string {
 	return wildcard.config.Name
 }
 
-func (wildcard *Wildcard) createIndex() error {
+// Creates the index file, which is filled by the returned builder
+func (wildcard *Wildcard) createIndex() (*wildcardBuilder, error) {
 	indexFile, err := os.Create(wildcard.config.Path)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	wildcard.indexFile = indexFile
 
 	indexStream := wildcardPackage.NewStream(indexFile, 0)
 
@@ -65,98 +91,118 @@ func (wildcard *Wildcard) createIndex() error {
 		RootNodesCount: len(wildcard.config.Properties),
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	index := make(map[string]*wildcardPackage.TreeNode)
 	for propertyIndex, property := range wildcard.config.Properties {
 		index[property], err = wildcardPackage.NewEmptyTreeNode(indexStream)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if err := index[property].Save(); err != nil {
-			return err
+			return nil, err
 		}
 
 		metadata.SetRootNode(propertyIndex, index[property])
 	}
 
 	if err := metadata.Save(); err != nil {
-		return err
+		return nil, err
 	}
 
-	updateProgress := util.TrackProgress(wildcard.input, wildcard.config.Logger)
+	return &wildcardBuilder{
+		wildcard:    wildcard,
+		indexFile:   indexFile,
+		indexStream: indexStream,
+		metadata:    metadata,
+		index:       index,
+	}, nil
+}
 
-	inputIterator, end, err := wildcard.input.IterateAll()
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if err := end(); err != nil {
-			wildcard.config.Logger.Errorf("Error while closing the input iterator: %v", err)
-		}
-	}()
+type wildcardBuilder struct {
+	wildcard    *Wildcard
+	indexFile   *os.File
+	indexStream *wildcardPackage.Stream
+	metadata    *wildcardPackage.Metadata
+	index       map[string]*wildcardPackage.TreeNode
+}
+
+func (builder *wildcardBuilder) input() input.Input {
+	return builder.wildcard.input
+}
 
-	for {
-		record, err := inputIterator()
+func (builder *wildcardBuilder) logger() *logrus.Entry {
+	return builder.wildcard.config.Logger
+}
+
+func (builder *wildcardBuilder) add(record record.Record) error {
+	for _, property := range builder.wildcard.config.Properties {
+		value, err := record.Get(property)
 		if err != nil {
 			return err
 		}
-		if record == nil {
-			break
-		}
-
-		updateProgress(record.Position())
 
-		for _, property := range wildcard.config.Properties {
-			value, err := record.Get(property)
-			if err != nil {
-				return err
-			}
-
-			if value != nil {
-				value = reflect.ValueOf(value).Interface()
-			}
+		if value != nil {
+			value = reflect.ValueOf(value).Interface()
+		}
 
-			if err := wildcard.addValueToIndex(index, property, value, record.Position()); err != nil {
-				return fmt.Errorf("Cannot index the property '%v': ", property)
-			}
+		if err := builder.wildcard.addValueToIndex(builder.index, property, value, record.Position()); err != nil {
+			return fmt.Errorf("Cannot index the property '%v': ", property)
 		}
 	}
 
-	metadata.SetCompleted(true)
-	if err := metadata.Save(); err != nil {
+	return nil
+}
+
+func (builder *wildcardBuilder) finish() error {
+	wildcard := builder.wildcard
+
+	builder.metadata.SetCompleted(true)
+	if err := builder.metadata.Save(); err != nil {
 		return err
 	}
 
-	if err := indexStream.Flush(); err != nil {
+	if err := builder.indexStream.Flush(); err != nil {
 		return err
 	}
 
-	wildcard.index = index
+	wildcard.index = builder.index
 
-	indexStat, err := indexFile.Stat()
+	indexStat, err := builder.indexFile.Stat()
 	if err != nil {
 		return err
 	}
 
 	wildcard.config.Logger.WithField("indexSize", indexStat.Size()).Infof("Successfully finished indexing")
 
+	// The index is written using a regular file,
+	// and must be re-opened to get it memory-mapped
+	if wildcard.config.Mmap {
+		if err := wildcard.indexFile.Close(); err != nil {
+			return err
+		}
+		if err := wildcard.loadIndex(); err != nil {
+			return fmt.Errorf("Error while loading the index: %w", err)
+		}
+	}
+
 	return nil
 }
 
 func (wildcard *Wildcard) loadIndex() error {
-	indexFile, err := os.Open(wildcard.config.Path)
+	indexFileStat, err := os.Stat(wildcard.config.Path)
 	if err != nil {
 		return err
 	}
 
-	indexFileStat, err := indexFile.Stat()
+	indexFile, err := util.OpenReadOnlyFile(wildcard.config.Path, wildcard.config.Mmap, wildcard.config.Preload)
 	if err != nil {
 		return err
 	}
+	wildcard.indexFile = indexFile
 
-	indexStream := wildcardPackage.NewStream(indexFile, indexFileStat.Size())
+	indexStream := wildcardPackage.NewReadOnlyStream(indexFile, indexFileStat.Size())
 
 	metadata, err := wildcardPackage.LoadMetadata(indexStream)
 	if err != nil {
@@ -193,6 +239,11 @@ func (wildcard *Wildcard) addValueToIndex(
 	value interface{},
 	position record.Position,
 ) error {
+	// The null values can not match any pattern
+	if value == nil {
+		return nil
+	}
+
 	if valueArray, valueIsArray := value.([]interface{}); valueIsArray {
 		for _, valueArrayValue := range valueArray {
 			if err := wildcard.addValueToIndex(index, property, valueArrayValue, position); err != nil {
@@ -266,5 +317,9 @@ func (wildcard *Wildcard) GetRecordPositions(
 }
 
 func (wildcard *Wildcard) Close() error {
-	return nil
+	if wildcard.indexFile == nil {
+		return nil
+	}
+
+	return wildcard.indexFile.Close()
 }
@@ -50,17 +50,18 @@ func GetTreeNode(
 		return nil, nil
 	}
 
-	serialized, err := stream.Get(int64(offset), TreeNodeSize)
-	if err != nil {
-		return nil, err
-	}
-
 	node := &TreeNode{
 		stream: stream,
 		offset: offset,
 	}
 
-	node.Unserialize(serialized)
+	err := stream.View(int64(offset), TreeNodeSize, func(serialized []byte) error {
+		node.Unserialize(serialized)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return node, nil
 }
@@ -155,18 +156,19 @@ func (node *TreeNode) FindChildByPrefix(
 	}
 
 	for child != nil {
-		childValue, err := child.Value()
-		if err != nil {
-			return nil, 0, err
-		}
-
 		commonBytes := int64(0)
-		for byteIndex := 0; byteIndex < len(childValue) && byteIndex < len(value); byteIndex++ {
-			if childValue[byteIndex] == value[byteIndex] {
-				commonBytes++
-			} else {
-				break
+		err := child.stream.View(int64(child.valueOffset), int(child.valueLength), func(childValue []byte) error {
+			for byteIndex := 0; byteIndex < len(childValue) && byteIndex < len(value); byteIndex++ {
+				if childValue[byteIndex] == value[byteIndex] {
+					commonBytes++
+				} else {
+					break
+				}
 			}
+			return nil
+		})
+		if err != nil {
+			return nil, 0, err
 		}
 
 		if commonBytes > 0 {
@@ -77,17 +77,18 @@ func GetPositionLinkedList(
 		return nil, nil
 	}
 
-	serialized, err := stream.Get(int64(offset), PositionLinkedListSize)
-	if err != nil {
-		return nil, err
-	}
-
 	position := &PositionLinkedList{
 		stream: stream,
 		offset: offset,
 	}
 
-	position.Unserialize(serialized)
+	err := stream.View(int64(offset), PositionLinkedListSize, func(serialized []byte) error {
+		position.Unserialize(serialized)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return position, nil
 }
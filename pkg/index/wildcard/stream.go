@@ -1,7 +1,9 @@
 package wildcard
 
 import (
+	"errors"
 	"fmt"
+	"github.com/rodb-io/rodb/pkg/util"
 	"io"
 	"os"
 )
@@ -9,7 +11,8 @@ import (
 const STREAM_BUFFER_SIZE = 10_000
 
 type Stream struct {
-	stream        *os.File
+	reader        io.ReaderAt
+	writer        io.WriterAt
 	streamSize    int64
 	bufferOffset  int64
 	bufferMaxSize int64
@@ -17,12 +20,15 @@ type Stream struct {
 	readerOffset  int64
 }
 
+var ErrReadOnlyStream = errors.New("Cannot write to a read-only stream")
+
 func NewStream(
 	stream *os.File,
 	streamSize int64,
 ) *Stream {
 	return &Stream{
-		stream:        stream,
+		reader:        stream,
+		writer:        stream,
 		streamSize:    streamSize,
 		bufferOffset:  streamSize,
 		bufferMaxSize: STREAM_BUFFER_SIZE,
@@ -30,13 +36,32 @@ func NewStream(
 	}
 }
 
+// Creates a stream that can only be read, for example from a memory-mapped file
+func NewReadOnlyStream(
+	reader io.ReaderAt,
+	streamSize int64,
+) *Stream {
+	return &Stream{
+		reader:        reader,
+		writer:        nil,
+		streamSize:    streamSize,
+		bufferOffset:  streamSize,
+		bufferMaxSize: 0,
+		buffer:        nil,
+	}
+}
+
 // Forces the internal buffer to be written to the file
 func (stream *Stream) Flush() error {
 	if len(stream.buffer) == 0 {
 		return nil
 	}
 
-	size, err := stream.stream.WriteAt(stream.buffer, stream.bufferOffset)
+	if stream.writer == nil {
+		return ErrReadOnlyStream
+	}
+
+	size, err := stream.writer.WriteAt(stream.buffer, stream.bufferOffset)
 	if err != nil {
 		return err
 	}
@@ -62,7 +87,7 @@ func (stream *Stream) Get(offset int64, bytesCount int) ([]byte, error) {
 		}
 
 		bytesFromFile := make([]byte, bytesToReadFromFile)
-		size, err := stream.stream.ReadAt(bytesFromFile, currentOffset)
+		size, err := stream.reader.ReadAt(bytesFromFile, currentOffset)
 		if err != nil {
 			return nil, err
 		}
@@ -107,6 +132,22 @@ func (stream *Stream) Get(offset int64, bytesCount int) ([]byte, error) {
 	return bytes, nil
 }
 
+// Calls the callback with the bytes at the given offset. The memory-mapped
+// data is used without copying it, so the bytes must not be modified,
+// nor used after the callback returns.
+func (stream *Stream) View(offset int64, bytesCount int, callback func(bytes []byte) error) error {
+	if mmapFile, isMmapFile := stream.reader.(*util.MmapFile); isMmapFile && offset+int64(bytesCount) <= stream.bufferOffset {
+		return mmapFile.View(offset, bytesCount, callback)
+	}
+
+	bytes, err := stream.Get(offset, bytesCount)
+	if err != nil {
+		return err
+	}
+
+	return callback(bytes)
+}
+
 func (stream *Stream) Add(bytes []byte) (offset int64, err error) {
 	offset = stream.streamSize
 	if err := stream.Replace(offset, bytes); err != nil {
@@ -117,6 +158,10 @@ func (stream *Stream) Add(bytes []byte) (offset int64, err error) {
 }
 
 func (stream *Stream) Replace(offset int64, bytes []byte) error {
+	if stream.writer == nil {
+		return ErrReadOnlyStream
+	}
+
 	currentOffset := offset
 	remainingBytes := bytes
 
@@ -126,7 +171,7 @@ func (stream *Stream) Replace(offset int64, bytes []byte) error {
 			bytesToWriteToFile = stream.bufferOffset - currentOffset
 		}
 
-		size, err := stream.stream.WriteAt(remainingBytes[:bytesToWriteToFile], currentOffset)
+		size, err := stream.writer.WriteAt(remainingBytes[:bytesToWriteToFile], currentOffset)
 		if err != nil {
 			return err
 		}
@@ -168,19 +213,12 @@ func (stream *Stream) Replace(offset int64, bytes []byte) error {
 }
 
 // Returns a reader from the given position
-// Note: the current implementation is dumb and does not
-// work with concurrent read or writes
+// Note: the returned reader does not see the data
+// written to the stream after calling this method
 func (stream *Stream) GetReaderFrom(offset int64) (io.Reader, error) {
-	// We just assume there is no concurrent read or write to this stream
-	// So that we can return the raw stream because nothing will be buffered
-	// This is only acceptable because this is only used by the metadata, which is not written very often
 	if err := stream.Flush(); err != nil {
 		return nil, err
 	}
 
-	if _, err := stream.stream.Seek(offset, io.SeekStart); err != nil {
-		return nil, err
-	}
-
-	return stream.stream, nil
+	return io.NewSectionReader(stream.reader, offset, stream.streamSize-offset), nil
 }
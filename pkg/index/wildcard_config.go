@@ -15,6 +15,8 @@ type WildcardConfig struct {
 	Input      string   `yaml:"input"`
 	Properties []string `yaml:"properties"`
 	IgnoreCase *bool    `yaml:"ignoreCase"`
+	Mmap       bool     `yaml:"mmap"`
+	Preload    bool     `yaml:"preload"`
 	Logger     *logrus.Entry
 }
 
@@ -80,6 +82,10 @@ func (config *WildcardConfig) DoesHandleProperty(property string) bool {
 	return isHandled
 }
 
+func (config *WildcardConfig) GetPropertyReferences() []input.PropertyReference {
+	return input.NewPropertyReferences(config.Input, config.Properties, "properties")
+}
+
 func (config *WildcardConfig) DoesHandleInput(input input.Config) bool {
 	return input.GetName() == config.Input
 }
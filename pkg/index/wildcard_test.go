@@ -195,6 +195,61 @@ func TestWildcard(t *testing.T) {
 			t.Fatalf("Unexpected list of results. Expected :\n=====\n%v\n=====\nbut got:\n=====\n%v\n", expect, got)
 		}
 	})
+	t.Run("mmap", func(t *testing.T) {
+		falseValue := false
+		config := &WildcardConfig{
+			Properties: []string{"col"},
+			Path:       t.TempDir() + "/test-index-wildcard-mmap.rodb",
+			IgnoreCase: &falseValue,
+			Mmap:       true,
+			Preload:    true,
+			Input:      "input",
+			Logger:     logrus.NewEntry(logrus.StandardLogger()),
+		}
+		inputs := input.List{
+			"input": input.NewMock(parser.NewMock(), []record.Record{
+				record.NewStringPropertiesMockRecord(map[string]string{
+					"col": "BANANA",
+				}, 1),
+				record.NewStringPropertiesMockRecord(map[string]string{
+					"col": "PLANT",
+				}, 2),
+			}),
+		}
+
+		expect := strings.Join([]string{
+			">A=1,2",
+			">A>N=1,2",
+			">A>N>A=1",
+			">A>N>A>NA=1",
+			">A>N>T=2",
+			">BANANA=1",
+			">LANT=2",
+			">N=1,2",
+			">N>A=1",
+			">N>A>NA=1",
+			">N>T=2",
+			">PLANT=2",
+			">T=2",
+		}, "\n")
+
+		// Creating, then loading the index file
+		for i := 0; i < 2; i++ {
+			index, err := NewWildcard(config, inputs)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			got := stringifyWildcardTree(t, index.index["col"])
+			if got != expect {
+				t.Fatalf("Unexpected list of results. Expected :\n=====\n%v\n=====\nbut got:\n=====\n%v\n", expect, got)
+			}
+
+			if err := index.Close(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	})
 }
 
 func TestWildcardGetRecordPositions(t *testing.T) {
@@ -0,0 +1,89 @@
+package input
+
+import (
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/rodb-io/rodb/pkg/util"
+	"time"
+)
+
+// Wraps another input and keeps the most recently used
+// records in memory, already decoded
+type Cached struct {
+	input Input
+	cache *util.Lru
+}
+
+func NewCached(input Input, config *CacheConfig) *Cached {
+	cached := &Cached{
+		input: input,
+		cache: util.NewLru(*config.MaxSize),
+	}
+
+	input.OnChange(cached.cache.Purge)
+
+	return cached
+}
+
+func (cached *Cached) Name() string {
+	return cached.input.Name()
+}
+
+func (cached *Cached) Get(position record.Position) (record.Record, error) {
+	if cachedRecord, exists := cached.cache.Get(position); exists {
+		return cachedRecord.(*record.Decoded), nil
+	}
+
+	inputRecord, err := cached.input.Get(position)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedRecord, err := record.NewDecoded(inputRecord)
+	if err != nil {
+		// Some properties may still be readable individually,
+		// so we return the record as-is without caching it
+		return inputRecord, nil
+	}
+
+	cached.cache.Add(position, decodedRecord, decodedRecord.EstimateSize())
+
+	return decodedRecord, nil
+}
+
+func (cached *Cached) Size() (int64, error) {
+	return cached.input.Size()
+}
+
+func (cached *Cached) ModTime() (time.Time, error) {
+	return cached.input.ModTime()
+}
+
+func (cached *Cached) OnChange(callback func()) func() {
+	return cached.input.OnChange(callback)
+}
+
+func (cached *Cached) IterateAll() (record.Iterator, func() error, error) {
+	return cached.input.IterateAll()
+}
+
+func (cached *Cached) CheckPropertyPath(path string) error {
+	return cached.input.CheckPropertyPath(path)
+}
+
+func (cached *Cached) GetPropertyParser(path string) parser.Parser {
+	if parsedInput, isParsed := cached.input.(ParsedInput); isParsed {
+		return parsedInput.GetPropertyParser(path)
+	}
+
+	return nil
+}
+
+func (cached *Cached) CacheStats() util.LruStats {
+	return cached.cache.Stats()
+}
+
+func (cached *Cached) Close() error {
+	cached.cache.Purge()
+	return cached.input.Close()
+}
@@ -0,0 +1,23 @@
+package input
+
+import (
+	"errors"
+	"github.com/sirupsen/logrus"
+)
+
+type CacheConfig struct {
+	MaxSize *int64 `yaml:"maxSize"`
+}
+
+func (config *CacheConfig) Validate(log *logrus.Entry, logPrefix string) error {
+	if config.MaxSize == nil {
+		defaultMaxSize := int64(64 * 1024 * 1024)
+		log.Debugf(logPrefix+"maxSize is not set. Defaulting to %v", defaultMaxSize)
+		config.MaxSize = &defaultMaxSize
+	}
+	if *config.MaxSize <= 0 {
+		return errors.New("maxSize: The value must be a positive and non-zero number.")
+	}
+
+	return nil
+}
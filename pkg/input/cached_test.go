@@ -0,0 +1,57 @@
+package input
+
+import (
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"testing"
+)
+
+func TestCached(t *testing.T) {
+	maxSize := int64(1024 * 1024)
+	mock := NewMock(parser.NewMock(), []record.Record{
+		record.NewStringPropertiesMockRecord(map[string]string{"id": "0"}, 0),
+		record.NewStringPropertiesMockRecord(map[string]string{"id": "1"}, 1),
+	})
+	cached := NewCached(mock, &CacheConfig{
+		MaxSize: &maxSize,
+	})
+
+	t.Run("get", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			result, err := cached.Get(1)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+
+			value, err := result.Get("id")
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if expect, got := "1", value; got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		}
+
+		stats := cached.CacheStats()
+		if expect, got := uint64(1), stats.Hits; got != expect {
+			t.Fatalf("Expected %v hits, got %v", expect, got)
+		}
+		if expect, got := uint64(1), stats.Misses; got != expect {
+			t.Fatalf("Expected %v misses, got %v", expect, got)
+		}
+	})
+	t.Run("invalidation", func(t *testing.T) {
+		if _, err := cached.Get(0); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := 2, cached.CacheStats().Count; got != expect {
+			t.Fatalf("Expected %v cached records, got %v", expect, got)
+		}
+
+		mock.TriggerChange()
+
+		if expect, got := 0, cached.CacheStats().Count; got != expect {
+			t.Fatalf("Expected %v cached records, got %v", expect, got)
+		}
+	})
+}
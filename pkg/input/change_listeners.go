@@ -0,0 +1,18 @@
+package input
+
+import (
+	"github.com/rodb-io/rodb/pkg/util"
+)
+
+// Keeps the callbacks to be called when the file of an input is modified
+type changeListeners struct {
+	callbacks util.Callbacks
+}
+
+func (listeners *changeListeners) OnChange(callback func()) func() {
+	return listeners.callbacks.Add(callback)
+}
+
+func (listeners *changeListeners) notifyChange() {
+	listeners.callbacks.Call()
+}
@@ -11,19 +11,16 @@ import (
 	"github.com/rodb-io/rodb/pkg/util"
 	"io"
 	"os"
-	"sync"
+	"strings"
 	"time"
 )
 
 type Csv struct {
 	config        *CsvConfig
-	reader        io.ReadSeeker
-	readerLock    sync.Mutex
-	csvFile       *os.File
-	csvReader     *csv.Reader
-	readerBuffer  *bufio.Reader
+	csvFile       util.ReadOnlyFile
 	columnParsers []parser.Parser
 	watcher       *fsnotify.Watcher
+	changeListeners
 }
 
 func NewCsv(
@@ -36,25 +33,22 @@ func NewCsv(
 	}
 
 	csvInput := &Csv{
-		config:     config,
-		readerLock: sync.Mutex{},
-		watcher:    watcher,
+		config:  config,
+		watcher: watcher,
 	}
 
 	util.StartFilesystemWatchProcess(
 		csvInput.watcher,
 		csvInput.config.ShouldDieOnInputChange(),
 		csvInput.config.Logger,
+		csvInput.notifyChange,
 	)
 
-	reader, readerBuffer, csvReader, file, err := csvInput.open()
+	file, err := util.OpenReadOnlyFile(config.Path, config.Mmap, config.Preload)
 	if err != nil {
 		return nil, err
 	}
-	csvInput.reader = reader
-	csvInput.readerBuffer = readerBuffer
 	csvInput.csvFile = file
-	csvInput.csvReader = csvReader
 
 	if err := csvInput.watcher.Add(config.Path); err != nil {
 		return nil, err
@@ -83,14 +77,11 @@ func (csvInput *Csv) Name() string {
 }
 
 func (csvInput *Csv) Get(position record.Position) (record.Record, error) {
-	csvInput.readerLock.Lock()
-	defer csvInput.readerLock.Unlock()
-
-	if err := util.SetBufferedReaderOffset(csvInput.reader, csvInput.readerBuffer, position); err != nil {
-		return nil, err
-	}
+	// Each call uses it's own reader, so that
+	// the records can be read concurrently
+	csvReader := csvInput.newCsvReader(util.NewBufferedReaderAt(csvInput.csvFile, position))
 
-	row, err := csvInput.csvReader.Read()
+	row, err := csvReader.Read()
 	if err != nil {
 		if errors.Is(err, csv.ErrFieldCount) {
 			csvInput.config.Logger.Warnf("Expected %v columns in csv, got %+v", len(csvInput.config.Columns), row)
@@ -126,7 +117,8 @@ func (csvInput *Csv) ModTime() (time.Time, error) {
 }
 
 func (csvInput *Csv) autodetectColumns() error {
-	firstRow, err := csvInput.csvReader.Read()
+	csvReader := csvInput.newCsvReader(util.NewBufferedReaderAt(csvInput.csvFile, 0))
+	firstRow, err := csvReader.Read()
 	if err != nil {
 		return fmt.Errorf("Cannot read csv data: %w", err)
 	}
@@ -163,16 +155,20 @@ func (csvInput *Csv) open() (io.ReadSeeker, *bufio.Reader, *csv.Reader, *os.File
 	reader := io.ReadSeeker(file)
 
 	// Giving a buffer to the csv reader will prevent it from creating
-	// it's own buffer, since we need to control it when seeking
+	// it's own buffer, since we need to control it when getting
 	// the positions (this condition is managed by bufio's constructor)
 	readerBuffer := bufio.NewReader(reader)
 
+	return reader, readerBuffer, csvInput.newCsvReader(readerBuffer), file, nil
+}
+
+func (csvInput *Csv) newCsvReader(readerBuffer *bufio.Reader) *csv.Reader {
 	csvReader := csv.NewReader(readerBuffer)
 	csvReader.Comma = []rune(csvInput.config.Delimiter)[0]
 	csvReader.FieldsPerRecord = len(csvInput.config.Columns)
 	csvReader.ReuseRecord = false
 
-	return reader, readerBuffer, csvReader, file, nil
+	return csvReader
 }
 
 func (csvInput *Csv) IterateAll() (record.Iterator, func() error, error) {
@@ -220,6 +216,28 @@ func (csvInput *Csv) IterateAll() (record.Iterator, func() error, error) {
 	return iterator, end, nil
 }
 
+func (csvInput *Csv) CheckPropertyPath(path string) error {
+	columnName := strings.Split(path, ".")[0]
+	if _, exists := csvInput.config.ColumnIndexByName[columnName]; !exists {
+		return fmt.Errorf("The column '%v' does not exist.", columnName)
+	}
+
+	// The columns using a json parser may contain sub-values,
+	// but their structure is unknown
+	return nil
+}
+
+// The sub-values of the columns using a json parser
+// are decoded by the parser of the column
+func (csvInput *Csv) GetPropertyParser(path string) parser.Parser {
+	columnIndex, exists := csvInput.config.ColumnIndexByName[strings.Split(path, ".")[0]]
+	if !exists {
+		return nil
+	}
+
+	return csvInput.columnParsers[columnIndex]
+}
+
 func (csvInput *Csv) Close() error {
 	if err := csvInput.watcher.Remove(csvInput.config.Path); err != nil {
 		return err
@@ -13,6 +13,9 @@ type CsvConfig struct {
 	Type              string             `yaml:"type"`
 	Path              string             `yaml:"path"`
 	DieOnInputChange  *bool              `yaml:"dieOnInputChange"`
+	Cache             *CacheConfig       `yaml:"cache"`
+	Mmap              bool               `yaml:"mmap"`
+	Preload           bool               `yaml:"preload"`
 	IgnoreFirstRow    bool               `yaml:"ignoreFirstRow"`
 	AutodetectColumns bool               `yaml:"autodetectColumns"`
 	Delimiter         string             `yaml:"delimiter"`
@@ -34,6 +37,16 @@ func (config *CsvConfig) ShouldDieOnInputChange() bool {
 	return config.DieOnInputChange == nil || *config.DieOnInputChange
 }
 
+func (config *CsvConfig) GetCache() *CacheConfig {
+	return config.Cache
+}
+
+// The autodetected columns are only known once the input has been created
+func (config *CsvConfig) DeclaresProperty(property string) bool {
+	_, exists := config.ColumnIndexByName[property]
+	return exists
+}
+
 func (config *CsvConfig) Validate(parsers map[string]parser.Config, log *logrus.Entry) error {
 	config.Logger = log
 
@@ -47,6 +60,16 @@ func (config *CsvConfig) Validate(parsers map[string]parser.Config, log *logrus.
 		config.DieOnInputChange = &defaultValue
 	}
 
+	if config.Mmap && !config.ShouldDieOnInputChange() {
+		return errors.New("csv.mmap cannot be enabled while dieOnInputChange is false, because modifying the file while it is memory-mapped would crash the process.")
+	}
+
+	if config.Cache != nil {
+		if err := config.Cache.Validate(log, "csv.cache."); err != nil {
+			return fmt.Errorf("csv.cache.%w", err)
+		}
+	}
+
 	if config.AutodetectColumns {
 		if !config.IgnoreFirstRow {
 			log.Debugf("csv.autodetectColumns is enabled, but 'ignoreFirstRow' is not. The header row will be included in the data.\n")
@@ -5,7 +5,6 @@ import (
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/rodb-io/rodb/pkg/parser"
 	"github.com/sirupsen/logrus"
-	"io"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -133,6 +132,26 @@ func TestCsvGet(t *testing.T) {
 			}
 		}
 	})
+	t.Run("mmap", func(t *testing.T) {
+		mmapConfig := *config
+		mmapConfig.Mmap = true
+		mmapConfig.Preload = true
+		mmapCsv, err := NewCsv(&mmapConfig, parsers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mmapCsv.Close()
+
+		for position, expect := range map[record.Position]string{0: "test1", 12: "test3"} {
+			row, err := mmapCsv.Get(position)
+			if err != nil {
+				t.Fatalf("Expected no error, got '%v'", err)
+			}
+			if result, _ := row.Get("a"); result != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, result)
+			}
+		}
+	})
 	t.Run("from IterateAll", func(t *testing.T) {
 		iterator, end, err := csv.IterateAll()
 		if err != nil {
@@ -220,6 +239,45 @@ func TestCsvSize(t *testing.T) {
 	})
 }
 
+func TestCsvCheckPropertyPath(t *testing.T) {
+	file, err := createCsvTestFile(t, "a,b\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	defer file.Close()
+
+	falseValue := false
+	csv, err := NewCsv(&CsvConfig{
+		Path:             file.Name(),
+		DieOnInputChange: &falseValue,
+		Delimiter:        ",",
+		Logger:           logrus.NewEntry(logrus.StandardLogger()),
+		Columns: []*CsvColumnConfig{
+			{Name: "a", Parser: "mock"},
+			{Name: "b", Parser: "mock"},
+		},
+		ColumnIndexByName: map[string]int{
+			"a": 0,
+			"b": 1,
+		},
+	}, parser.List{"mock": parser.NewMock()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, expectError := range map[string]bool{
+		"a":     false,
+		"b.foo": false,
+		"c":     true,
+	} {
+		t.Run(path, func(t *testing.T) {
+			if err := csv.CheckPropertyPath(path); (err != nil) != expectError {
+				t.Fatalf("Expected error: %v, got '%v'", expectError, err)
+			}
+		})
+	}
+}
+
 func TestCsvIterateAll(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -329,7 +387,7 @@ func TestCsvIterateAll(t *testing.T) {
 				}
 
 				// Asserts that IterateAll does not fail with concurrent accesses
-				if _, err := csv.reader.Seek(0, io.SeekStart); err != nil {
+				if _, err := csv.Get(testCase.expectedPositions[0]); err != nil {
 					t.Fatalf("Got error '%v'", err)
 				}
 			}
@@ -464,3 +522,24 @@ func TestCsvOpen(t *testing.T) {
 		}
 	})
 }
+
+func TestDeclaresProperty(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		config   Config
+		property string
+		expect   bool
+	}{
+		{"csv", &CsvConfig{ColumnIndexByName: map[string]int{"a": 0}}, "a", true},
+		{"csv unknown", &CsvConfig{ColumnIndexByName: map[string]int{"a": 0}}, "b", false},
+		{"xml", &XmlConfig{Properties: []*XmlPropertyConfig{{Name: "a"}}}, "a", true},
+		{"xml unknown", &XmlConfig{Properties: []*XmlPropertyConfig{{Name: "a"}}}, "b", false},
+		{"json", &JsonConfig{}, "a", false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := DeclaresProperty(testCase.config, testCase.property); got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/rodb-io/rodb/pkg/parser"
 	"github.com/sirupsen/logrus"
+	"reflect"
 	"time"
 )
 
@@ -14,12 +15,19 @@ type Input interface {
 	Size() (int64, error)
 	ModTime() (time.Time, error)
 
+	// Registers a callback to be called every time the underlying
+	// file gets modified, and returns a function removing it
+	OnChange(callback func()) func()
+
 	// Iterates all the records in the input, ordered
 	// from the smallest to the biggest position
 	// The second returned parameter is a callback that
 	// must be used to close the relevant resources
 	IterateAll() (record.Iterator, func() error, error)
 
+	// Checks that the given property path can exist in the records of this input
+	CheckPropertyPath(path string) error
+
 	Close() error
 }
 
@@ -27,6 +35,21 @@ type Config interface {
 	Validate(parsers map[string]parser.Config, log *logrus.Entry) error
 	GetName() string
 	ShouldDieOnInputChange() bool
+	GetCache() *CacheConfig
+}
+
+// Implemented by the configs of the inputs whose
+// properties are declared before reading their data
+type DeclaredPropertiesConfig interface {
+	Config
+	DeclaresProperty(property string) bool
+}
+
+// Returns true if the property is declared in the config of the input.
+// A false result does not mean that the property cannot exist.
+func DeclaresProperty(config Config, property string) bool {
+	declaredPropertiesConfig, isDeclaredPropertiesConfig := config.(DeclaredPropertiesConfig)
+	return isDeclaredPropertiesConfig && declaredPropertiesConfig.DeclaresProperty(property)
 }
 
 type List = map[string]Input
@@ -35,16 +58,28 @@ func NewFromConfig(
 	config Config,
 	parsers parser.List,
 ) (Input, error) {
-	switch config.(type) {
-	case *CsvConfig:
-		return NewCsv(config.(*CsvConfig), parsers)
-	case *XmlConfig:
-		return NewXml(config.(*XmlConfig), parsers)
-	case *JsonConfig:
-		return NewJson(config.(*JsonConfig))
-	default:
+	input, err := newFromConfig(config, parsers)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheConfig := config.GetCache(); cacheConfig != nil {
+		return NewCached(input, cacheConfig), nil
+	}
+
+	return input, nil
+}
+
+func newFromConfig(
+	config Config,
+	parsers parser.List,
+) (Input, error) {
+	registration, exists := registrationsByConfigType[reflect.TypeOf(config)]
+	if !exists {
 		return nil, fmt.Errorf("Unknown input config type: %#v", config)
 	}
+
+	return registration.constructor(config, parsers)
 }
 
 func NewFromConfigs(
@@ -55,6 +90,7 @@ func NewFromConfigs(
 	for inputName, inputConfig := range configs {
 		input, err := NewFromConfig(inputConfig, parsers)
 		if err != nil {
+			Close(inputs)
 			return nil, err
 		}
 		inputs[inputName] = input
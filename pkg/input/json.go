@@ -8,16 +8,14 @@ import (
 	"github.com/rodb-io/rodb/pkg/util"
 	"io"
 	"os"
-	"sync"
 	"time"
 )
 
 type Json struct {
-	config     *JsonConfig
-	reader     io.ReadSeeker
-	readerLock sync.Mutex
-	jsonFile   *os.File
-	watcher    *fsnotify.Watcher
+	config   *JsonConfig
+	jsonFile util.ReadOnlyFile
+	watcher  *fsnotify.Watcher
+	changeListeners
 }
 
 func NewJson(config *JsonConfig) (*Json, error) {
@@ -27,30 +25,23 @@ func NewJson(config *JsonConfig) (*Json, error) {
 	}
 
 	jsonInput := &Json{
-		config:     config,
-		readerLock: sync.Mutex{},
-		watcher:    watcher,
+		config:  config,
+		watcher: watcher,
 	}
 
 	util.StartFilesystemWatchProcess(
 		jsonInput.watcher,
 		jsonInput.config.ShouldDieOnInputChange(),
 		jsonInput.config.Logger,
+		jsonInput.notifyChange,
 	)
 
-	reader, file, err := jsonInput.open()
+	file, err := util.OpenReadOnlyFile(config.Path, config.Mmap, config.Preload)
 	if err != nil {
 		return nil, err
 	}
-	jsonInput.reader = reader
 	jsonInput.jsonFile = file
 
-	// Returning to the beginning of the file after checking the first token
-	_, err = reader.Seek(0, io.SeekStart)
-	if err != nil {
-		return nil, err
-	}
-
 	if err := jsonInput.watcher.Add(config.Path); err != nil {
 		return nil, err
 	}
@@ -63,17 +54,9 @@ func (jsonInput *Json) Name() string {
 }
 
 func (jsonInput *Json) Get(position record.Position) (record.Record, error) {
-	jsonInput.readerLock.Lock()
-	defer jsonInput.readerLock.Unlock()
-
-	_, err := jsonInput.reader.Seek(position, io.SeekStart)
-	if err != nil {
-		return nil, fmt.Errorf("Cannot seek position '%v' in json file: %w", position, err)
-	}
-
-	// Creating a new decoder each time because we cannot reset
-	// it's internal state when seeking otherwise
-	jsonDecoder := json.NewDecoder(jsonInput.reader)
+	// Each call uses it's own decoder, so that
+	// the records can be read concurrently
+	jsonDecoder := json.NewDecoder(util.NewBufferedReaderAt(jsonInput.jsonFile, position))
 
 	var data map[string]interface{}
 	if err := jsonDecoder.Decode(&data); err != nil {
@@ -156,6 +139,12 @@ func (jsonInput *Json) IterateAll() (record.Iterator, func() error, error) {
 	return iterator, end, nil
 }
 
+// The json input has no defined structure,
+// so the property is searched in the first records
+func (jsonInput *Json) CheckPropertyPath(path string) error {
+	return checkPropertyPathInSample(jsonInput, path)
+}
+
 func (jsonInput *Json) Close() error {
 	if err := jsonInput.watcher.Remove(jsonInput.config.Path); err != nil {
 		return err
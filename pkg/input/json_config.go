@@ -2,16 +2,20 @@ package input
 
 import (
 	"errors"
+	"fmt"
 	"github.com/rodb-io/rodb/pkg/parser"
 	"github.com/sirupsen/logrus"
 	"os"
 )
 
 type JsonConfig struct {
-	Name             string `yaml:"name"`
-	Type             string `yaml:"type"`
-	Path             string `yaml:"path"`
-	DieOnInputChange *bool  `yaml:"dieOnInputChange"`
+	Name             string       `yaml:"name"`
+	Type             string       `yaml:"type"`
+	Path             string       `yaml:"path"`
+	DieOnInputChange *bool        `yaml:"dieOnInputChange"`
+	Cache            *CacheConfig `yaml:"cache"`
+	Mmap             bool         `yaml:"mmap"`
+	Preload          bool         `yaml:"preload"`
 	Logger           *logrus.Entry
 }
 
@@ -23,6 +27,10 @@ func (config *JsonConfig) ShouldDieOnInputChange() bool {
 	return config.DieOnInputChange == nil || *config.DieOnInputChange
 }
 
+func (config *JsonConfig) GetCache() *CacheConfig {
+	return config.Cache
+}
+
 func (config *JsonConfig) Validate(parsers map[string]parser.Config, log *logrus.Entry) error {
 	config.Logger = log
 
@@ -36,6 +44,16 @@ func (config *JsonConfig) Validate(parsers map[string]parser.Config, log *logrus
 		config.DieOnInputChange = &defaultValue
 	}
 
+	if config.Mmap && !config.ShouldDieOnInputChange() {
+		return errors.New("json.mmap cannot be enabled while dieOnInputChange is false, because modifying the file while it is memory-mapped would crash the process.")
+	}
+
+	if config.Cache != nil {
+		if err := config.Cache.Validate(log, "json.cache."); err != nil {
+			return fmt.Errorf("json.cache.%w", err)
+		}
+	}
+
 	fileInfo, err := os.Stat(config.Path)
 	if os.IsNotExist(err) {
 		return errors.New("The json file '" + config.Path + "' does not exist")
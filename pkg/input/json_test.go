@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/sirupsen/logrus"
-	"io"
 	"os"
 	"testing"
 )
@@ -222,6 +221,27 @@ func TestJsonSize(t *testing.T) {
 	})
 }
 
+func TestJsonCheckPropertyPath(t *testing.T) {
+	file, json, err := createJsonTestInput(t, `{"a":1}{"a":2,"b":{"c":[3]}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	defer file.Close()
+
+	for path, expectError := range map[string]bool{
+		"a":     false,
+		"b.c.0": false,
+		"b.d":   true,
+		"c":     true,
+	} {
+		t.Run(path, func(t *testing.T) {
+			if err := json.CheckPropertyPath(path); (err != nil) != expectError {
+				t.Fatalf("Expected error: %v, got '%v'", expectError, err)
+			}
+		})
+	}
+}
+
 func TestJsonIterateAll(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		file, json, err := createJsonTestInput(t, `{"val": 1}{"val": 42}{"val": 123}`)
@@ -266,7 +286,7 @@ func TestJsonIterateAll(t *testing.T) {
 			}
 
 			// Asserts that IterateAll does not fail with concurrent accesses
-			if _, err := json.reader.Seek(0, io.SeekStart); err != nil {
+			if _, err := json.Get(expectedPositions[0]); err != nil {
 				t.Fatalf("Got error '%v'", err)
 			}
 		}
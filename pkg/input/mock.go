@@ -11,6 +11,7 @@ type Mock struct {
 	data    []record.Record
 	parser  parser.Parser
 	modTime time.Time
+	changeListeners
 }
 
 func NewMock(parser parser.Parser, data []record.Record) *Mock {
@@ -46,6 +47,11 @@ func (mock *Mock) ModTime() (time.Time, error) {
 	return mock.modTime, nil
 }
 
+// Simulates a modification of the input
+func (mock *Mock) TriggerChange() {
+	mock.notifyChange()
+}
+
 func (mock *Mock) IterateAll() (record.Iterator, func() error, error) {
 	i := 0
 	iterator := func() (record.Record, error) {
@@ -65,6 +71,15 @@ func (mock *Mock) IterateAll() (record.Iterator, func() error, error) {
 	return iterator, end, nil
 }
 
+func (mock *Mock) CheckPropertyPath(path string) error {
+	return checkPropertyPathInSample(mock, path)
+}
+
+// All the properties are decoded by the parser of the mock
+func (mock *Mock) GetPropertyParser(path string) parser.Parser {
+	return mock.parser
+}
+
 func (mock *Mock) Close() error {
 	return nil
 }
@@ -0,0 +1,95 @@
+package input
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"sort"
+)
+
+// Loads the values of the mapping parsers which are using an input,
+// and reloads them every time this input is modified.
+// The inputs must contain all the inputs used by the mappings.
+func LoadParserMappings(parsers parser.List, inputs List) error {
+	parserNames := make([]string, 0, len(parsers))
+	for parserName := range parsers {
+		parserNames = append(parserNames, parserName)
+	}
+	sort.Strings(parserNames)
+
+	for _, parserName := range parserNames {
+		mapping, isMapping := parsers[parserName].(*parser.Mapping)
+		if !isMapping || mapping.InputName() == "" {
+			continue
+		}
+
+		mappingInput, inputExists := inputs[mapping.InputName()]
+		if !inputExists {
+			return fmt.Errorf("Input '%v' not found in inputs list.", mapping.InputName())
+		}
+
+		// The values of the key and value properties cannot
+		// be parsed before the mapping has been loaded
+		if parsedInput, isParsed := mappingInput.(ParsedInput); isParsed {
+			for _, property := range []string{mapping.Config().KeyProperty, mapping.Config().ValueProperty} {
+				if mapping.IsUsedBy(parsedInput.GetPropertyParser(property), parsers) {
+					return fmt.Errorf("The mapping '%v' cannot be loaded from the property '%v', because the parser of this property uses the mapping.", parserName, property)
+				}
+			}
+		}
+
+		if err := loadParserMapping(mapping, mappingInput); err != nil {
+			return err
+		}
+
+		mappingInput.OnChange(func() {
+			if err := loadParserMapping(mapping, mappingInput); err != nil {
+				mapping.Config().Logger.Errorf("Error while reloading the mapping: %v", err)
+				return
+			}
+			if err := parser.ParseDelegatedDefaultValues(parsers); err != nil {
+				mapping.Config().Logger.Errorf("Error after reloading the mapping: %v", err)
+			}
+		})
+	}
+
+	// The default values delegated to the mappings
+	// could not be parsed before they were loaded
+	return parser.ParseDelegatedDefaultValues(parsers)
+}
+
+func loadParserMapping(mapping *parser.Mapping, mappingInput Input) error {
+	iterator, end, err := mappingInput.IterateAll()
+	if err != nil {
+		return err
+	}
+	defer end()
+
+	config := mapping.Config()
+	values := make(map[string]interface{})
+	for {
+		record, err := iterator()
+		if err != nil {
+			return fmt.Errorf("Error while loading the mapping '%v': %w", config.Name, err)
+		}
+		if record == nil {
+			break
+		}
+
+		key, err := record.Get(config.KeyProperty)
+		if err != nil {
+			return fmt.Errorf("Error while loading the mapping '%v': %w", config.Name, err)
+		}
+		if key == nil {
+			continue
+		}
+
+		value, err := record.Get(config.ValueProperty)
+		if err != nil {
+			return fmt.Errorf("Error while loading the mapping '%v': %w", config.Name, err)
+		}
+
+		values[fmt.Sprint(key)] = value
+	}
+
+	return mapping.SetValues(values)
+}
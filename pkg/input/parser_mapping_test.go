@@ -0,0 +1,75 @@
+package input
+
+import (
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestLoadParserMappings(t *testing.T) {
+	config := &parser.MappingConfig{
+		Name:          "status",
+		Input:         "statuses",
+		KeyProperty:   "code",
+		ValueProperty: "label",
+	}
+	if err := config.Validate(map[string]parser.Config{}, logrus.NewEntry(logrus.StandardLogger())); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	mapping := parser.NewMapping(config)
+	parsers := parser.List{"status": mapping}
+
+	mock := NewMock(parser.NewMock(), []record.Record{
+		record.NewStringPropertiesMockRecord(map[string]string{"code": "A", "label": "active"}, 0),
+		record.NewStringPropertiesMockRecord(map[string]string{"code": "I", "label": "inactive"}, 1),
+	})
+
+	expectParsed := func(t *testing.T, value string, expect interface{}) {
+		got, err := mapping.Parse(value)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	}
+
+	t.Run("missing input", func(t *testing.T) {
+		if err := LoadParserMappings(parsers, List{}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("cycle", func(t *testing.T) {
+		regexpConfig := &parser.RegexpConfig{Name: "regexp", Pattern: "(.+)", Parser: "status"}
+		if err := regexpConfig.Validate(map[string]parser.Config{"status": config}, logrus.NewEntry(logrus.StandardLogger())); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		regexpParser := parser.NewRegexp(regexpConfig, parsers)
+
+		for _, propertyParser := range []parser.Parser{mapping, regexpParser} {
+			cyclicMock := NewMock(propertyParser, mock.data)
+			if err := LoadParserMappings(parsers, List{"statuses": cyclicMock}); err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+		}
+	})
+	t.Run("load", func(t *testing.T) {
+		if err := LoadParserMappings(parsers, List{"statuses": mock}); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		expectParsed(t, "A", "active")
+		expectParsed(t, "I", "inactive")
+	})
+	t.Run("reload", func(t *testing.T) {
+		mock.data = []record.Record{
+			record.NewStringPropertiesMockRecord(map[string]string{"code": "A", "label": "enabled"}, 0),
+		}
+		mock.TriggerChange()
+
+		expectParsed(t, "A", "enabled")
+		if got, err := mapping.Parse("I"); err == nil {
+			t.Fatalf("Expected an error, got '%v'", got)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package input
+
+import (
+	"github.com/rodb-io/rodb/pkg/parser"
+)
+
+// Implemented by the inputs whose properties are decoded
+// by the parsers declared in their configuration
+type ParsedInput interface {
+	// Returns the parser of the given property path,
+	// or nil if it is not declared by the configuration
+	GetPropertyParser(path string) parser.Parser
+}
+
+// Returns a hash of the parser of the given property, which changes
+// when the values of this property may change, or an empty string
+// if the input does not declare the parser of this property.
+func GetPropertyParserHash(input Input, path string) string {
+	parsedInput, isParsed := input.(ParsedInput)
+	if !isParsed {
+		return ""
+	}
+
+	propertyParser := parsedInput.GetPropertyParser(path)
+	if propertyParser == nil {
+		return ""
+	}
+
+	return parser.GetConfigHash(propertyParser)
+}
@@ -0,0 +1,29 @@
+package input
+
+import (
+	"strconv"
+)
+
+// A property of an input used by the configuration of another
+// component, which can be checked before serving any request
+type PropertyReference struct {
+	Input    string
+	Property string
+	// The location of the property in the configuration of the
+	// component referencing it, for example "relationships.foo.match.0.parentProperty"
+	ConfigPath string
+}
+
+// Creates the references for a list of properties of the same input
+func NewPropertyReferences(inputName string, properties []string, configPath string) []PropertyReference {
+	references := make([]PropertyReference, len(properties))
+	for i, property := range properties {
+		references[i] = PropertyReference{
+			Input:      inputName,
+			Property:   property,
+			ConfigPath: configPath + "." + strconv.Itoa(i),
+		}
+	}
+
+	return references
+}
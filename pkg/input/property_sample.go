@@ -0,0 +1,41 @@
+package input
+
+import (
+	"fmt"
+)
+
+// Number of records read to find a property in an input without a defined structure
+const propertySampleSize = 100
+
+// Checks that at least one of the first records of the
+// input has a value for the given property path
+func checkPropertyPathInSample(input Input, path string) error {
+	iterator, end, err := input.IterateAll()
+	if err != nil {
+		return err
+	}
+	defer end()
+
+	recordCount := 0
+	for ; recordCount < propertySampleSize; recordCount++ {
+		record, err := iterator()
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			break
+		}
+
+		value, err := record.Get(path)
+		if err == nil && value != nil {
+			return nil
+		}
+	}
+
+	// An empty input cannot tell anything about it's structure
+	if recordCount == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("The property '%v' has no value in the first %v records.", path, recordCount)
+}
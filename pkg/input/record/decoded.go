@@ -0,0 +1,88 @@
+package record
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A record whose data has already been entirely parsed,
+// which allows to keep it in memory without re-parsing it
+type Decoded struct {
+	record Record
+	data   map[string]interface{}
+}
+
+func NewDecoded(record Record) (*Decoded, error) {
+	data, err := record.All()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Decoded{
+		record: record,
+		data:   data,
+	}, nil
+}
+
+// Returns a copy of the first level of the data, because the
+// callers are allowed to modify it (to add the relationships)
+func (decoded *Decoded) All() (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(decoded.data))
+	for key, value := range decoded.data {
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+func (decoded *Decoded) Get(path string) (interface{}, error) {
+	if path == "" {
+		return nil, fmt.Errorf("Cannot get the property '%v' because it's path is empty.", path)
+	}
+
+	pathArray := strings.Split(path, ".")
+
+	value, exists := decoded.data[pathArray[0]]
+	if !exists {
+		// Each input handles the unknown properties differently
+		return decoded.record.Get(path)
+	}
+
+	return GetSubValue(value, pathArray[1:])
+}
+
+func (decoded *Decoded) Position() Position {
+	return decoded.record.Position()
+}
+
+// Returns an approximation of the memory used by the decoded data
+func (decoded *Decoded) EstimateSize() int64 {
+	return estimateValueSize(decoded.data)
+}
+
+func estimateValueSize(value interface{}) int64 {
+	// Rough sizes of the headers of the Go values
+	const interfaceSize = 16
+	const stringHeaderSize = 16
+	const sliceHeaderSize = 24
+	const mapEntryOverhead = 48
+
+	switch value.(type) {
+	case string:
+		return interfaceSize + stringHeaderSize + int64(len(value.(string)))
+	case map[string]interface{}:
+		size := int64(interfaceSize)
+		for key, subValue := range value.(map[string]interface{}) {
+			size += mapEntryOverhead + stringHeaderSize + int64(len(key)) + estimateValueSize(subValue)
+		}
+		return size
+	case []interface{}:
+		size := int64(interfaceSize + sliceHeaderSize)
+		for _, subValue := range value.([]interface{}) {
+			size += estimateValueSize(subValue)
+		}
+		return size
+	default:
+		return interfaceSize + 8
+	}
+}
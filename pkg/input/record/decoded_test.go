@@ -0,0 +1,53 @@
+package record
+
+import (
+	"testing"
+)
+
+func TestDecoded(t *testing.T) {
+	mock := NewStringPropertiesMockRecord(map[string]string{
+		"a": "foo",
+	}, 42)
+	decoded, err := NewDecoded(mock)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	t.Run("all", func(t *testing.T) {
+		data, err := decoded.All()
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := "foo", data["a"]; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+
+		data["b"] = "modified"
+		data, err = decoded.All()
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if _, exists := data["b"]; exists {
+			t.Fatalf("Expected the decoded data to not be modified")
+		}
+	})
+	t.Run("get", func(t *testing.T) {
+		value, err := decoded.Get("a")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := "foo", value; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("position", func(t *testing.T) {
+		if expect, got := Position(42), decoded.Position(); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("size", func(t *testing.T) {
+		if got := decoded.EstimateSize(); got <= 0 {
+			t.Fatalf("Expected a positive size, got '%v'", got)
+		}
+	})
+}
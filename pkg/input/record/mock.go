@@ -9,6 +9,7 @@ type MockRecord struct {
 	integers map[string]int
 	floats   map[string]float64
 	booleans map[string]bool
+	values   map[string]interface{}
 	position Position
 }
 
@@ -41,6 +42,17 @@ func NewStringPropertiesMockRecord(
 	)
 }
 
+// Mocks a record whose properties have the given parsed values
+func NewValuesMockRecord(
+	values map[string]interface{},
+	position Position,
+) *MockRecord {
+	return &MockRecord{
+		values:   values,
+		position: position,
+	}
+}
+
 func (record *MockRecord) All() (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
@@ -56,6 +68,9 @@ func (record *MockRecord) All() (map[string]interface{}, error) {
 	for field, value := range record.booleans {
 		result[field] = value
 	}
+	for field, value := range record.values {
+		result[field] = value
+	}
 
 	return result, nil
 }
@@ -73,6 +88,9 @@ func (record *MockRecord) Get(path string) (interface{}, error) {
 	if value, ok := record.booleans[path]; ok {
 		return value, nil
 	}
+	if value, ok := record.values[path]; ok {
+		return value, nil
+	}
 
 	return nil, fmt.Errorf("Property '%v' does not exist in mocked record", path)
 }
@@ -82,4 +82,16 @@ func TestMockRecordGet(t *testing.T) {
 			t.Fatalf("Got error: '%v'", err)
 		}
 	})
+	t.Run("values", func(t *testing.T) {
+		record := NewValuesMockRecord(map[string]interface{}{
+			"col_a": nil,
+		}, 0)
+		got, err := record.Get("col_a")
+		if err != nil {
+			t.Fatalf("Got error: '%v'", err)
+		}
+		if got != nil {
+			t.Fatalf("Expected to get nil, got '%v'", got)
+		}
+	})
 }
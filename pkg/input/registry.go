@@ -0,0 +1,72 @@
+package input
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"reflect"
+	"sort"
+)
+
+// Creates an input from its validated config, and the
+// parsers of its properties.
+type Constructor = func(config Config, parsers parser.List) (Input, error)
+
+type registration struct {
+	newConfig   func() Config
+	constructor Constructor
+}
+
+var registrations = map[string]*registration{}
+var registrationsByConfigType = map[reflect.Type]*registration{}
+
+func init() {
+	Register("csv", func() Config { return &CsvConfig{} }, func(config Config, parsers parser.List) (Input, error) {
+		return NewCsv(config.(*CsvConfig), parsers)
+	})
+	Register("xml", func() Config { return &XmlConfig{} }, func(config Config, parsers parser.List) (Input, error) {
+		return NewXml(config.(*XmlConfig), parsers)
+	})
+	Register("json", func() Config { return &JsonConfig{} }, func(config Config, parsers parser.List) (Input, error) {
+		return NewJson(config.(*JsonConfig))
+	})
+}
+
+// Makes a type of input available under the given "type" value.
+func Register(typeName string, newConfig func() Config, constructor Constructor) {
+	if _, exists := registrations[typeName]; exists {
+		panic(fmt.Sprintf("The input type '%v' is already registered.", typeName))
+	}
+
+	configType := reflect.TypeOf(newConfig())
+	if _, exists := registrationsByConfigType[configType]; exists {
+		panic(fmt.Sprintf("The input config type '%v' is already registered.", configType))
+	}
+
+	registration := &registration{
+		newConfig:   newConfig,
+		constructor: constructor,
+	}
+	registrations[typeName] = registration
+	registrationsByConfigType[configType] = registration
+}
+
+// Returns a new, empty config of the given input type
+func NewConfig(typeName string) (Config, bool) {
+	registration, exists := registrations[typeName]
+	if !exists {
+		return nil, false
+	}
+
+	return registration.newConfig(), true
+}
+
+// Returns the sorted names of the registered input types
+func TypeNames() []string {
+	typeNames := make([]string, 0, len(registrations))
+	for typeName := range registrations {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	return typeNames
+}
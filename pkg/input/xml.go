@@ -11,7 +11,8 @@ import (
 	"github.com/rodb-io/rodb/pkg/util"
 	"io"
 	"os"
-	"sync"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -22,14 +23,11 @@ var xmlParserOptions = xmlquery.ParserOptions{
 }
 
 type Xml struct {
-	config       *XmlConfig
-	reader       io.ReadSeeker
-	readerBuffer *bufio.Reader
-	readerLock   sync.Mutex
-	xmlFile      *os.File
-	xmlParser    *xmlquery.StreamParser
-	parsers      parser.List
-	watcher      *fsnotify.Watcher
+	config  *XmlConfig
+	xmlFile util.ReadOnlyFile
+	parsers parser.List
+	watcher *fsnotify.Watcher
+	changeListeners
 }
 
 type xmlTempRecordNode struct {
@@ -48,35 +46,23 @@ func NewXml(
 	}
 
 	xmlInput := &Xml{
-		config:     config,
-		readerLock: sync.Mutex{},
-		watcher:    watcher,
-		parsers:    parsers,
+		config:  config,
+		watcher: watcher,
+		parsers: parsers,
 	}
 
 	util.StartFilesystemWatchProcess(
 		xmlInput.watcher,
 		xmlInput.config.ShouldDieOnInputChange(),
 		xmlInput.config.Logger,
+		xmlInput.notifyChange,
 	)
 
-	file, err := os.Open(xmlInput.config.Path)
+	file, err := util.OpenReadOnlyFile(config.Path, config.Mmap, config.Preload)
 	if err != nil {
 		return nil, err
 	}
-
 	xmlInput.xmlFile = file
-	xmlInput.reader = io.ReadSeeker(file)
-	xmlInput.readerBuffer = bufio.NewReader(xmlInput.reader)
-
-	xmlInput.xmlParser, err = xmlquery.CreateStreamParserWithOptions(
-		xmlInput.readerBuffer,
-		xmlParserOptions,
-		xmlInput.config.RecordXPath,
-	)
-	if err != nil {
-		return nil, err
-	}
 
 	if err := xmlInput.watcher.Add(config.Path); err != nil {
 		return nil, err
@@ -90,14 +76,18 @@ func (xmlInput *Xml) Name() string {
 }
 
 func (xmlInput *Xml) Get(position record.Position) (record.Record, error) {
-	xmlInput.readerLock.Lock()
-	defer xmlInput.readerLock.Unlock()
-
-	if err := util.SetBufferedReaderOffset(xmlInput.reader, xmlInput.readerBuffer, position); err != nil {
+	// Each call uses it's own parser, so that
+	// the records can be read concurrently
+	xmlParser, err := xmlquery.CreateStreamParserWithOptions(
+		util.NewBufferedReaderAt(xmlInput.xmlFile, position),
+		xmlParserOptions,
+		xmlInput.config.RecordXPath,
+	)
+	if err != nil {
 		return nil, err
 	}
 
-	node, err := xmlInput.xmlParser.Read()
+	node, err := xmlParser.Read()
 	if err == io.EOF {
 		return nil, fmt.Errorf("Did not find an XML record at position %v", position)
 	} else if err != nil {
@@ -176,6 +166,76 @@ func (xmlInput *Xml) IterateAll() (record.Iterator, func() error, error) {
 	return iterator, end, nil
 }
 
+func (xmlInput *Xml) CheckPropertyPath(path string) error {
+	root := &XmlPropertyConfig{
+		Type:       XmlInputPropertyTypeObject,
+		Properties: xmlInput.config.Properties,
+	}
+
+	return checkXmlPropertyPath(root, strings.Split(path, "."))
+}
+
+func (xmlInput *Xml) GetPropertyParser(path string) parser.Parser {
+	property := &XmlPropertyConfig{
+		Type:       XmlInputPropertyTypeObject,
+		Properties: xmlInput.config.Properties,
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch property.Type {
+		case XmlInputPropertyTypeObject:
+			var subProperty *XmlPropertyConfig = nil
+			for _, objectProperty := range property.Properties {
+				if objectProperty.Name == key {
+					subProperty = objectProperty
+					break
+				}
+			}
+			if subProperty == nil {
+				return nil
+			}
+			property = subProperty
+		case XmlInputPropertyTypeArray:
+			property = property.Items
+		default:
+			// The sub-values of the primitive properties using
+			// a json parser are decoded by this parser
+			return xmlInput.parsers[property.Parser]
+		}
+	}
+
+	if property.Parser == "" {
+		return nil
+	}
+
+	return xmlInput.parsers[property.Parser]
+}
+
+func checkXmlPropertyPath(property *XmlPropertyConfig, path []string) error {
+	if len(path) == 0 {
+		return nil
+	}
+
+	switch property.Type {
+	case XmlInputPropertyTypeObject:
+		for _, subProperty := range property.Properties {
+			if subProperty.Name == path[0] {
+				return checkXmlPropertyPath(subProperty, path[1:])
+			}
+		}
+		return fmt.Errorf("The property '%v' is not defined.", path[0])
+	case XmlInputPropertyTypeArray:
+		if _, err := strconv.Atoi(path[0]); err != nil {
+			return fmt.Errorf("The key '%v' must be an array index.", path[0])
+		}
+		return checkXmlPropertyPath(property.Items, path[1:])
+	default:
+		// The primitive properties using a json parser may
+		// contain sub-values, but their structure is unknown
+		return nil
+	}
+}
+
 func (xmlInput *Xml) Close() error {
 	if err := xmlInput.watcher.Remove(xmlInput.config.Path); err != nil {
 		return err
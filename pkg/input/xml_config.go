@@ -22,6 +22,9 @@ type XmlConfig struct {
 	Type             string               `yaml:"type"`
 	Path             string               `yaml:"path"`
 	DieOnInputChange *bool                `yaml:"dieOnInputChange"`
+	Cache            *CacheConfig         `yaml:"cache"`
+	Mmap             bool                 `yaml:"mmap"`
+	Preload          bool                 `yaml:"preload"`
 	Properties       []*XmlPropertyConfig `yaml:"properties"`
 	RecordXPath      string               `yaml:"recordXpath"`
 	Logger           *logrus.Entry
@@ -45,6 +48,20 @@ func (config *XmlConfig) ShouldDieOnInputChange() bool {
 	return config.DieOnInputChange == nil || *config.DieOnInputChange
 }
 
+func (config *XmlConfig) GetCache() *CacheConfig {
+	return config.Cache
+}
+
+func (config *XmlConfig) DeclaresProperty(property string) bool {
+	for _, declaredProperty := range config.Properties {
+		if declaredProperty.Name == property {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (config *XmlConfig) Validate(parsers map[string]parser.Config, log *logrus.Entry) error {
 	config.Logger = log
 
@@ -58,6 +75,16 @@ func (config *XmlConfig) Validate(parsers map[string]parser.Config, log *logrus.
 		config.DieOnInputChange = &defaultValue
 	}
 
+	if config.Mmap && !config.ShouldDieOnInputChange() {
+		return errors.New("xml.mmap cannot be enabled while dieOnInputChange is false, because modifying the file while it is memory-mapped would crash the process.")
+	}
+
+	if config.Cache != nil {
+		if err := config.Cache.Validate(log, "xml.cache."); err != nil {
+			return fmt.Errorf("xml.cache.%w", err)
+		}
+	}
+
 	_, err := xpath.Compile(config.RecordXPath)
 	if err != nil {
 		return fmt.Errorf("recordXpath: Invalid xpath expression: %w", err)
@@ -6,7 +6,6 @@ import (
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/rodb-io/rodb/pkg/parser"
 	"github.com/sirupsen/logrus"
-	"io"
 	"os"
 	"testing"
 )
@@ -230,6 +229,79 @@ func TestXmlSize(t *testing.T) {
 	})
 }
 
+func TestXmlCheckPropertyPath(t *testing.T) {
+	xml := &Xml{
+		config: &XmlConfig{
+			Properties: []*XmlPropertyConfig{
+				{Name: "id", Type: XmlInputPropertyTypePrimitive},
+				{
+					Name: "tags",
+					Type: XmlInputPropertyTypeArray,
+					Items: &XmlPropertyConfig{
+						Type: XmlInputPropertyTypeObject,
+						Properties: []*XmlPropertyConfig{
+							{Name: "name", Type: XmlInputPropertyTypePrimitive},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for path, expectError := range map[string]bool{
+		"id":          false,
+		"tags":        false,
+		"tags.0.name": false,
+		"tags.name":   true,
+		"tags.0.foo":  true,
+		"foo":         true,
+	} {
+		t.Run(path, func(t *testing.T) {
+			if err := xml.CheckPropertyPath(path); (err != nil) != expectError {
+				t.Fatalf("Expected error: %v, got '%v'", expectError, err)
+			}
+		})
+	}
+}
+
+func TestXmlGetPropertyParser(t *testing.T) {
+	idParser := parser.NewMockWithPrefix("id")
+	nameParser := parser.NewMockWithPrefix("name")
+	xml := &Xml{
+		config: &XmlConfig{
+			Properties: []*XmlPropertyConfig{
+				{Name: "id", Type: XmlInputPropertyTypePrimitive, Parser: "id"},
+				{
+					Name: "tags",
+					Type: XmlInputPropertyTypeArray,
+					Items: &XmlPropertyConfig{
+						Type: XmlInputPropertyTypeObject,
+						Properties: []*XmlPropertyConfig{
+							{Name: "name", Type: XmlInputPropertyTypePrimitive, Parser: "name"},
+						},
+					},
+				},
+			},
+		},
+		parsers: parser.List{"id": idParser, "name": nameParser},
+	}
+
+	for path, expect := range map[string]parser.Parser{
+		"id":          idParser,
+		"id.foo":      idParser,
+		"tags":        nil,
+		"tags.0.name": nameParser,
+		"tags.0.foo":  nil,
+		"foo":         nil,
+	} {
+		t.Run(path, func(t *testing.T) {
+			if got := xml.GetPropertyParser(path); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		})
+	}
+}
+
 func TestXmlIterateAll(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -335,7 +407,7 @@ func TestXmlIterateAll(t *testing.T) {
 				}
 
 				// Asserts that IterateAll does not fail with concurrent accesses
-				if _, err := xml.reader.Seek(0, io.SeekStart); err != nil {
+				if _, err := xml.Get(testCase.expectedPositions[0]); err != nil {
 					t.Fatalf("Got error '%v'", err)
 				}
 			}
@@ -0,0 +1,142 @@
+package inspect
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var csvDelimiterCandidates = []string{",", ";", "\t", "|"}
+
+func inspectCsv(path string, name string, sampleSize int) (*Result, error) {
+	delimiter, rows, err := detectCsvDelimiter(path, sampleSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("The file '%v' is empty.", path)
+	}
+
+	hasHeader := isCsvHeader(rows[0])
+	values := rows
+	if hasHeader {
+		values = rows[1:]
+	}
+
+	columns := make([]*input.CsvColumnConfig, len(rows[0]))
+	for columnIndex := range columns {
+		columnValues := make([]string, 0, len(values))
+		for _, row := range values {
+			if columnIndex < len(row) {
+				columnValues = append(columnValues, strings.TrimSpace(row[columnIndex]))
+			}
+		}
+
+		columnParser, err := inferParser(columnValues)
+		if err != nil {
+			return nil, err
+		}
+
+		columnName := "column" + strconv.Itoa(columnIndex+1)
+		if hasHeader {
+			columnName = strings.TrimSpace(rows[0][columnIndex])
+		}
+
+		columns[columnIndex] = &input.CsvColumnConfig{
+			Name:   columnName,
+			Parser: columnParser,
+		}
+	}
+
+	return &Result{
+		Input: &input.CsvConfig{
+			Name:           name,
+			Type:           "csv",
+			Path:           path,
+			IgnoreFirstRow: hasHeader,
+			Delimiter:      delimiter,
+			Columns:        columns,
+		},
+	}, nil
+}
+
+// The delimiter is the one splitting all the sampled rows
+// in the same number of columns, with the most columns.
+func detectCsvDelimiter(path string, sampleSize int) (string, [][]string, error) {
+	bestDelimiter := csvDelimiterCandidates[0]
+	var bestRows [][]string
+	bestScore := -1
+	for _, delimiter := range csvDelimiterCandidates {
+		rows, err := readCsvRows(path, delimiter, sampleSize)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		score := len(rows[0])
+		for _, row := range rows {
+			if len(row) != len(rows[0]) {
+				score = 0
+				break
+			}
+		}
+		if score > bestScore {
+			bestDelimiter = delimiter
+			bestRows = rows
+			bestScore = score
+		}
+	}
+
+	return bestDelimiter, bestRows, nil
+}
+
+func readCsvRows(path string, delimiter string, rowCount int) ([][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open the file '%v': %w", path, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = rune(delimiter[0])
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows := make([][]string, 0, rowCount)
+	for len(rows) < rowCount+1 {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Cannot read the file '%v': %w", path, err)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// The first row is considered as a header when it contains
+// unique and non-empty names, that are not numbers.
+func isCsvHeader(row []string) bool {
+	names := make(map[string]bool, len(row))
+	for _, value := range row {
+		value = strings.TrimSpace(value)
+		if value == "" || names[value] {
+			return false
+		}
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return false
+		}
+		names[value] = true
+	}
+
+	return true
+}
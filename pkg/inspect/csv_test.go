@@ -0,0 +1,68 @@
+package inspect
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"testing"
+)
+
+func TestInspectCsv(t *testing.T) {
+	t.Run("header", func(t *testing.T) {
+		path := writeTestFile(t, "data.csv", "id;name;price\n1;a;1.5\n2;b;2\n")
+		result, err := Inspect(path, 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		config := result.Input.(*input.CsvConfig)
+		if expect, got := ";", config.Delimiter; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if !config.IgnoreFirstRow {
+			t.Fatalf("Expected the first row to be ignored")
+		}
+		for i, expect := range []input.CsvColumnConfig{
+			{Name: "id", Parser: "integer"},
+			{Name: "name", Parser: "string"},
+			{Name: "price", Parser: "float"},
+		} {
+			if got := *config.Columns[i]; got != expect {
+				t.Fatalf("Expected '%+v', got '%+v'", expect, got)
+			}
+		}
+	})
+	t.Run("no header", func(t *testing.T) {
+		path := writeTestFile(t, "data.csv", "1\ta\n2\tb\n")
+		result, err := Inspect(path, 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		config := result.Input.(*input.CsvConfig)
+		if expect, got := "\t", config.Delimiter; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if config.IgnoreFirstRow {
+			t.Fatalf("Expected the first row to be a record")
+		}
+		if expect, got := "column2", config.Columns[1].Name; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("sample size", func(t *testing.T) {
+		path := writeTestFile(t, "data.csv", "id\n1\n2\nfoo\n")
+		result, err := Inspect(path, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		config := result.Input.(*input.CsvConfig)
+		if expect, got := "integer", config.Columns[0].Parser; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		if _, err := Inspect(writeTestFile(t, "data.csv", ""), 10); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
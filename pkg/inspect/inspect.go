@@ -0,0 +1,84 @@
+package inspect
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/config"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// The details guessed from a sample of a data file
+type Result struct {
+	Input input.Config
+
+	// For xml files, the other xpaths that may also match the records
+	RecordXPathCandidates []string
+}
+
+// Reads the beginning of the given file, and guesses
+// the configuration of the input from it's extension
+// and from the first records (up to sampleSize).
+func Inspect(path string, sampleSize int) (*Result, error) {
+	name := getInputName(path)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".tsv", ".txt":
+		return inspectCsv(path, name, sampleSize)
+	case ".json", ".jsonl", ".ndjson":
+		return inspectJson(path, name, sampleSize)
+	case ".xml":
+		return inspectXml(path, name, sampleSize)
+	default:
+		return nil, fmt.Errorf("Cannot guess the type of the file '%v'. The supported extensions are .csv, .json and .xml.", path)
+	}
+}
+
+var invalidInputNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+func getInputName(path string) string {
+	base := filepath.Base(path)
+	name := invalidInputNameCharacters.ReplaceAllString(strings.TrimSuffix(base, filepath.Ext(base)), "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return "data"
+	}
+
+	return name
+}
+
+// The candidate parsers, from the most specific to the most generic
+var inferredParserNames = []string{"integer", "float", "boolean"}
+
+// Returns the name of the first default parser accepting all the
+// given values. The "string" parser is used when no other one matches,
+// including when a value is empty, because it could not be parsed.
+func inferParser(values []string) (string, error) {
+	parserConfigs := make(map[string]parser.Config)
+	for _, parserConfig := range config.DefaultParserConfigs() {
+		parserConfigs[parserConfig.GetName()] = parserConfig
+	}
+
+	for _, parserName := range inferredParserNames {
+		candidate, err := parser.NewFromConfig(parserConfigs[parserName], parser.List{})
+		if err != nil {
+			return "", err
+		}
+		if acceptsAll(candidate, values) {
+			return parserName, nil
+		}
+	}
+
+	return "string", nil
+}
+
+func acceptsAll(candidate parser.Parser, values []string) bool {
+	for _, value := range values {
+		if _, err := candidate.Parse(value); err != nil {
+			return false
+		}
+	}
+
+	return len(values) > 0
+}
@@ -0,0 +1,60 @@
+package inspect
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	return path
+}
+
+func TestInferParser(t *testing.T) {
+	for _, testCase := range []struct {
+		name   string
+		values []string
+		expect string
+	}{
+		{"integer", []string{"1", "-20", "300"}, "integer"},
+		{"float", []string{"1", "2.5"}, "float"},
+		{"boolean", []string{"true", "FALSE"}, "boolean"},
+		{"string", []string{"1", "a"}, "string"},
+		{"empty value", []string{"1", ""}, "string"},
+		{"no values", []string{}, "string"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := inferParser(testCase.values)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestGetInputName(t *testing.T) {
+	for path, expect := range map[string]string{
+		"/tmp/users.csv":     "users",
+		"zip-codes 2021.csv": "zip_codes_2021",
+		"-.csv":              "data",
+	} {
+		if got := getInputName(path); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	t.Run("unknown extension", func(t *testing.T) {
+		if _, err := Inspect(writeTestFile(t, "data.bin", ""), 10); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
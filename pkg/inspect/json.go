@@ -0,0 +1,41 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"io"
+	"os"
+)
+
+// The json documents are typed, so the sample
+// is only used to check the format of the file
+func inspectJson(path string, name string, sampleSize int) (*Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open the file '%v': %w", path, err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	for i := 0; i < sampleSize; i++ {
+		var document interface{}
+		if err := decoder.Decode(&document); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("Cannot read the file '%v': %w", path, err)
+		}
+
+		if _, isObject := document.(map[string]interface{}); !isObject {
+			return nil, fmt.Errorf("The file '%v' must contain one JSON object per row, but the document %v is not an object.", path, i+1)
+		}
+	}
+
+	return &Result{
+		Input: &input.JsonConfig{
+			Name: name,
+			Type: "json",
+			Path: path,
+		},
+	}, nil
+}
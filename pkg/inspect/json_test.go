@@ -0,0 +1,22 @@
+package inspect
+
+import (
+	"testing"
+)
+
+func TestInspectJson(t *testing.T) {
+	t.Run("objects", func(t *testing.T) {
+		result, err := Inspect(writeTestFile(t, "data.json", `{"a":1}`+"\n"+`{"a":2}`), 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := "data", result.Input.GetName(); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("array", func(t *testing.T) {
+		if _, err := Inspect(writeTestFile(t, "data.json", `[{"a":1}]`), 10); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"gopkg.in/yaml.v3"
+)
+
+// Creates a configuration file serving the inspected input
+// as a paginated list, on the given HTTP address.
+func (result *Result) NewStarterConfig(listen string) ([]byte, error) {
+	inputNode, err := newInputYamlNode(result.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	inputName := result.Input.GetName()
+	config := newYamlMapping(
+		"inputs", newYamlSequence(inputNode),
+		"outputs", newYamlSequence(newYamlMapping(
+			"name", inputName,
+			"type", "jsonArray",
+			"input", inputName,
+		)),
+		"services", newYamlSequence(newYamlMapping(
+			"name", "http",
+			"type", "http",
+			"http", newYamlMapping(
+				"listen", listen,
+			),
+			"routes", newYamlSequence(newYamlMapping(
+				"path", "/"+inputName,
+				"output", inputName,
+			)),
+		)),
+	)
+
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// The config structures are not marshalled directly,
+// to only output the relevant properties, in a readable order
+func newInputYamlNode(config input.Config) (*yaml.Node, error) {
+	switch config := config.(type) {
+	case *input.CsvConfig:
+		columns := make([]*yaml.Node, len(config.Columns))
+		for i, column := range config.Columns {
+			columns[i] = newYamlMapping(
+				"name", column.Name,
+				"parser", column.Parser,
+			)
+		}
+		return newYamlMapping(
+			"name", config.Name,
+			"type", config.Type,
+			"path", config.Path,
+			"ignoreFirstRow", config.IgnoreFirstRow,
+			"delimiter", config.Delimiter,
+			"columns", newYamlSequence(columns...),
+		), nil
+	case *input.XmlConfig:
+		properties := make([]*yaml.Node, len(config.Properties))
+		for i, property := range config.Properties {
+			properties[i] = newYamlMapping(
+				"name", property.Name,
+				"parser", property.Parser,
+				"xpath", property.XPath,
+			)
+		}
+		return newYamlMapping(
+			"name", config.Name,
+			"type", config.Type,
+			"path", config.Path,
+			"recordXpath", config.RecordXPath,
+			"properties", newYamlSequence(properties...),
+		), nil
+	case *input.JsonConfig:
+		return newYamlMapping(
+			"name", config.Name,
+			"type", config.Type,
+			"path", config.Path,
+		), nil
+	default:
+		return nil, fmt.Errorf("Unknown input config type: %#v", config)
+	}
+}
+
+// Creates a mapping from a list of alternating keys and values.
+// The values can either be nodes, or scalar values.
+func newYamlMapping(keysAndValues ...interface{}) *yaml.Node {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		valueNode, isNode := keysAndValues[i+1].(*yaml.Node)
+		if !isNode {
+			valueNode = &yaml.Node{}
+			if err := valueNode.Encode(keysAndValues[i+1]); err != nil {
+				panic(err)
+			}
+		}
+
+		mapping.Content = append(
+			mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: keysAndValues[i].(string)},
+			valueNode,
+		)
+	}
+
+	return mapping
+}
+
+func newYamlSequence(items ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{
+		Kind:    yaml.SequenceNode,
+		Content: items,
+	}
+}
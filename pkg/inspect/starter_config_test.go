@@ -0,0 +1,38 @@
+package inspect
+
+import (
+	"github.com/rodb-io/rodb/pkg/config"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewStarterConfig(t *testing.T) {
+	dataPath := writeTestFile(t, "users.csv", "id,name\n1,a\n")
+	result, err := Inspect(dataPath, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	configData, err := result.NewStarterConfig(":8080")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "rodb.yaml")
+	if err := ioutil.WriteFile(configPath, configData, 0644); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	starterConfig, errs := config.LoadYamlFile(configPath, logrus.StandardLogger())
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: '%+v'", errs)
+	}
+	if _, exists := starterConfig.Outputs["users"]; !exists {
+		t.Fatalf("Expected the output 'users' to exist")
+	}
+	if _, exists := starterConfig.Services["http"]; !exists {
+		t.Fatalf("Expected the service 'http' to exist")
+	}
+}
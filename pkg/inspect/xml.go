@@ -0,0 +1,217 @@
+package inspect
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/util"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The statistics of all the elements found at the same path
+type xmlElementStats struct {
+	name        string
+	path        string
+	depth       int
+	count       int
+	fields      []*xmlField
+	fieldsByKey map[string]*xmlField
+}
+
+// A value that can be extracted as a property of an element:
+// either an attribute, or a child element containing only text
+type xmlField struct {
+	name     string
+	xpath    string
+	values   []string
+	repeated bool
+}
+
+// An element currently being read
+type xmlOpenElement struct {
+	stats       *xmlElementStats
+	text        strings.Builder
+	hasChildren bool
+	childCounts map[string]int
+}
+
+func inspectXml(path string, name string, sampleSize int) (*Result, error) {
+	elements, err := readXmlElementStats(path, sampleSize)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := getXmlRecordCandidates(elements)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("Cannot find any repeated element with properties in the file '%v'.", path)
+	}
+
+	record := candidates[0]
+	properties := make([]*input.XmlPropertyConfig, 0, len(record.fields))
+	usedNames := make(map[string]bool, len(record.fields))
+	for _, field := range record.fields {
+		if field.repeated || usedNames[field.name] {
+			continue
+		}
+		usedNames[field.name] = true
+
+		propertyParser, err := inferParser(field.values)
+		if err != nil {
+			return nil, err
+		}
+
+		properties = append(properties, &input.XmlPropertyConfig{
+			Name:   field.name,
+			Type:   input.XmlInputPropertyTypePrimitive,
+			Parser: propertyParser,
+			XPath:  "string(" + field.xpath + ")",
+		})
+	}
+
+	// The streaming parser only matches the records by their name
+	recordXPath := "//" + record.name
+	otherCandidates := make([]string, 0, len(candidates)-1)
+	for _, candidate := range candidates[1:] {
+		candidateXPath := "//" + candidate.name
+		if candidateXPath != recordXPath && !util.IsInArray(candidateXPath, otherCandidates) {
+			otherCandidates = append(otherCandidates, candidateXPath)
+		}
+	}
+
+	return &Result{
+		Input: &input.XmlConfig{
+			Name:        name,
+			Type:        "xml",
+			Path:        path,
+			RecordXPath: recordXPath,
+			Properties:  properties,
+		},
+		RecordXPathCandidates: otherCandidates,
+	}, nil
+}
+
+// Reads the elements of the file, until one of them has been found
+// sampleSize times, which is enough to get a sample of the records.
+func readXmlElementStats(path string, sampleSize int) (map[string]*xmlElementStats, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open the file '%v': %w", path, err)
+	}
+	defer file.Close()
+
+	elements := make(map[string]*xmlElementStats)
+	stack := make([]*xmlOpenElement, 0)
+	decoder := xml.NewDecoder(file)
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Cannot read the file '%v': %w", path, err)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			parentPath := ""
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.hasChildren = true
+				parent.childCounts[token.Name.Local]++
+				parentPath = parent.stats.path
+			}
+
+			elementPath := parentPath + "/" + token.Name.Local
+			stats, exists := elements[elementPath]
+			if !exists {
+				stats = &xmlElementStats{
+					name:        token.Name.Local,
+					path:        elementPath,
+					depth:       len(stack) + 1,
+					fields:      make([]*xmlField, 0),
+					fieldsByKey: make(map[string]*xmlField),
+				}
+				elements[elementPath] = stats
+			}
+			stats.count++
+
+			for _, attribute := range token.Attr {
+				stats.addField(attribute.Name.Local, "/@"+attribute.Name.Local, attribute.Value)
+			}
+
+			stack = append(stack, &xmlOpenElement{
+				stats:       stats,
+				childCounts: make(map[string]int),
+			})
+
+			if stats.count >= sampleSize {
+				return elements, nil
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(token)
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				break
+			}
+			element := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			for childName, childCount := range element.childCounts {
+				if field, exists := element.stats.fieldsByKey["/"+childName]; exists && childCount > 1 {
+					field.repeated = true
+				}
+			}
+
+			if len(stack) > 0 && !element.hasChildren {
+				elementName := token.Name.Local
+				stack[len(stack)-1].stats.addField(elementName, "/"+elementName, strings.TrimSpace(element.text.String()))
+			}
+		}
+	}
+
+	return elements, nil
+}
+
+func (stats *xmlElementStats) addField(name string, xpath string, value string) {
+	field, exists := stats.fieldsByKey[xpath]
+	if !exists {
+		field = &xmlField{
+			name:   name,
+			xpath:  xpath,
+			values: make([]string, 0),
+		}
+		stats.fieldsByKey[xpath] = field
+		stats.fields = append(stats.fields, field)
+	}
+
+	field.values = append(field.values, value)
+}
+
+// The candidates are the repeated elements having properties, from
+// the least deep to the deepest, then from the most frequent.
+func getXmlRecordCandidates(elements map[string]*xmlElementStats) []*xmlElementStats {
+	candidates := make([]*xmlElementStats, 0)
+	for _, element := range elements {
+		if element.count > 1 && len(element.fields) > 0 {
+			candidates = append(candidates, element)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].depth != candidates[j].depth {
+			return candidates[i].depth < candidates[j].depth
+		}
+		if candidates[i].count != candidates[j].count {
+			return candidates[i].count > candidates[j].count
+		}
+		return candidates[i].path < candidates[j].path
+	})
+
+	return candidates
+}
@@ -0,0 +1,43 @@
+package inspect
+
+import (
+	"github.com/rodb-io/rodb/pkg/input"
+	"testing"
+)
+
+func TestInspectXml(t *testing.T) {
+	path := writeTestFile(t, "data.xml", `<?xml version="1.0"?>
+		<root>
+			<meta><version>1</version></meta>
+			<items>
+				<item id="1"><name>a</name><tag>x</tag><tag>y</tag><sub><b>1</b></sub></item>
+				<item id="2"><name>b</name><sub><b>2</b></sub></item>
+			</items>
+		</root>
+	`)
+	result, err := Inspect(path, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	config := result.Input.(*input.XmlConfig)
+	if expect, got := "//item", config.RecordXPath; got != expect {
+		t.Fatalf("Expected '%v', got '%v'", expect, got)
+	}
+	if expect, got := []string{"//sub"}, result.RecordXPathCandidates; len(got) != 1 || got[0] != expect[0] {
+		t.Fatalf("Expected '%v', got '%v'", expect, got)
+	}
+
+	expectedProperties := []input.XmlPropertyConfig{
+		{Name: "id", Type: input.XmlInputPropertyTypePrimitive, Parser: "integer", XPath: "string(/@id)"},
+		{Name: "name", Type: input.XmlInputPropertyTypePrimitive, Parser: "string", XPath: "string(/name)"},
+	}
+	if expect, got := len(expectedProperties), len(config.Properties); got != expect {
+		t.Fatalf("Expected '%v' properties, got '%v'", expect, got)
+	}
+	for i, expect := range expectedProperties {
+		if got := *config.Properties[i]; got.Name != expect.Name || got.Parser != expect.Parser || got.XPath != expect.XPath || got.Type != expect.Type {
+			t.Fatalf("Expected '%+v', got '%+v'", expect, got)
+		}
+	}
+}
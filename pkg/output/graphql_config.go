@@ -18,6 +18,18 @@ func (config *GraphQLConfig) GetName() string {
 	return config.Name
 }
 
+func (config *GraphQLConfig) GetInputNames() []string {
+	return []string{}
+}
+
+func (config *GraphQLConfig) GetIndexNames() []string {
+	return []string{}
+}
+
+func (config *GraphQLConfig) GetPropertyReferences() []inputPackage.PropertyReference {
+	return []inputPackage.PropertyReference{}
+}
+
 func (config *GraphQLConfig) Validate(
 	inputs map[string]inputPackage.Config,
 	indexes map[string]indexPackage.Config,
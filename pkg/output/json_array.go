@@ -7,8 +7,11 @@ import (
 	indexPackage "github.com/rodb-io/rodb/pkg/index"
 	inputPackage "github.com/rodb-io/rodb/pkg/input"
 	recordPackage "github.com/rodb-io/rodb/pkg/input/record"
+	parameterPackage "github.com/rodb-io/rodb/pkg/output/parameter"
 	parserPackage "github.com/rodb-io/rodb/pkg/parser"
 	"io"
+	"math"
+	"sort"
 	"strconv"
 )
 
@@ -16,9 +19,14 @@ type JsonArray struct {
 	config       *JsonArrayConfig
 	inputs       inputPackage.List
 	input        inputPackage.Input
+	usedInputs   inputPackage.List
 	defaultIndex indexPackage.Index
 	indexes      indexPackage.List
 	parsers      parserPackage.List
+	// Only set when the distance is configured
+	distanceIndex indexPackage.DistanceIndex
+	// Only set when the rank is configured
+	rankIndex *indexPackage.Fts5
 }
 
 func NewJsonArray(
@@ -48,6 +56,36 @@ func NewJsonArray(
 		}
 	}
 
+	if config.Distance != nil {
+		index, indexExists := indexes[config.Distance.Index]
+		if !indexExists {
+			return nil, fmt.Errorf("Index '%v' not found in indexes list.", config.Distance.Index)
+		}
+		distanceIndex, isDistanceIndex := index.(indexPackage.DistanceIndex)
+		if !isDistanceIndex {
+			return nil, fmt.Errorf("The index '%v' cannot compute distances.", config.Distance.Index)
+		}
+		jsonArray.distanceIndex = distanceIndex
+	}
+
+	if config.Rank != nil {
+		index, indexExists := indexes[config.Rank.Index]
+		if !indexExists {
+			return nil, fmt.Errorf("Index '%v' not found in indexes list.", config.Rank.Index)
+		}
+		rankIndex, isFts5 := index.(*indexPackage.Fts5)
+		if !isFts5 {
+			return nil, fmt.Errorf("The index '%v' cannot rank the records.", config.Rank.Index)
+		}
+		jsonArray.rankIndex = rankIndex
+	}
+
+	usedInputs, err := getUsedInputs(jsonArray.inputs, jsonArray.input, jsonArray.config.Relationships)
+	if err != nil {
+		return nil, err
+	}
+	jsonArray.usedInputs = usedInputs
+
 	return jsonArray, nil
 }
 
@@ -59,6 +97,10 @@ func (jsonArray *JsonArray) ExpectedPayloadType() *string {
 	return nil
 }
 
+func (jsonArray *JsonArray) Inputs() inputPackage.List {
+	return jsonArray.usedInputs
+}
+
 func (jsonArray *JsonArray) ResponseType() string {
 	return "application/json"
 }
@@ -84,17 +126,43 @@ func (jsonArray *JsonArray) Handle(
 		return sendError(err)
 	}
 
-	positionsPerIndex, err := getFilteredRecordPositionsPerIndex(
-		jsonArray.defaultIndex,
-		jsonArray.indexes,
-		jsonArray.input,
-		filtersPerIndex,
-	)
-	if err != nil {
-		return sendError(err)
+	var rankFilters map[string]interface{}
+	if jsonArray.rankIndex != nil {
+		rankFilters = filtersPerIndex[jsonArray.config.Rank.Index]
 	}
 
-	nextPosition := recordPackage.JoinPositionIterators(positionsPerIndex...)
+	var nextPosition recordPackage.PositionIterator
+	var rankData map[recordPackage.Position]map[string]interface{}
+	if rankFilters != nil {
+		nextPosition, rankData, err = jsonArray.sortByRank(filtersPerIndex, rankFilters)
+		if err != nil {
+			return sendError(err)
+		}
+	} else {
+		positionsPerIndex, err := getFilteredRecordPositionsPerIndex(
+			jsonArray.defaultIndex,
+			jsonArray.indexes,
+			jsonArray.input,
+			filtersPerIndex,
+		)
+		if err != nil {
+			return sendError(err)
+		}
+
+		nextPosition = recordPackage.JoinPositionIterators(positionsPerIndex...)
+	}
+
+	var distanceFilters map[string]interface{}
+	var distances map[recordPackage.Position]float64
+	if jsonArray.distanceIndex != nil {
+		distanceFilters = filtersPerIndex[jsonArray.config.Distance.Index]
+	}
+	if distanceFilters != nil && jsonArray.config.Distance.Sort {
+		nextPosition, distances, err = jsonArray.sortByDistance(nextPosition, distanceFilters)
+		if err != nil {
+			return sendError(err)
+		}
+	}
 
 	// Skipping rows depending on the offset
 	for i := uint(0); i < offset; i++ {
@@ -107,7 +175,8 @@ func (jsonArray *JsonArray) Handle(
 		}
 	}
 
-	rowsData := make([]interface{}, 0)
+	rowsData := make([]map[string]interface{}, 0)
+	rowsPositions := make(recordPackage.PositionList, 0)
 	for len(rowsData) < int(limit) {
 		position, err := nextPosition()
 		if err != nil {
@@ -129,12 +198,175 @@ func (jsonArray *JsonArray) Handle(
 			return sendError(err)
 		}
 
+		for property, value := range rankData[*position] {
+			// The properties of some inputs are only known when reading them
+			if _, propertyExists := rowData[property]; propertyExists {
+				return sendError(fmt.Errorf("The property '%v' of the record is already used for the rank.", property))
+			}
+			rowData[property] = value
+		}
+
 		rowsData = append(rowsData, rowData)
+		rowsPositions = append(rowsPositions, *position)
+	}
+
+	if distanceFilters != nil {
+		// Without sorting, only the distances of the returned rows are needed
+		if distances == nil {
+			distances, err = jsonArray.distanceIndex.GetDistances(rowsPositions, distanceFilters)
+			if err != nil {
+				return sendError(err)
+			}
+		}
+		for i, position := range rowsPositions {
+			if distance, hasDistance := distances[position]; hasDistance {
+				rowsData[i][jsonArray.config.Distance.Property] = distance
+			}
+		}
 	}
 
 	return json.NewEncoder(sendSucces()).Encode(rowsData)
 }
 
+// Reads all the positions, and returns them ordered from the
+// nearest to the farthest, with the distance of each position.
+// The positions having the same distance keep their original order.
+func (jsonArray *JsonArray) sortByDistance(
+	nextPosition recordPackage.PositionIterator,
+	filters map[string]interface{},
+) (
+	recordPackage.PositionIterator,
+	map[recordPackage.Position]float64,
+	error,
+) {
+	positions := make(recordPackage.PositionList, 0)
+	for {
+		position, err := nextPosition()
+		if err != nil {
+			return nil, nil, err
+		}
+		if position == nil {
+			break
+		}
+
+		positions = append(positions, *position)
+	}
+
+	distances, err := jsonArray.distanceIndex.GetDistances(positions, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	getDistance := func(position recordPackage.Position) float64 {
+		if distance, hasDistance := distances[position]; hasDistance {
+			return distance
+		}
+		return math.Inf(1)
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		return getDistance(positions[i]) < getDistance(positions[j])
+	})
+
+	return positions.Iterate(), distances, nil
+}
+
+// Returns the positions matched by all the filters, ordered from the most
+// relevant to the least relevant according to the filters of the rank index,
+// with the score and highlights to add to the data of each position.
+func (jsonArray *JsonArray) sortByRank(
+	filtersPerIndex map[string]map[string]interface{},
+	rankFilters map[string]interface{},
+) (
+	recordPackage.PositionIterator,
+	map[recordPackage.Position]map[string]interface{},
+	error,
+) {
+	// The other indexes may have excluded some of the ranked records.
+	// The rank index itself is only queried once, when ranking.
+	otherFiltersPerIndex := make(map[string]map[string]interface{}, len(filtersPerIndex))
+	for indexName, filters := range filtersPerIndex {
+		if indexName != jsonArray.config.Rank.Index {
+			otherFiltersPerIndex[indexName] = filters
+		}
+	}
+
+	var allowedPositions map[recordPackage.Position]bool
+	if len(otherFiltersPerIndex) > 0 {
+		positionsPerIndex, err := getFilteredRecordPositionsPerIndex(
+			jsonArray.defaultIndex,
+			jsonArray.indexes,
+			jsonArray.input,
+			otherFiltersPerIndex,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		nextPosition := recordPackage.JoinPositionIterators(positionsPerIndex...)
+		allowedPositions = make(map[recordPackage.Position]bool)
+		for {
+			position, err := nextPosition()
+			if err != nil {
+				return nil, nil, err
+			}
+			if position == nil {
+				break
+			}
+			allowedPositions[*position] = true
+		}
+	}
+
+	// Sorting the names to always return the same order
+	config := jsonArray.config.Rank
+	highlightNames := make([]string, 0, len(config.Highlights))
+	for highlightName := range config.Highlights {
+		highlightNames = append(highlightNames, highlightName)
+	}
+	sort.Strings(highlightNames)
+
+	ranking := indexPackage.Fts5Ranking{
+		Weights:    config.Weights,
+		Highlights: make([]indexPackage.Fts5Highlight, 0, len(highlightNames)),
+	}
+	for _, highlightName := range highlightNames {
+		highlight := config.Highlights[highlightName]
+		ranking.Highlights = append(ranking.Highlights, indexPackage.Fts5Highlight{
+			Property: highlight.Property,
+			Snippet:  highlight.Snippet,
+			Start:    highlight.Start,
+			End:      highlight.End,
+			Ellipsis: highlight.Ellipsis,
+			Tokens:   highlight.Tokens,
+		})
+	}
+
+	rankedRecords, err := jsonArray.rankIndex.GetRankedRecords(jsonArray.input, rankFilters, ranking)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	positions := make(recordPackage.PositionList, 0, len(rankedRecords))
+	rankData := make(map[recordPackage.Position]map[string]interface{}, len(rankedRecords))
+	for _, rankedRecord := range rankedRecords {
+		if allowedPositions != nil && !allowedPositions[rankedRecord.Position] {
+			continue
+		}
+
+		data := make(map[string]interface{}, len(highlightNames)+1)
+		if config.Property != "" {
+			data[config.Property] = rankedRecord.Score
+		}
+		for highlightIndex, highlightName := range highlightNames {
+			data[highlightName] = rankedRecord.Highlights[highlightIndex]
+		}
+
+		positions = append(positions, rankedRecord.Position)
+		rankData[rankedRecord.Position] = data
+	}
+
+	return positions.Iterate(), rankData, nil
+}
+
 func (jsonArray *JsonArray) getLimit(params map[string]string) (uint, error) {
 	limit := jsonArray.config.Limit.Default
 	if limitParam, limitParamExists := params[jsonArray.config.Limit.Parameter]; limitParamExists {
@@ -178,9 +410,9 @@ func (jsonArray *JsonArray) getFiltersPerIndex(params map[string]string) (map[st
 			continue
 		}
 
-		parser, parserExists := jsonArray.parsers[paramConfig.Parser]
-		if !parserExists {
-			return nil, errors.New("Parser '" + paramConfig.Parser + "' does not exist")
+		parser, err := parameterPackage.NewParser(paramConfig, jsonArray.parsers)
+		if err != nil {
+			return nil, err
 		}
 
 		parsedParamValue, err := parser.Parse(paramValue)
@@ -211,12 +443,7 @@ func (jsonArray *JsonArray) GetParameterParser(paramName string) (parserPackage.
 		return nil, errors.New("Parameter '" + paramName + "' does not exist")
 	}
 
-	parser, parserExists := jsonArray.parsers[parameter.Parser]
-	if !parserExists {
-		return nil, errors.New("Parser '" + parameter.Parser + "' does not exist")
-	}
-
-	return parser, nil
+	return parameterPackage.NewParser(parameter, jsonArray.parsers)
 }
 
 func (jsonArray *JsonArray) Close() error {
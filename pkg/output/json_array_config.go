@@ -19,6 +19,8 @@ type JsonArrayConfig struct {
 	Offset        JsonArrayOffsetConfig                              `yaml:"offset"`
 	Parameters    map[string]*parameterPackage.ParameterConfig       `yaml:"parameters"`
 	Relationships map[string]*relationshipPackage.RelationshipConfig `yaml:"relationships"`
+	Distance      *JsonArrayDistanceConfig                           `yaml:"distance"`
+	Rank          *JsonArrayRankConfig                               `yaml:"rank"`
 	Logger        *logrus.Entry
 }
 
@@ -32,10 +34,52 @@ type JsonArrayOffsetConfig struct {
 	Parameter string `yaml:"parameter"`
 }
 
+type JsonArrayDistanceConfig struct {
+	Index    string `yaml:"index"`
+	Property string `yaml:"property"`
+	Sort     bool   `yaml:"sort"`
+}
+
+type JsonArrayRankConfig struct {
+	Index      string                               `yaml:"index"`
+	Property   string                               `yaml:"property"`
+	Weights    map[string]float64                   `yaml:"weights"`
+	Highlights map[string]*JsonArrayHighlightConfig `yaml:"highlights"`
+}
+
+type JsonArrayHighlightConfig struct {
+	Property string `yaml:"property"`
+	Snippet  bool   `yaml:"snippet"`
+	Start    string `yaml:"start"`
+	End      string `yaml:"end"`
+	Ellipsis string `yaml:"ellipsis"`
+	Tokens   int    `yaml:"tokens"`
+}
+
 func (config *JsonArrayConfig) GetName() string {
 	return config.Name
 }
 
+func (config *JsonArrayConfig) GetInputNames() []string {
+	return getConfigInputNames(config.Input, config.Relationships)
+}
+
+func (config *JsonArrayConfig) GetIndexNames() []string {
+	indexNames := getConfigIndexNames(config.Parameters, config.Relationships)
+	if config.Distance != nil {
+		indexNames = append(indexNames, config.Distance.Index)
+	}
+	if config.Rank != nil {
+		indexNames = append(indexNames, config.Rank.Index)
+	}
+
+	return indexNames
+}
+
+func (config *JsonArrayConfig) GetPropertyReferences() []inputPackage.PropertyReference {
+	return getConfigPropertyReferences(config.Input, config.Parameters, config.Relationships)
+}
+
 func (config *JsonArrayConfig) Validate(
 	inputs map[string]inputPackage.Config,
 	indexes map[string]indexPackage.Config,
@@ -85,6 +129,29 @@ func (config *JsonArrayConfig) Validate(
 		}
 	}
 
+	if config.Distance != nil {
+		if err := config.Distance.Validate(indexes, input, log); err != nil {
+			return fmt.Errorf("jsonArray.distance.%v", err)
+		}
+	}
+
+	if config.Rank != nil {
+		if err := config.Rank.Validate(indexes, input, log); err != nil {
+			return fmt.Errorf("jsonArray.rank.%v", err)
+		}
+		if config.Distance != nil && config.Distance.Sort {
+			return errors.New("jsonArray.rank: The records cannot be ranked and sorted by distance at the same time")
+		}
+		for _, property := range config.Rank.getAddedProperties() {
+			if _, exists := config.Relationships[property]; exists {
+				return fmt.Errorf("jsonArray.rank: The property '%v' is already used by a relationship", property)
+			}
+			if config.Distance != nil && property == config.Distance.Property {
+				return fmt.Errorf("jsonArray.rank: The property '%v' is already used for the distance", property)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -119,3 +186,144 @@ func (config *JsonArrayOffsetConfig) Validate(log *logrus.Entry) error {
 
 	return nil
 }
+
+func (config *JsonArrayDistanceConfig) Validate(
+	indexes map[string]indexPackage.Config,
+	input inputPackage.Config,
+	log *logrus.Entry,
+) error {
+	if config.Index == "" {
+		return errors.New("index is required")
+	}
+	index, indexExists := indexes[config.Index]
+	if !indexExists {
+		return fmt.Errorf("index: Index '%v' not found in indexes list.", config.Index)
+	}
+	if !index.DoesHandleInput(input) {
+		return fmt.Errorf("index: Index '%v' does not handle input '%v'.", config.Index, input.GetName())
+	}
+	if !indexPackage.HasDistance(index) {
+		return fmt.Errorf("index: Index '%v' cannot compute distances.", config.Index)
+	}
+
+	if config.Property == "" {
+		log.Debug("jsonArray.distance.property not set. Assuming 'distance'")
+		config.Property = "distance"
+	}
+
+	return nil
+}
+
+func (config *JsonArrayRankConfig) Validate(
+	indexes map[string]indexPackage.Config,
+	input inputPackage.Config,
+	log *logrus.Entry,
+) error {
+	if config.Index == "" {
+		return errors.New("index is required")
+	}
+	index, indexExists := indexes[config.Index]
+	if !indexExists {
+		return fmt.Errorf("index: Index '%v' not found in indexes list.", config.Index)
+	}
+	if !index.DoesHandleInput(input) {
+		return fmt.Errorf("index: Index '%v' does not handle input '%v'.", config.Index, input.GetName())
+	}
+	fts5Config, isFts5 := index.(*indexPackage.Fts5Config)
+	if !isFts5 {
+		return fmt.Errorf("index: Index '%v' is not an fts5 index.", config.Index)
+	}
+
+	if config.Property == "" {
+		log.Debug("jsonArray.rank.property not set. The score will not be added to the records")
+	} else if inputPackage.DeclaresProperty(input, config.Property) {
+		return fmt.Errorf("property: The property '%v' already exists in the input '%v'.", config.Property, input.GetName())
+	}
+
+	for property, weight := range config.Weights {
+		if !isFts5Property(fts5Config, property) {
+			return fmt.Errorf("weights.%v: Index '%v' does not handle property '%v'.", property, config.Index, property)
+		}
+		if weight < 0 {
+			return fmt.Errorf("weights.%v: The weight cannot be negative.", property)
+		}
+	}
+
+	for highlightName, highlight := range config.Highlights {
+		if err := highlight.Validate(fts5Config, log); err != nil {
+			return fmt.Errorf("highlights.%v.%v", highlightName, err)
+		}
+		if highlightName == config.Property {
+			return fmt.Errorf("highlights.%v: The property '%v' is already used for the score", highlightName, highlightName)
+		}
+		if inputPackage.DeclaresProperty(input, highlightName) {
+			return fmt.Errorf("highlights.%v: The property '%v' already exists in the input '%v'.", highlightName, highlightName, input.GetName())
+		}
+	}
+
+	return nil
+}
+
+// Returns the properties added to the records for the score and highlights
+func (config *JsonArrayRankConfig) getAddedProperties() []string {
+	properties := make([]string, 0, len(config.Highlights)+1)
+	if config.Property != "" {
+		properties = append(properties, config.Property)
+	}
+	for highlightName := range config.Highlights {
+		properties = append(properties, highlightName)
+	}
+
+	return properties
+}
+
+func (config *JsonArrayHighlightConfig) Validate(
+	index *indexPackage.Fts5Config,
+	log *logrus.Entry,
+) error {
+	if config.Property == "" {
+		return errors.New("property is required")
+	}
+	if !isFts5Property(index, config.Property) {
+		return fmt.Errorf("property: Index '%v' does not handle property '%v'.", index.Name, config.Property)
+	}
+
+	if config.Start == "" {
+		log.Debug("jsonArray.rank.highlights.start not set. Assuming '<b>'")
+		config.Start = "<b>"
+	}
+
+	if config.End == "" {
+		log.Debug("jsonArray.rank.highlights.end not set. Assuming '</b>'")
+		config.End = "</b>"
+	}
+
+	if config.Snippet {
+		if config.Ellipsis == "" {
+			log.Debug("jsonArray.rank.highlights.ellipsis not set. Assuming '...'")
+			config.Ellipsis = "..."
+		}
+
+		if config.Tokens == 0 {
+			log.Debug("jsonArray.rank.highlights.tokens not set. Assuming '16'")
+			config.Tokens = 16
+		}
+		if config.Tokens < 1 || config.Tokens > 64 {
+			return errors.New("tokens must be between 1 and 64")
+		}
+	} else if config.Ellipsis != "" || config.Tokens != 0 {
+		return errors.New("ellipsis and tokens can only be used with a snippet")
+	}
+
+	return nil
+}
+
+func isFts5Property(index *indexPackage.Fts5Config, property string) bool {
+	for _, indexedProperty := range index.Properties {
+		if indexedProperty == property {
+			return true
+		}
+	}
+
+	return false
+}
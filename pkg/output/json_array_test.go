@@ -3,10 +3,17 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
 	parameterPackage "github.com/rodb-io/rodb/pkg/output/parameter"
 	relationshipPackage "github.com/rodb-io/rodb/pkg/output/relationship"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
 	"io"
 	"io/ioutil"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -427,3 +434,282 @@ func TestJsonArrayGetFiltersPerIndex(t *testing.T) {
 		}
 	})
 }
+
+func handleJsonArrayForTests(t *testing.T, jsonArray *JsonArray, params map[string]string) string {
+	buffer := bytes.NewBufferString("")
+	err := jsonArray.Handle(
+		params,
+		[]byte{},
+		func(err error) error {
+			return err
+		},
+		func() io.Writer {
+			return buffer
+		},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	return buffer.String()
+}
+
+func TestJsonArrayNullValueParameter(t *testing.T) {
+	mockInput := input.NewMock(parser.NewMock(), []record.Record{
+		record.NewValuesMockRecord(map[string]interface{}{"id": int64(1), "email": "alice@example.com"}, 0),
+		record.NewValuesMockRecord(map[string]interface{}{"id": int64(2), "email": nil}, 1),
+		record.NewValuesMockRecord(map[string]interface{}{"id": int64(3), "email": nil}, 2),
+	})
+	inputs := input.List{"users": mockInput}
+	defaultIndex := index.NewNoop(&index.NoopConfig{}, inputs)
+
+	nullValue := "null"
+	jsonArray, err := NewJsonArray(
+		&JsonArrayConfig{
+			Input: "users",
+			Limit: JsonArrayLimitConfig{Default: 10, Max: 10},
+			Parameters: map[string]*parameterPackage.ParameterConfig{
+				"email": {Property: "email", Index: "default", Parser: "mock", NullValue: &nullValue},
+			},
+		},
+		inputs,
+		defaultIndex,
+		index.List{"default": defaultIndex},
+		parser.List{"mock": parser.NewMock()},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	expect := "[{\"email\":null,\"id\":2},{\"email\":null,\"id\":3}]\n"
+	if got := handleJsonArrayForTests(t, jsonArray, map[string]string{"email": "null"}); got != expect {
+		t.Fatalf("Expected '%v', got '%v'", expect, got)
+	}
+}
+
+func TestJsonArrayDistance(t *testing.T) {
+	logger := logrus.NewEntry(logrus.StandardLogger())
+	floatConfig := &parser.FloatConfig{Name: "float", DecimalSeparator: "."}
+	geoPointConfig := &parser.GeoPointConfig{Name: "geopoint"}
+	for _, config := range []parser.Config{floatConfig, geoPointConfig} {
+		if err := config.Validate(map[string]parser.Config{}, logger); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+	}
+	parsers := parser.List{
+		"mock":     parser.NewMock(),
+		"float":    parser.NewFloat(floatConfig),
+		"geopoint": parser.NewGeoPoint(geoPointConfig),
+	}
+
+	t.Run("geo", func(t *testing.T) {
+		mockInput := input.NewMock(parser.NewMock(), []record.Record{
+			record.NewValuesMockRecord(map[string]interface{}{"id": int64(1), "lat": 48.8049, "lon": 2.1204}, 0),
+			record.NewValuesMockRecord(map[string]interface{}{"id": int64(2), "lat": 48.8566, "lon": 2.3522}, 1),
+			record.NewValuesMockRecord(map[string]interface{}{"id": int64(3), "lat": 51.5074, "lon": -0.1278}, 2),
+		})
+		inputs := input.List{"stores": mockInput}
+		defaultIndex := index.NewNoop(&index.NoopConfig{}, inputs)
+		geoIndex, err := index.NewGeo(&index.GeoConfig{
+			Input:             "stores",
+			Path:              filepath.Join(t.TempDir(), "geo.rodb"),
+			LatitudeProperty:  "lat",
+			LongitudeProperty: "lon",
+			Logger:            logger,
+		}, inputs)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		jsonArray, err := NewJsonArray(
+			&JsonArrayConfig{
+				Input: "stores",
+				Limit: JsonArrayLimitConfig{Default: 10, Max: 10},
+				Parameters: map[string]*parameterPackage.ParameterConfig{
+					"near":   {Property: "point", Index: "geo", Parser: "geopoint"},
+					"radius": {Property: "radius", Index: "geo", Parser: "float"},
+				},
+				Distance: &JsonArrayDistanceConfig{Index: "geo", Property: "distance", Sort: true},
+			},
+			inputs,
+			defaultIndex,
+			index.List{"default": defaultIndex, "geo": geoIndex},
+			parsers,
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		response := handleJsonArrayForTests(t, jsonArray, map[string]string{
+			"near":   "48.8566,2.3522",
+			"radius": "500000",
+		})
+		stores := make([]struct {
+			Id       int64
+			Distance float64
+		}, 0)
+		if err := json.Unmarshal([]byte(response), &stores); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := 3, len(stores); got != expect {
+			t.Fatalf("Expected '%v' stores, got '%v'", expect, response)
+		}
+		for i, expect := range []struct {
+			id          int64
+			minDistance float64
+			maxDistance float64
+		}{
+			{2, 0, 0},
+			{1, 17000, 18000},
+			{3, 343000, 344000},
+		} {
+			if got := stores[i]; got.Id != expect.id || got.Distance < expect.minDistance || got.Distance > expect.maxDistance {
+				t.Fatalf("Expected '%+v' at %v, got '%v'", expect, i, response)
+			}
+		}
+	})
+	t.Run("fuzzy", func(t *testing.T) {
+		mockInput := input.NewMock(parser.NewMock(), []record.Record{
+			record.NewValuesMockRecord(map[string]interface{}{"id": int64(1), "word": "accommodate"}, 0),
+			record.NewValuesMockRecord(map[string]interface{}{"id": int64(2), "word": "accommodation"}, 1),
+			record.NewValuesMockRecord(map[string]interface{}{"id": int64(3), "word": "accordion"}, 2),
+			record.NewValuesMockRecord(map[string]interface{}{"id": int64(4), "word": "commode"}, 3),
+		})
+		inputs := input.List{"words": mockInput}
+		defaultIndex := index.NewNoop(&index.NoopConfig{}, inputs)
+		maxDistance := 3
+		fuzzyIndex, err := index.NewFuzzy(&index.FuzzyConfig{
+			Input:       "words",
+			Path:        filepath.Join(t.TempDir(), "fuzzy.rodb"),
+			Properties:  []string{"word"},
+			MaxDistance: &maxDistance,
+			Logger:      logger,
+		}, inputs)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		jsonArray, err := NewJsonArray(
+			&JsonArrayConfig{
+				Input: "words",
+				Limit: JsonArrayLimitConfig{Default: 10, Max: 10},
+				Parameters: map[string]*parameterPackage.ParameterConfig{
+					"search": {Property: "word", Index: "fuzzy", Parser: "mock"},
+				},
+				Distance: &JsonArrayDistanceConfig{Index: "fuzzy", Property: "typos", Sort: true},
+			},
+			inputs,
+			defaultIndex,
+			index.List{"default": defaultIndex, "fuzzy": fuzzyIndex},
+			parsers,
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		expect := "[{\"id\":2,\"typos\":2,\"word\":\"accommodation\"},{\"id\":1,\"typos\":3,\"word\":\"accommodate\"}]\n"
+		if got := handleJsonArrayForTests(t, jsonArray, map[string]string{"search": "acommodaton"}); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
+
+func TestJsonArrayRank(t *testing.T) {
+	mockInput := input.NewMock(parser.NewMock(), []record.Record{
+		record.NewValuesMockRecord(map[string]interface{}{"id": int64(1), "title": "Pasta", "body": "Cooking pasta in salted water", "category": "main"}, 0),
+		record.NewValuesMockRecord(map[string]interface{}{"id": int64(2), "title": "Cooking rice", "body": "Rinse the rice before cooking it", "category": "side"}, 1),
+		record.NewValuesMockRecord(map[string]interface{}{"id": int64(3), "title": "Salad", "body": "Mix the vegetables", "category": "main"}, 2),
+		record.NewValuesMockRecord(map[string]interface{}{"id": int64(4), "title": "Cooking tips", "body": "Taste while cooking", "category": "side"}, 3),
+	})
+	inputs := input.List{"recipes": mockInput}
+	defaultIndex := index.NewNoop(&index.NoopConfig{}, inputs)
+	fts5Index, err := index.NewFts5(&index.Fts5Config{
+		Name:       "fts5",
+		Input:      "recipes",
+		Dsn:        ":memory:",
+		Properties: []string{"title", "body"},
+		Logger:     logrus.NewEntry(logrus.StandardLogger()),
+	}, inputs)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	defer fts5Index.Close()
+
+	newJsonArrayForTests := func(t *testing.T, rankProperty string) *JsonArray {
+		jsonArray, err := NewJsonArray(
+			&JsonArrayConfig{
+				Input: "recipes",
+				Limit: JsonArrayLimitConfig{Default: 10, Max: 10},
+				Parameters: map[string]*parameterPackage.ParameterConfig{
+					"search":   {Property: "match", Index: "fts5", Parser: "mock"},
+					"category": {Property: "category", Index: "default", Parser: "mock"},
+				},
+				Rank: &JsonArrayRankConfig{
+					Index:    "fts5",
+					Property: rankProperty,
+					Weights:  map[string]float64{"title": 10},
+					Highlights: map[string]*JsonArrayHighlightConfig{
+						"highlight": {Property: "title", Start: "[", End: "]"},
+					},
+				},
+			},
+			inputs,
+			defaultIndex,
+			index.List{"default": defaultIndex, "fts5": fts5Index},
+			parser.List{"mock": parser.NewMock()},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		return jsonArray
+	}
+
+	for _, testCase := range []struct {
+		name   string
+		params map[string]string
+		expect []map[string]interface{}
+	}{
+		{
+			name:   "rank index only",
+			params: map[string]string{"search": "cooking"},
+			expect: []map[string]interface{}{
+				{"id": float64(4), "title": "Cooking tips", "body": "Taste while cooking", "category": "side", "highlight": "[Cooking] tips"},
+				{"id": float64(2), "title": "Cooking rice", "body": "Rinse the rice before cooking it", "category": "side", "highlight": "[Cooking] rice"},
+				{"id": float64(1), "title": "Pasta", "body": "Cooking pasta in salted water", "category": "main", "highlight": "Pasta"},
+			},
+		}, {
+			name:   "other index",
+			params: map[string]string{"search": "cooking", "category": "side"},
+			expect: []map[string]interface{}{
+				{"id": float64(4), "title": "Cooking tips", "body": "Taste while cooking", "category": "side", "highlight": "[Cooking] tips"},
+				{"id": float64(2), "title": "Cooking rice", "body": "Rinse the rice before cooking it", "category": "side", "highlight": "[Cooking] rice"},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			rows := make([]map[string]interface{}, 0)
+			if err := json.Unmarshal([]byte(handleJsonArrayForTests(t, newJsonArrayForTests(t, ""), testCase.params)), &rows); err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if !reflect.DeepEqual(testCase.expect, rows) {
+				t.Fatalf("Expected '%+v', got '%+v'", testCase.expect, rows)
+			}
+		})
+	}
+	t.Run("existing property", func(t *testing.T) {
+		err := newJsonArrayForTests(t, "title").Handle(
+			map[string]string{"search": "cooking"},
+			[]byte{},
+			func(err error) error {
+				return err
+			},
+			func() io.Writer {
+				return bytes.NewBufferString("")
+			},
+		)
+		if err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
@@ -6,9 +6,80 @@ import (
 	indexPackage "github.com/rodb-io/rodb/pkg/index"
 	inputPackage "github.com/rodb-io/rodb/pkg/input"
 	recordPackage "github.com/rodb-io/rodb/pkg/input/record"
+	parameterPackage "github.com/rodb-io/rodb/pkg/output/parameter"
 	relationshipPackage "github.com/rodb-io/rodb/pkg/output/relationship"
+	"sort"
 )
 
+func getConfigInputNames(
+	inputName string,
+	relationships map[string]*relationshipPackage.RelationshipConfig,
+) []string {
+	inputNames := []string{inputName}
+	for _, relationship := range relationships {
+		inputNames = append(inputNames, relationship.GetInputNames()...)
+	}
+
+	return inputNames
+}
+
+func getConfigPropertyReferences(
+	inputName string,
+	parameters map[string]*parameterPackage.ParameterConfig,
+	relationships map[string]*relationshipPackage.RelationshipConfig,
+) []inputPackage.PropertyReference {
+	references := make([]inputPackage.PropertyReference, 0)
+	for _, parameterName := range getSortedParameterNames(parameters) {
+		parameter := parameters[parameterName]
+		// The search filters of some indexes are not input properties
+		if parameter.IndexConfig != nil && indexPackage.IsSearchFilter(parameter.IndexConfig, parameter.Property) {
+			continue
+		}
+
+		references = append(references, inputPackage.PropertyReference{
+			Input:      inputName,
+			Property:   parameter.Property,
+			ConfigPath: "parameters." + parameterName + ".property",
+		})
+	}
+	for _, relationshipName := range relationshipPackage.GetSortedNames(relationships) {
+		references = append(references, relationships[relationshipName].GetPropertyReferences(
+			inputName,
+			"relationships."+relationshipName,
+		)...)
+	}
+
+	return references
+}
+
+// The parameters are sorted, to always report the errors in the same order
+func getSortedParameterNames(parameters map[string]*parameterPackage.ParameterConfig) []string {
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// The default index is always included, because
+// it is used when a filter has no dedicated index
+func getConfigIndexNames(
+	parameters map[string]*parameterPackage.ParameterConfig,
+	relationships map[string]*relationshipPackage.RelationshipConfig,
+) []string {
+	indexNames := []string{"default"}
+	for _, parameter := range parameters {
+		indexNames = append(indexNames, parameter.Index)
+	}
+	for _, relationship := range relationships {
+		indexNames = append(indexNames, relationship.GetIndexNames()...)
+	}
+
+	return indexNames
+}
+
 func checkRelationshipMatches(
 	inputs inputPackage.List,
 	relationship *relationshipPackage.RelationshipConfig,
@@ -28,6 +99,41 @@ func checkRelationshipMatches(
 	return nil
 }
 
+// Lists the given root input and the inputs of all
+// the (nested) relationships, indexed by their name
+func getUsedInputs(
+	inputs inputPackage.List,
+	rootInput inputPackage.Input,
+	relationships map[string]*relationshipPackage.RelationshipConfig,
+) (inputPackage.List, error) {
+	usedInputs := inputPackage.List{
+		rootInput.Name(): rootInput,
+	}
+
+	var addRelationshipInputs func(relationships map[string]*relationshipPackage.RelationshipConfig) error
+	addRelationshipInputs = func(relationships map[string]*relationshipPackage.RelationshipConfig) error {
+		for _, relationship := range relationships {
+			relationshipInput, inputExists := inputs[relationship.Input]
+			if !inputExists {
+				return fmt.Errorf("Input '%v' not found in inputs list.", relationship.Input)
+			}
+			usedInputs[relationshipInput.Name()] = relationshipInput
+
+			if err := addRelationshipInputs(relationship.Relationships); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := addRelationshipInputs(relationships); err != nil {
+		return nil, err
+	}
+
+	return usedInputs, nil
+}
+
 func getRelationshipFiltersPerIndex(
 	data map[string]interface{},
 	matchConfig []*relationshipPackage.RelationshipMatchConfig,
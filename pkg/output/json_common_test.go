@@ -4,8 +4,11 @@ import (
 	"github.com/rodb-io/rodb/pkg/index"
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
+	parameterPackage "github.com/rodb-io/rodb/pkg/output/parameter"
 	relationshipPackage "github.com/rodb-io/rodb/pkg/output/relationship"
 	"github.com/rodb-io/rodb/pkg/parser"
+	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -64,6 +67,118 @@ func mockJsonDataForTests() jsonDataForTests {
 	}
 }
 
+func TestGetUsedInputs(t *testing.T) {
+	mockedJsonData := mockJsonDataForTests()
+	t.Run("normal", func(t *testing.T) {
+		usedInputs, err := getUsedInputs(
+			mockedJsonData.inputs,
+			mockedJsonData.mockInput,
+			map[string]*relationshipPackage.RelationshipConfig{
+				"children": {
+					Input: "mock",
+					Relationships: map[string]*relationshipPackage.RelationshipConfig{
+						"grandchildren": {Input: "mock"},
+					},
+				},
+			},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := 1, len(usedInputs); got != expect {
+			t.Fatalf("Expected %v inputs, got %v", expect, got)
+		}
+		if expect, got := mockedJsonData.mockInput, usedInputs["mock"]; got != expect {
+			t.Fatalf("Expected '%+v', got '%+v'", expect, got)
+		}
+	})
+	t.Run("wrong input", func(t *testing.T) {
+		_, err := getUsedInputs(
+			mockedJsonData.inputs,
+			mockedJsonData.mockInput,
+			map[string]*relationshipPackage.RelationshipConfig{
+				"children": {
+					Input: "mock",
+					Relationships: map[string]*relationshipPackage.RelationshipConfig{
+						"grandchildren": {Input: "wrong"},
+					},
+				},
+			},
+		)
+		if err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
+
+func TestGetConfigDependencies(t *testing.T) {
+	relationships := map[string]*relationshipPackage.RelationshipConfig{
+		"children": {
+			Input: "child",
+			Match: []*relationshipPackage.RelationshipMatchConfig{
+				{ChildIndex: "childIndex"},
+			},
+			Relationships: map[string]*relationshipPackage.RelationshipConfig{
+				"grandchildren": {
+					Input: "grandchild",
+					Match: []*relationshipPackage.RelationshipMatchConfig{
+						{ChildIndex: "grandchildIndex"},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("inputs", func(t *testing.T) {
+		got := getConfigInputNames("root", relationships)
+		sort.Strings(got)
+		if expect := []string{"child", "grandchild", "root"}; !reflect.DeepEqual(expect, got) {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("indexes", func(t *testing.T) {
+		got := getConfigIndexNames(map[string]*parameterPackage.ParameterConfig{
+			"id": {Index: "rootIndex"},
+		}, relationships)
+		sort.Strings(got)
+		if expect := []string{"childIndex", "default", "grandchildIndex", "rootIndex"}; !reflect.DeepEqual(expect, got) {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
+
+func TestGetConfigPropertyReferences(t *testing.T) {
+	parameters := map[string]*parameterPackage.ParameterConfig{
+		"c": {Property: "c"},
+		"a": {Property: "a"},
+		"b": {Property: "b"},
+		"d": {Property: "point", Index: "geo", IndexConfig: &index.GeoConfig{}},
+		"e": {Property: "e", Index: "map", IndexConfig: &index.MapConfig{}},
+	}
+	relationships := map[string]*relationshipPackage.RelationshipConfig{
+		"y": {Input: "child", Sort: []*record.SortConfig{{Property: "y"}}},
+		"x": {Input: "child", Sort: []*record.SortConfig{{Property: "x"}}},
+	}
+
+	expect := []string{
+		"parameters.a.property",
+		"parameters.b.property",
+		"parameters.c.property",
+		"parameters.e.property",
+		"relationships.x.sort.0.property",
+		"relationships.y.sort.0.property",
+	}
+	for i := 0; i < 10; i++ {
+		got := make([]string, 0)
+		for _, reference := range getConfigPropertyReferences("root", parameters, relationships) {
+			got = append(got, reference.ConfigPath)
+		}
+		if !reflect.DeepEqual(expect, got) {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	}
+}
+
 func TestJsonObjectGetRelationshipFiltersPerIndex(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		filtersPerIndex, err := getRelationshipFiltersPerIndex(
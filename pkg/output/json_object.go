@@ -7,6 +7,7 @@ import (
 	indexPackage "github.com/rodb-io/rodb/pkg/index"
 	inputPackage "github.com/rodb-io/rodb/pkg/input"
 	recordPackage "github.com/rodb-io/rodb/pkg/input/record"
+	parameterPackage "github.com/rodb-io/rodb/pkg/output/parameter"
 	parserPackage "github.com/rodb-io/rodb/pkg/parser"
 	"io"
 )
@@ -15,6 +16,7 @@ type JsonObject struct {
 	config       *JsonObjectConfig
 	inputs       inputPackage.List
 	input        inputPackage.Input
+	usedInputs   inputPackage.List
 	defaultIndex indexPackage.Index
 	indexes      indexPackage.List
 	paramParsers map[string]parserPackage.Parser
@@ -29,9 +31,9 @@ func NewJsonObject(
 ) (*JsonObject, error) {
 	paramParsers := make(map[string]parserPackage.Parser)
 	for paramName, param := range config.Parameters {
-		parser, parserExists := parsers[param.Parser]
-		if !parserExists {
-			return nil, errors.New("Parser '" + param.Parser + "' does not exist")
+		parser, err := parameterPackage.NewParser(param, parsers)
+		if err != nil {
+			return nil, err
 		}
 		paramParsers[paramName] = parser
 	}
@@ -56,6 +58,12 @@ func NewJsonObject(
 		}
 	}
 
+	usedInputs, err := getUsedInputs(jsonObject.inputs, jsonObject.input, jsonObject.config.Relationships)
+	if err != nil {
+		return nil, err
+	}
+	jsonObject.usedInputs = usedInputs
+
 	return jsonObject, nil
 }
 
@@ -67,6 +75,10 @@ func (jsonObject *JsonObject) ExpectedPayloadType() *string {
 	return nil
 }
 
+func (jsonObject *JsonObject) Inputs() inputPackage.List {
+	return jsonObject.usedInputs
+}
+
 func (jsonObject *JsonObject) ResponseType() string {
 	return "application/json"
 }
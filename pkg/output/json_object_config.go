@@ -24,6 +24,18 @@ func (config *JsonObjectConfig) GetName() string {
 	return config.Name
 }
 
+func (config *JsonObjectConfig) GetInputNames() []string {
+	return getConfigInputNames(config.Input, config.Relationships)
+}
+
+func (config *JsonObjectConfig) GetIndexNames() []string {
+	return getConfigIndexNames(config.Parameters, config.Relationships)
+}
+
+func (config *JsonObjectConfig) GetPropertyReferences() []inputPackage.PropertyReference {
+	return getConfigPropertyReferences(config.Input, config.Parameters, config.Relationships)
+}
+
 func (config *JsonObjectConfig) Validate(
 	inputs map[string]inputPackage.Config,
 	indexes map[string]indexPackage.Config,
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/parser"
 	"io"
 )
@@ -8,6 +9,7 @@ import (
 type Mock struct {
 	MockOutput      func(params map[string]string) ([]byte, error)
 	MockPayloadType *string
+	MockInputs      input.List
 	parser          parser.Parser
 }
 
@@ -23,6 +25,14 @@ func (mock *Mock) ExpectedPayloadType() *string {
 	return mock.MockPayloadType
 }
 
+func (mock *Mock) Inputs() input.List {
+	if mock.MockInputs == nil {
+		return input.List{}
+	}
+
+	return mock.MockInputs
+}
+
 func (mock *Mock) ResponseType() string {
 	return "text/plain"
 }
@@ -7,12 +7,16 @@ import (
 	"github.com/rodb-io/rodb/pkg/parser"
 	"github.com/sirupsen/logrus"
 	"io"
+	"reflect"
 )
 
 type Output interface {
 	Name() string
 	ExpectedPayloadType() *string
 	ResponseType() string
+	// Returns the inputs that are used to build the responses
+	// (including the relationships), indexed by their name
+	Inputs() input.List
 	Handle(
 		params map[string]string,
 		payload []byte,
@@ -32,6 +36,13 @@ type Config interface {
 		log *logrus.Entry,
 	) error
 	GetName() string
+	// Returns the names of the inputs and indexes used to build
+	// the responses (including the relationships), which must
+	// exist for this output to be created
+	GetInputNames() []string
+	GetIndexNames() []string
+	// Returns the properties used by the parameters and relationships
+	GetPropertyReferences() []input.PropertyReference
 }
 
 type List = map[string]Output
@@ -47,14 +58,12 @@ func NewFromConfig(
 		return nil, fmt.Errorf("Index 'default' not found in indexes list.")
 	}
 
-	switch config.(type) {
-	case *JsonObjectConfig:
-		return NewJsonObject(config.(*JsonObjectConfig), inputs, defaultIndex, indexes, parsers)
-	case *JsonArrayConfig:
-		return NewJsonArray(config.(*JsonArrayConfig), inputs, defaultIndex, indexes, parsers)
-	default:
+	registration, exists := registrationsByConfigType[reflect.TypeOf(config)]
+	if !exists {
 		return nil, fmt.Errorf("Unknown output config type: %#v", config)
 	}
+
+	return registration.constructor(config, inputs, defaultIndex, indexes, parsers)
 }
 
 func NewFromConfigs(
@@ -67,6 +76,7 @@ func NewFromConfigs(
 	for outputName, outputConfig := range configs {
 		output, err := NewFromConfig(outputConfig, inputs, indexes, parsers)
 		if err != nil {
+			Close(outputs)
 			return nil, err
 		}
 		outputs[outputName] = output
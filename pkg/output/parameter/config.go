@@ -10,9 +10,11 @@ import (
 )
 
 type ParameterConfig struct {
-	Property string `yaml:"property"`
-	Index    string `yaml:"index"`
-	Parser   string `yaml:"parser"`
+	Property    string  `yaml:"property"`
+	Index       string  `yaml:"index"`
+	Parser      string  `yaml:"parser"`
+	NullValue   *string `yaml:"nullValue"`
+	IndexConfig index.Config
 }
 
 func (config *ParameterConfig) Validate(
@@ -30,16 +32,20 @@ func (config *ParameterConfig) Validate(
 		log.Debugf(logPrefix + "index is empty. Assuming 'default'.\n")
 		config.Index = "default"
 	}
-	index, indexExists := indexes[config.Index]
+	indexConfig, indexExists := indexes[config.Index]
 	if !indexExists {
 		return fmt.Errorf("index: Index '%v' not found in indexes list.", config.Index)
 	}
-	if !index.DoesHandleInput(input) {
+	if !indexConfig.DoesHandleInput(input) {
 		return fmt.Errorf("index: Index '%v' does not handle input '%v'.", config.Index, input.GetName())
 	}
-	if !index.DoesHandleProperty(config.Property) {
+	if !indexConfig.DoesHandleProperty(config.Property) {
 		return fmt.Errorf("property: Index '%v' does not handle property '%v'.", config.Index, config.Property)
 	}
+	if config.NullValue != nil && !index.HandlesNullValues(indexConfig) {
+		return fmt.Errorf("nullValue: Index '%v' cannot search for the null values.", config.Index)
+	}
+	config.IndexConfig = indexConfig
 
 	if config.Parser == "" {
 		log.Debug(logPrefix + "parser not defined. Assuming 'string'")
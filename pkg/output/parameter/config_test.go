@@ -0,0 +1,44 @@
+package parameter
+
+import (
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestParameterConfigValidate(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	inputConfig := &input.CsvConfig{Name: "input"}
+	indexes := map[string]index.Config{
+		"map":   &index.MapConfig{Name: "map", Input: "input", Properties: []string{"col"}},
+		"fuzzy": &index.FuzzyConfig{Name: "fuzzy", Input: "input", Properties: []string{"col"}},
+	}
+	parsers := map[string]parser.Config{
+		"string": &parser.StringConfig{Name: "string"},
+	}
+	nullValue := "null"
+
+	for _, testCase := range []struct {
+		name        string
+		config      *ParameterConfig
+		expectError bool
+	}{
+		{"valid", &ParameterConfig{Property: "col", Index: "fuzzy"}, false},
+		{"null value", &ParameterConfig{Property: "col", Index: "map", NullValue: &nullValue}, false},
+		{"unhandled null value", &ParameterConfig{Property: "col", Index: "fuzzy", NullValue: &nullValue}, true},
+		{"unknown index", &ParameterConfig{Property: "col", Index: "wrong"}, true},
+		{"unknown parser", &ParameterConfig{Property: "col", Index: "map", Parser: "wrong"}, true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.config.Validate(indexes, parsers, log, "", inputConfig)
+			if testCase.expectError && err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+			if !testCase.expectError && err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+		})
+	}
+}
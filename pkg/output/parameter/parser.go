@@ -0,0 +1,53 @@
+package parameter
+
+import (
+	"fmt"
+	parserPackage "github.com/rodb-io/rodb/pkg/parser"
+	"regexp"
+)
+
+// Parses the values of a parameter with it's parser, except
+// it's null value, which searches for the null properties
+type nullableParser struct {
+	parserPackage.Parser
+	nullValue string
+}
+
+// Returns the parser of the parameter. When the parameter has
+// a null value, the parser also accepts it, and returns nil.
+func NewParser(config *ParameterConfig, parsers parserPackage.List) (parserPackage.Parser, error) {
+	parser, parserExists := parsers[config.Parser]
+	if !parserExists {
+		return nil, fmt.Errorf("Parser '%v' not found in parsers list.", config.Parser)
+	}
+
+	if config.NullValue == nil {
+		return parser, nil
+	}
+
+	return &nullableParser{
+		Parser:    parser,
+		nullValue: *config.NullValue,
+	}, nil
+}
+
+func (parser *nullableParser) GetRegexpPattern() string {
+	return "(?:" + regexp.QuoteMeta(parser.nullValue) + "|" + parser.Parser.GetRegexpPattern() + ")"
+}
+
+// Forwards the changes of the pattern of the wrapped parser
+func (parser *nullableParser) OnChange(callback func()) func() {
+	if changingParser, isChanging := parser.Parser.(parserPackage.ChangingParser); isChanging {
+		return changingParser.OnChange(callback)
+	}
+
+	return func() {}
+}
+
+func (parser *nullableParser) Parse(value string) (interface{}, error) {
+	if value == parser.nullValue {
+		return nil, nil
+	}
+
+	return parser.Parser.Parse(value)
+}
@@ -0,0 +1,55 @@
+package parameter
+
+import (
+	"github.com/rodb-io/rodb/pkg/parser"
+	"regexp"
+	"testing"
+)
+
+func TestNewParser(t *testing.T) {
+	parsers := parser.List{
+		"integer": parser.NewInteger(&parser.IntegerConfig{}),
+	}
+
+	t.Run("without null value", func(t *testing.T) {
+		integer, err := NewParser(&ParameterConfig{Parser: "integer"}, parsers)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if got, err := integer.Parse("null"); err == nil {
+			t.Fatalf("Expected an error, got '%v'", got)
+		}
+	})
+	t.Run("with null value", func(t *testing.T) {
+		nullValue := "null"
+		integer, err := NewParser(&ParameterConfig{Parser: "integer", NullValue: &nullValue}, parsers)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		for value, expect := range map[string]interface{}{
+			"null": nil,
+			"42":   int64(42),
+		} {
+			got, err := integer.Parse(value)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got != expect {
+				t.Fatalf("Expected '%#v', got '%#v'", expect, got)
+			}
+		}
+
+		patternRegexp := regexp.MustCompile("^(" + integer.GetRegexpPattern() + ")$")
+		for _, value := range []string{"null", "42"} {
+			if !patternRegexp.MatchString(value) {
+				t.Fatalf("Expected the pattern to match '%v'", value)
+			}
+		}
+	})
+	t.Run("unknown parser", func(t *testing.T) {
+		if _, err := NewParser(&ParameterConfig{Parser: "wrong"}, parsers); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"reflect"
+	"sort"
+)
+
+// Creates an output from its validated config, and the
+// inputs, indexes and parsers it refers to.
+type Constructor = func(config Config, inputs input.List, defaultIndex index.Index, indexes index.List, parsers parser.List) (Output, error)
+
+type registration struct {
+	newConfig   func() Config
+	constructor Constructor
+}
+
+var registrations = map[string]*registration{}
+var registrationsByConfigType = map[reflect.Type]*registration{}
+
+func init() {
+	Register("jsonObject", func() Config { return &JsonObjectConfig{} }, func(config Config, inputs input.List, defaultIndex index.Index, indexes index.List, parsers parser.List) (Output, error) {
+		return NewJsonObject(config.(*JsonObjectConfig), inputs, defaultIndex, indexes, parsers)
+	})
+	Register("jsonArray", func() Config { return &JsonArrayConfig{} }, func(config Config, inputs input.List, defaultIndex index.Index, indexes index.List, parsers parser.List) (Output, error) {
+		return NewJsonArray(config.(*JsonArrayConfig), inputs, defaultIndex, indexes, parsers)
+	})
+	Register("graphql", func() Config { return &GraphQLConfig{} }, func(config Config, inputs input.List, defaultIndex index.Index, indexes index.List, parsers parser.List) (Output, error) {
+		return nil, errors.New("The graphql output is not implemented yet.")
+	})
+}
+
+// Makes a type of output available under the given "type" value.
+func Register(typeName string, newConfig func() Config, constructor Constructor) {
+	if _, exists := registrations[typeName]; exists {
+		panic(fmt.Sprintf("The output type '%v' is already registered.", typeName))
+	}
+
+	configType := reflect.TypeOf(newConfig())
+	if _, exists := registrationsByConfigType[configType]; exists {
+		panic(fmt.Sprintf("The output config type '%v' is already registered.", configType))
+	}
+
+	registration := &registration{
+		newConfig:   newConfig,
+		constructor: constructor,
+	}
+	registrations[typeName] = registration
+	registrationsByConfigType[configType] = registration
+}
+
+// Returns a new, empty config of the given output type
+func NewConfig(typeName string) (Config, bool) {
+	registration, exists := registrations[typeName]
+	if !exists {
+		return nil, false
+	}
+
+	return registration.newConfig(), true
+}
+
+// Returns the sorted names of the registered output types
+func TypeNames() []string {
+	typeNames := make([]string, 0, len(registrations))
+	for typeName := range registrations {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	return typeNames
+}
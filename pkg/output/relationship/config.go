@@ -6,6 +6,7 @@ import (
 	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
 	"github.com/sirupsen/logrus"
+	"sort"
 )
 
 type RelationshipConfig struct {
@@ -85,6 +86,67 @@ func (config *RelationshipConfig) Validate(
 	return nil
 }
 
+// Returns the names of the inputs of this relationship
+// and all it's nested relationships
+func (config *RelationshipConfig) GetInputNames() []string {
+	inputNames := []string{config.Input}
+	for _, relationship := range config.Relationships {
+		inputNames = append(inputNames, relationship.GetInputNames()...)
+	}
+
+	return inputNames
+}
+
+// Returns the names of the indexes used to match this
+// relationship and all it's nested relationships
+func (config *RelationshipConfig) GetIndexNames() []string {
+	indexNames := make([]string, 0, len(config.Match))
+	for _, match := range config.Match {
+		indexNames = append(indexNames, match.ChildIndex)
+	}
+	for _, relationship := range config.Relationships {
+		indexNames = append(indexNames, relationship.GetIndexNames()...)
+	}
+
+	return indexNames
+}
+
+// Returns the properties used by this relationship and all it's nested
+// relationships. The config path is the location of this relationship.
+func (config *RelationshipConfig) GetPropertyReferences(
+	parentInput string,
+	configPath string,
+) []input.PropertyReference {
+	references := make([]input.PropertyReference, 0)
+	for matchIndex, match := range config.Match {
+		matchPath := fmt.Sprintf("%v.match.%v", configPath, matchIndex)
+		references = append(references, input.PropertyReference{
+			Input:      parentInput,
+			Property:   match.ParentProperty,
+			ConfigPath: matchPath + ".parentProperty",
+		}, input.PropertyReference{
+			Input:      config.Input,
+			Property:   match.ChildProperty,
+			ConfigPath: matchPath + ".childProperty",
+		})
+	}
+	for sortIndex, sort := range config.Sort {
+		references = append(references, input.PropertyReference{
+			Input:      config.Input,
+			Property:   sort.Property,
+			ConfigPath: fmt.Sprintf("%v.sort.%v.property", configPath, sortIndex),
+		})
+	}
+	for _, relationshipName := range GetSortedNames(config.Relationships) {
+		references = append(references, config.Relationships[relationshipName].GetPropertyReferences(
+			config.Input,
+			configPath+".relationships."+relationshipName,
+		)...)
+	}
+
+	return references
+}
+
 func (config *RelationshipMatchConfig) Validate(
 	indexes map[string]index.Config,
 	log *logrus.Entry,
@@ -111,3 +173,14 @@ func (config *RelationshipMatchConfig) Validate(
 
 	return nil
 }
+
+// The relationships are sorted, to always report the errors in the same order
+func GetSortedNames(relationships map[string]*RelationshipConfig) []string {
+	names := make([]string, 0, len(relationships))
+	for name := range relationships {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -27,6 +27,10 @@ func (boolean *Boolean) Primitive() bool {
 	return boolean.config.Primitive()
 }
 
+func (boolean *Boolean) GetConfig() Config {
+	return boolean.config
+}
+
 func (boolean *Boolean) GetRegexpPattern() string {
 	values := make([]string, 0, len(boolean.config.TrueValues)+len(boolean.config.FalseValues))
 	for _, value := range boolean.config.TrueValues {
@@ -39,6 +43,10 @@ func (boolean *Boolean) GetRegexpPattern() string {
 }
 
 func (boolean *Boolean) Parse(value string) (interface{}, error) {
+	return parseNullable(boolean.config.NullValues, boolean.config.DefaultValue, value, boolean.parse)
+}
+
+func (boolean *Boolean) parse(value string) (interface{}, error) {
 	if util.IsInArray(value, boolean.config.TrueValues) {
 		return true, nil
 	}
@@ -2,15 +2,19 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"github.com/sirupsen/logrus"
 )
 
 type BooleanConfig struct {
-	Name        string   `yaml:"name"`
-	Type        string   `yaml:"type"`
-	TrueValues  []string `yaml:"trueValues"`
-	FalseValues []string `yaml:"falseValues"`
-	Logger      *logrus.Entry
+	Name         string   `yaml:"name"`
+	Type         string   `yaml:"type"`
+	TrueValues   []string `yaml:"trueValues"`
+	FalseValues  []string `yaml:"falseValues"`
+	NullValues   []string `yaml:"nullValues"`
+	Default      *string  `yaml:"default"`
+	Logger       *logrus.Entry
+	DefaultValue interface{}
 }
 
 func (config *BooleanConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
@@ -27,6 +31,12 @@ func (config *BooleanConfig) Validate(parsers map[string]Config, log *logrus.Ent
 		return errors.New("boolean.falseValues is required")
 	}
 
+	var err error
+	config.DefaultValue, err = parseDefaultValue(config.Default, NewBoolean(config).parse)
+	if err != nil {
+		return fmt.Errorf("boolean.default: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// Implemented by the parsers exposing their validated configuration
+type ConfigurableParser interface {
+	GetConfig() Config
+}
+
+var loggerType = reflect.TypeOf(&logrus.Entry{})
+
+// Returns a hash of the configuration of the parser, which changes when
+// the values it returns may change. The parsers which do not expose their
+// configuration are only identified by their name.
+func GetConfigHash(parser Parser) string {
+	hash := sha1.New()
+	if configurableParser, isConfigurable := parser.(ConfigurableParser); isConfigurable {
+		writeConfigValue(hash, reflect.ValueOf(configurableParser.GetConfig()))
+	} else {
+		fmt.Fprintf(hash, "%T:%q", parser, parser.Name())
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Writes the exported fields of the configuration,
+// ignoring the loggers and the pointers' addresses
+func writeConfigValue(writer io.Writer, value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			fmt.Fprint(writer, "nil")
+			return
+		}
+		writeConfigValue(writer, value.Elem())
+	case reflect.Struct:
+		fmt.Fprintf(writer, "%v{", value.Type())
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.PkgPath != "" || field.Type == loggerType {
+				continue
+			}
+			fmt.Fprintf(writer, "%v:", field.Name)
+			writeConfigValue(writer, value.Field(i))
+			fmt.Fprint(writer, ",")
+		}
+		fmt.Fprint(writer, "}")
+	case reflect.Slice, reflect.Array:
+		fmt.Fprint(writer, "[")
+		for i := 0; i < value.Len(); i++ {
+			writeConfigValue(writer, value.Index(i))
+			fmt.Fprint(writer, ",")
+		}
+		fmt.Fprint(writer, "]")
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		fmt.Fprint(writer, "{")
+		for _, key := range keys {
+			writeConfigValue(writer, key)
+			fmt.Fprint(writer, ":")
+			writeConfigValue(writer, value.MapIndex(key))
+			fmt.Fprint(writer, ",")
+		}
+		fmt.Fprint(writer, "}")
+	default:
+		fmt.Fprintf(writer, "%#v", value.Interface())
+	}
+}
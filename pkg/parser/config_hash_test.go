@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestGetConfigHash(t *testing.T) {
+	two := 2
+	three := 3
+	newDecimal := func(scale *int, logger *logrus.Entry) Parser {
+		return NewDecimal(&DecimalConfig{Name: "decimal", Scale: scale, Logger: logger})
+	}
+	logger := logrus.NewEntry(logrus.StandardLogger())
+
+	t.Run("same config", func(t *testing.T) {
+		otherTwo := 2
+		a := GetConfigHash(newDecimal(&two, logger))
+		b := GetConfigHash(newDecimal(&otherTwo, logrus.NewEntry(logrus.New())))
+		if a != b {
+			t.Fatalf("Expected '%v' to equal '%v'", a, b)
+		}
+	})
+	t.Run("different config", func(t *testing.T) {
+		a := GetConfigHash(newDecimal(&two, logger))
+		b := GetConfigHash(newDecimal(&three, logger))
+		if a == b {
+			t.Fatalf("Expected different hashes, got '%v'", a)
+		}
+	})
+	t.Run("different type", func(t *testing.T) {
+		a := GetConfigHash(NewInteger(&IntegerConfig{Name: "number"}))
+		b := GetConfigHash(NewFloat(&FloatConfig{Name: "number"}))
+		if a == b {
+			t.Fatalf("Expected different hashes, got '%v'", a)
+		}
+	})
+	t.Run("map", func(t *testing.T) {
+		values := map[string]interface{}{"a": int64(1), "b": "2", "c": nil}
+		a := GetConfigHash(NewMapping(&MappingConfig{Name: "mapping", Values: values}))
+		b := GetConfigHash(NewMapping(&MappingConfig{Name: "mapping", Values: values}))
+		if a != b {
+			t.Fatalf("Expected '%v' to equal '%v'", a, b)
+		}
+	})
+	t.Run("not configurable", func(t *testing.T) {
+		if GetConfigHash(NewMock()) == "" {
+			t.Fatalf("Expected a hash, got an empty string")
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The ISO-8601 layouts, which are always accepted
+// in addition to the ones of the configuration
+var isoDateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+const isoDateTimeRegexpPattern = "[0-9]{4}-[0-9]{2}-[0-9]{2}(?:T[0-9]{2}:[0-9]{2}:[0-9]{2}(?:\\.[0-9]+)?(?:Z|[+-][0-9]{2}:[0-9]{2})?)?"
+
+type DateTime struct {
+	config       *DateTimeConfig
+	layouts      []string
+	location     *time.Location
+	outputFormat string
+}
+
+func NewDateTime(
+	config *DateTimeConfig,
+) (*DateTime, error) {
+	layouts := make([]string, 0, len(config.Layouts))
+	for _, layout := range config.Layouts {
+		goLayout, err := config.getGoLayout(layout)
+		if err != nil {
+			return nil, err
+		}
+		layouts = append(layouts, goLayout)
+	}
+
+	location, err := LoadLocation(config.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	outputFormat, err := config.getGoOutputFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DateTime{
+		config:       config,
+		layouts:      layouts,
+		location:     location,
+		outputFormat: outputFormat,
+	}, nil
+}
+
+func (dateTime *DateTime) Name() string {
+	return dateTime.config.Name
+}
+
+func (dateTime *DateTime) Primitive() bool {
+	return dateTime.config.Primitive()
+}
+
+func (dateTime *DateTime) GetConfig() Config {
+	return dateTime.config
+}
+
+func (dateTime *DateTime) GetRegexpPattern() string {
+	patterns := make([]string, 0, len(dateTime.layouts)+1)
+	for _, layout := range dateTime.layouts {
+		patterns = append(patterns, "(?:"+getLayoutRegexpPattern(layout)+")")
+	}
+	patterns = append(patterns, "(?:"+isoDateTimeRegexpPattern+")")
+
+	return strings.Join(patterns, "|")
+}
+
+// The values are parsed with the configured layouts first, then
+// as ISO-8601. The values without time zone use the configured one.
+func (dateTime *DateTime) Parse(value string) (interface{}, error) {
+	return parseNullable(dateTime.config.NullValues, dateTime.config.DefaultValue, value, dateTime.parse)
+}
+
+func (dateTime *DateTime) parse(value string) (interface{}, error) {
+	for _, layouts := range [][]string{dateTime.layouts, isoDateTimeLayouts} {
+		for _, layout := range layouts {
+			parsedTime, err := time.ParseInLocation(layout, value, dateTime.location)
+			if err == nil {
+				return NewDateTimeValue(parsedTime, dateTime.location, dateTime.outputFormat), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("The value '%v' does not match any of the layouts of the datetime parser '%v'.", value, dateTime.config.Name)
+}
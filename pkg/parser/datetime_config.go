@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type DateTimeConfig struct {
+	Name         string   `yaml:"name"`
+	Type         string   `yaml:"type"`
+	Layouts      []string `yaml:"layouts"`
+	LayoutSyntax string   `yaml:"layoutSyntax"`
+	Timezone     string   `yaml:"timezone"`
+	OutputFormat string   `yaml:"outputFormat"`
+	NullValues   []string `yaml:"nullValues"`
+	Default      *string  `yaml:"default"`
+	Logger       *logrus.Entry
+	DefaultValue interface{}
+}
+
+func (config *DateTimeConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
+	config.Logger = log
+
+	if config.Name == "" {
+		return errors.New("datetime.name is required")
+	}
+
+	if config.LayoutSyntax == "" {
+		log.Debug("datetime.layoutSyntax not defined. Assuming 'go'")
+		config.LayoutSyntax = "go"
+	}
+	if config.LayoutSyntax != "go" && config.LayoutSyntax != "strftime" {
+		return fmt.Errorf("datetime.layoutSyntax: Unsupported value '%v'. The supported values are 'go' and 'strftime'.", config.LayoutSyntax)
+	}
+
+	if len(config.Layouts) == 0 {
+		log.Debug("datetime.layouts not defined. Only accepting ISO-8601 values")
+	}
+	for layoutIndex, layout := range config.Layouts {
+		if _, err := config.getGoLayout(layout); err != nil {
+			return fmt.Errorf("datetime.layouts[%v]: %w", layoutIndex, err)
+		}
+	}
+
+	if config.Timezone == "" {
+		log.Debug("datetime.timezone not defined. Assuming 'UTC'")
+		config.Timezone = "UTC"
+	}
+	if _, err := LoadLocation(config.Timezone); err != nil {
+		return fmt.Errorf("datetime.timezone: %w", err)
+	}
+
+	if config.OutputFormat == "" {
+		log.Debug("datetime.outputFormat not defined. Assuming ISO-8601")
+	} else if _, err := config.getGoLayout(config.OutputFormat); err != nil {
+		return fmt.Errorf("datetime.outputFormat: %w", err)
+	}
+
+	if config.Default != nil {
+		parser, err := NewDateTime(config)
+		if err != nil {
+			return fmt.Errorf("datetime.default: %w", err)
+		}
+		config.DefaultValue, err = parseDefaultValue(config.Default, parser.parse)
+		if err != nil {
+			return fmt.Errorf("datetime.default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (config *DateTimeConfig) GetName() string {
+	return config.Name
+}
+
+func (config *DateTimeConfig) Primitive() bool {
+	return true
+}
+
+// Converts the given layout or format to the Go syntax
+func (config *DateTimeConfig) getGoLayout(layout string) (string, error) {
+	if config.LayoutSyntax == "strftime" {
+		return convertStrftimeLayout(layout)
+	}
+
+	return layout, nil
+}
+
+func (config *DateTimeConfig) getGoOutputFormat() (string, error) {
+	if config.OutputFormat == "" {
+		return time.RFC3339Nano, nil
+	}
+
+	return config.getGoLayout(config.OutputFormat)
+}
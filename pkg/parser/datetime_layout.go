@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The Go layout equivalent of each supported strftime directive
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'f': "000000",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'z': "-0700",
+	'Z': "MST",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'R': "15:04",
+	'%': "%",
+}
+
+// The non-padded variants (%-m, %-d, %-I)
+var strftimeNonPaddedDirectives = map[byte]string{
+	'm': "1",
+	'd': "2",
+	'I': "3",
+}
+
+// Converts a strftime format (like "%Y-%m-%d") to a Go layout
+func convertStrftimeLayout(format string) (string, error) {
+	layout := strings.Builder{}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			layout.WriteByte(format[i])
+			continue
+		}
+
+		directives := strftimeDirectives
+		if i+1 < len(format) && format[i+1] == '-' {
+			directives = strftimeNonPaddedDirectives
+			i++
+		}
+		if i+1 >= len(format) {
+			return "", fmt.Errorf("Incomplete strftime directive at the end of '%v'.", format)
+		}
+
+		goLayout, exists := directives[format[i+1]]
+		if !exists {
+			return "", fmt.Errorf("Unsupported strftime directive '%v' in '%v'.", format[i:i+2], format)
+		}
+
+		layout.WriteString(goLayout)
+		i++
+	}
+
+	return layout.String(), nil
+}
+
+// The regexp pattern matching each element of the Go layouts, ordered
+// so that the longest elements are matched first
+var layoutElementPatterns = []struct {
+	element string
+	pattern string
+}{
+	{"January", "[A-Za-z]+"},
+	{"Jan", "[A-Za-z]{3}"},
+	{"Monday", "[A-Za-z]+"},
+	{"Mon", "[A-Za-z]{3}"},
+	{"MST", "[A-Za-z]+|[+-][0-9]{2,4}"},
+	{"2006", "[0-9]{4}"},
+	{"Z07:00:00", "Z|[+-][0-9]{2}:[0-9]{2}:[0-9]{2}"},
+	{"Z07:00", "Z|[+-][0-9]{2}:[0-9]{2}"},
+	{"Z0700", "Z|[+-][0-9]{4}"},
+	{"Z07", "Z|[+-][0-9]{2}"},
+	{"-07:00:00", "[+-][0-9]{2}:[0-9]{2}:[0-9]{2}"},
+	{"-07:00", "[+-][0-9]{2}:[0-9]{2}"},
+	{"-0700", "[+-][0-9]{4}"},
+	{"-07", "[+-][0-9]{2}"},
+	{"_2", "[ 0-9][0-9]"},
+	{"01", "[0-9]{2}"},
+	{"02", "[0-9]{2}"},
+	{"03", "[0-9]{2}"},
+	{"04", "[0-9]{2}"},
+	{"05", "[0-9]{2}"},
+	{"06", "[0-9]{2}"},
+	{"15", "[0-9]{2}"},
+	{"1", "[0-9]{1,2}"},
+	{"2", "[0-9]{1,2}"},
+	{"3", "[0-9]{1,2}"},
+	{"4", "[0-9]{1,2}"},
+	{"5", "[0-9]{1,2}"},
+	{"PM", "[AaPp][Mm]"},
+	{"pm", "[AaPp][Mm]"},
+}
+
+var fractionalSecondsRegexp = regexp.MustCompile(`^\.(0+|9+)`)
+
+// Returns a regexp pattern matching the values formatted with
+// the given Go layout. It does not contain any capturing group.
+func getLayoutRegexpPattern(layout string) string {
+	pattern := strings.Builder{}
+	for i := 0; i < len(layout); {
+		if fractional := fractionalSecondsRegexp.FindString(layout[i:]); fractional != "" {
+			if fractional[1] == '0' {
+				pattern.WriteString(fmt.Sprintf("\\.[0-9]{%v}", len(fractional)-1))
+			} else {
+				pattern.WriteString("(?:\\.[0-9]+)?")
+			}
+			i += len(fractional)
+			continue
+		}
+
+		matched := false
+		for _, elementPattern := range layoutElementPatterns {
+			if strings.HasPrefix(layout[i:], elementPattern.element) {
+				pattern.WriteString("(?:" + elementPattern.pattern + ")")
+				i += len(elementPattern.element)
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			pattern.WriteString(regexp.QuoteMeta(layout[i : i+1]))
+			i++
+		}
+	}
+
+	return pattern.String()
+}
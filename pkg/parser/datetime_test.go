@@ -0,0 +1,221 @@
+package parser
+
+import (
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestDateTimeParse(t *testing.T) {
+	paris, err := LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	for _, testCase := range []struct {
+		name   string
+		config *DateTimeConfig
+		value  string
+		expect time.Time
+		output string
+	}{
+		{
+			name:   "go layout",
+			config: &DateTimeConfig{Name: "datetime", Layouts: []string{"2/1/2006"}},
+			value:  "9/2/2021",
+			expect: time.Date(2021, 2, 9, 0, 0, 0, 0, time.UTC),
+			output: "2021-02-09T00:00:00Z",
+		},
+		{
+			name:   "strftime layout",
+			config: &DateTimeConfig{Name: "datetime", Layouts: []string{"%d/%m/%Y %H:%M"}, LayoutSyntax: "strftime", OutputFormat: "%Y%m%d"},
+			value:  "09/02/2021 13:45",
+			expect: time.Date(2021, 2, 9, 13, 45, 0, 0, time.UTC),
+			output: "20210209",
+		},
+		{
+			name:   "second layout",
+			config: &DateTimeConfig{Name: "datetime", Layouts: []string{"2006-01-02", "Jan 2, 2006"}},
+			value:  "Feb 9, 2021",
+			expect: time.Date(2021, 2, 9, 0, 0, 0, 0, time.UTC),
+			output: "2021-02-09T00:00:00Z",
+		},
+		{
+			name:   "timezone",
+			config: &DateTimeConfig{Name: "datetime", Layouts: []string{"2006-01-02 15:04"}, Timezone: "Europe/Paris"},
+			value:  "2021-02-09 13:45",
+			expect: time.Date(2021, 2, 9, 13, 45, 0, 0, paris),
+			output: "2021-02-09T13:45:00+01:00",
+		},
+		{
+			name:   "iso-8601",
+			config: &DateTimeConfig{Name: "datetime", Layouts: []string{"2/1/2006"}, Timezone: "Europe/Paris"},
+			value:  "2021-02-09T12:45:00Z",
+			expect: time.Date(2021, 2, 9, 13, 45, 0, 0, paris),
+			output: "2021-02-09T13:45:00+01:00",
+		},
+		{
+			name:   "iso-8601 date",
+			config: &DateTimeConfig{Name: "datetime", OutputFormat: "02/01/2006"},
+			value:  "2021-02-09",
+			expect: time.Date(2021, 2, 9, 0, 0, 0, 0, time.UTC),
+			output: "09/02/2021",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			dateTime := newParserForTests(t, testCase.config, nil)
+
+			got, err := dateTime.Parse(testCase.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+
+			value := got.(DateTimeValue)
+			if !value.Time().Equal(testCase.expect) {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, value.Time())
+			}
+			if got := value.String(); got != testCase.output {
+				t.Fatalf("Expected '%v', got '%v'", testCase.output, got)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		dateTime := newParserForTests(t, &DateTimeConfig{Name: "datetime", Layouts: []string{"2/1/2006"}}, nil)
+		if got, err := dateTime.Parse("2021/02/09"); err == nil {
+			t.Fatalf("Expected an error, got '%v'", got)
+		}
+	})
+	t.Run("comparable", func(t *testing.T) {
+		dateTime := newParserForTests(t, &DateTimeConfig{Name: "datetime", Layouts: []string{"2/1/2006 15:04 -0700"}}, nil)
+		a, err := dateTime.Parse("9/2/2021 14:45 +0100")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		b, err := dateTime.Parse("2021-02-09T13:45:00Z")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if a != b {
+			t.Fatalf("Expected '%#v' to be equal to '%#v'", a, b)
+		}
+	})
+	t.Run("json", func(t *testing.T) {
+		dateTime := newParserForTests(t, &DateTimeConfig{Name: "datetime", OutputFormat: "02/01/2006"}, nil)
+		value, err := dateTime.Parse("2021-02-09")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		data, err := json.Marshal(map[string]interface{}{"date": value})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := `{"date":"09/02/2021"}`, string(data); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
+
+func TestDateTimeGetRegexpPattern(t *testing.T) {
+	dateTime := newParserForTests(t, &DateTimeConfig{
+		Name:    "datetime",
+		Layouts: []string{"2/1/2006", "Jan _2 15:04:05.000 -07:00"},
+	}, nil)
+	regexp, err := regexp.Compile("^(" + dateTime.GetRegexpPattern() + ")$")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	for value, expect := range map[string]bool{
+		"9/2/2021":                         true,
+		"09/12/2021":                       true,
+		"Feb  9 13:45:00.123 +01:00":       true,
+		"2021-02-09":                       true,
+		"2021-02-09T13:45:00":              true,
+		"2021-02-09T13:45:00.123456+01:00": true,
+		"2021-02-09T13:45:00Z":             true,
+		"Feb 9 13:45:00 +01:00":            false,
+		"2021/02/09":                       false,
+		"foo":                              false,
+	} {
+		t.Run(value, func(t *testing.T) {
+			if got := regexp.MatchString(value); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		})
+	}
+	t.Run("no capturing group", func(t *testing.T) {
+		if expect, got := 1, regexp.NumSubexp(); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
+
+func TestConvertStrftimeLayout(t *testing.T) {
+	for format, expect := range map[string]string{
+		"%Y-%m-%d":          "2006-01-02",
+		"%-d/%-m/%y":        "2/1/06",
+		"%a %e %b %H:%M:%S": "Mon _2 Jan 15:04:05",
+		"%FT%T%z":           "2006-01-02T15:04:05-0700",
+		"%H:%M %%":          "15:04 %",
+	} {
+		t.Run(format, func(t *testing.T) {
+			got, err := convertStrftimeLayout(format)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		})
+	}
+	for _, format := range []string{"%Q", "%Y-%"} {
+		t.Run(format, func(t *testing.T) {
+			if got, err := convertStrftimeLayout(format); err == nil {
+				t.Fatalf("Expected an error, got '%v'", got)
+			}
+		})
+	}
+}
+
+func TestDateTimeConfigValidate(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	for _, testCase := range []struct {
+		name   string
+		config *DateTimeConfig
+	}{
+		{"syntax", &DateTimeConfig{Name: "a", LayoutSyntax: "wrong"}},
+		{"layout", &DateTimeConfig{Name: "a", LayoutSyntax: "strftime", Layouts: []string{"%Q"}}},
+		{"timezone", &DateTimeConfig{Name: "a", Timezone: "Wrong/Zone"}},
+		{"output format", &DateTimeConfig{Name: "a", LayoutSyntax: "strftime", OutputFormat: "%Q"}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.config.Validate(map[string]Config{}, log); err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestDateTimeValueKey(t *testing.T) {
+	paris, err := LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	instant := time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC)
+	utcValue := NewDateTimeValue(instant, time.UTC, "%Y-%m-%d")
+	parisValue := NewDateTimeValue(instant, paris, "%H:%M")
+	if utcValue == parisValue {
+		t.Fatalf("Expected the values with different options to be different, got %v", utcValue)
+	}
+	if utcValue.Key() != parisValue.Key() {
+		t.Fatalf("Expected the keys to be equal, got %v and %v", utcValue.Key(), parisValue.Key())
+	}
+	if got := NewDateTimeValue(instant.Add(time.Nanosecond), paris, "%H:%M").Key(); got == utcValue.Key() {
+		t.Fatalf("Expected the keys of different instants to be different, got %v", got)
+	}
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// The values returned by the datetime parser. They can be compared with
+// Compare, and are serialized using the output format of their parser.
+// Two values are only equal (with ==) when they also have the same output
+// format and location. The values returned by Key are equal when they
+// represent the same instant.
+type DateTimeValue struct {
+	time   time.Time
+	format string
+}
+
+// The location must be loaded with LoadLocation
+func NewDateTimeValue(value time.Time, location *time.Location, format string) DateTimeValue {
+	// Rebuilding the time from the unix time removes any other internal
+	// state (like the monotonic clock), which would make it unequal
+	return DateTimeValue{
+		time:   time.Unix(value.Unix(), int64(value.Nanosecond())).In(location),
+		format: format,
+	}
+}
+
+// Returns the same instant, in UTC and without output format, which
+// identifies the value whatever the options of its parser. It is the
+// value to use as map key.
+func (value DateTimeValue) Key() DateTimeValue {
+	return NewDateTimeValue(value.time, time.UTC, "")
+}
+
+func (value DateTimeValue) Time() time.Time {
+	return value.time
+}
+
+// The output format, using the Go syntax
+func (value DateTimeValue) Format() string {
+	return value.format
+}
+
+func (value DateTimeValue) String() string {
+	return value.time.Format(value.format)
+}
+
+func (value DateTimeValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(value.String())
+}
+
+// Stores the value in databases as a fixed-length UTC
+// string, which keeps the same order when sorted
+func (value DateTimeValue) Value() (driver.Value, error) {
+	return value.time.UTC().Format("2006-01-02T15:04:05.000000000Z"), nil
+}
+
+var locations = map[string]*time.Location{}
+var locationsLock = sync.Mutex{}
+
+// Loads the time zone with the given name, as time.LoadLocation does. The
+// same instance is always returned for a given name, which allows to compare
+// the values using it with ==, for example when they are used as map keys.
+func LoadLocation(name string) (*time.Location, error) {
+	locationsLock.Lock()
+	defer locationsLock.Unlock()
+
+	if location, exists := locations[name]; exists {
+		return location, nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations[name] = location
+
+	return location, nil
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/util"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+type Decimal struct {
+	config *DecimalConfig
+}
+
+func NewDecimal(
+	config *DecimalConfig,
+) *Decimal {
+	return &Decimal{
+		config: config,
+	}
+}
+
+func (decimal *Decimal) Name() string {
+	return decimal.config.Name
+}
+
+func (decimal *Decimal) Primitive() bool {
+	return decimal.config.Primitive()
+}
+
+func (decimal *Decimal) GetConfig() Config {
+	return decimal.config
+}
+
+func (decimal *Decimal) GetRegexpPattern() string {
+	separator := regexp.QuoteMeta(decimal.config.DecimalSeparator)
+	ignore := regexp.QuoteMeta(decimal.config.IgnoreCharacters)
+	ignoreBegin := ""
+	if ignore != "" {
+		ignoreBegin = "[" + ignore + "]*"
+	}
+	return ignoreBegin + "[-+]?[0-9" + ignore + "]+(?:" + separator + "[0-9" + ignore + "]+)?"
+}
+
+var decimalParseRegexp = regexp.MustCompile(`^([-+]?)([0-9]+)(?:\.([0-9]+))?$`)
+
+func (decimal *Decimal) Parse(value string) (interface{}, error) {
+	return parseNullable(decimal.config.NullValues, decimal.config.DefaultValue, value, decimal.parse)
+}
+
+func (decimal *Decimal) parse(value string) (interface{}, error) {
+	cleanedValue := util.RemoveCharacters(value, decimal.config.IgnoreCharacters)
+	if decimal.config.DecimalSeparator != "." {
+		cleanedValue = strings.ReplaceAll(cleanedValue, decimal.config.DecimalSeparator, ".")
+	}
+
+	match := decimalParseRegexp.FindStringSubmatch(cleanedValue)
+	if match == nil {
+		return nil, fmt.Errorf("The value '%v' is not a valid decimal number.", value)
+	}
+
+	scale := -1
+	sign, integerPart, fractionalPart := match[1], match[2], match[3]
+	if decimal.config.Scale != nil {
+		scale = *decimal.config.Scale
+		if len(fractionalPart) > scale {
+			integerPart, fractionalPart = roundDecimal(sign == "-", integerPart, fractionalPart, scale, decimal.config.Rounding)
+		}
+	}
+
+	number := integerPart
+	if fractionalPart != "" {
+		number += "." + fractionalPart
+	}
+	if sign == "-" {
+		number = "-" + number
+	}
+
+	return NewDecimalValue(number, scale, decimal.config.JsonFormat == "string")
+}
+
+// Decides if the absolute value of a number must be rounded up, depending
+// on it's sign, the comparison of the removed digits to a half (-1, 0 or 1)
+// and whether the last kept digit is odd
+var decimalRoundingModes = map[string]func(negative bool, comparedToHalf int, odd bool) bool{
+	"halfUp": func(negative bool, comparedToHalf int, odd bool) bool {
+		return comparedToHalf >= 0
+	},
+	"halfEven": func(negative bool, comparedToHalf int, odd bool) bool {
+		return comparedToHalf > 0 || (comparedToHalf == 0 && odd)
+	},
+	"up": func(negative bool, comparedToHalf int, odd bool) bool {
+		return true
+	},
+	"down": func(negative bool, comparedToHalf int, odd bool) bool {
+		return false
+	},
+	"ceiling": func(negative bool, comparedToHalf int, odd bool) bool {
+		return !negative
+	},
+	"floor": func(negative bool, comparedToHalf int, odd bool) bool {
+		return negative
+	},
+}
+
+// Rounds the absolute value of a number, given by it's integer and
+// fractional parts, to the given number of decimals
+func roundDecimal(negative bool, integerPart string, fractionalPart string, scale int, rounding string) (string, string) {
+	removed := strings.TrimRight(fractionalPart[scale:], "0")
+	unscaled, _ := new(big.Int).SetString(integerPart+fractionalPart[:scale], 10)
+	if removed != "" {
+		// The removed digits are compared to 0.5
+		comparedToHalf := strings.Compare(removed, "5")
+		odd := unscaled.Bit(0) == 1
+		if decimalRoundingModes[rounding](negative, comparedToHalf, odd) {
+			unscaled.Add(unscaled, big.NewInt(1))
+		}
+	}
+
+	digits := unscaled.String()
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	return digits[:len(digits)-scale], digits[len(digits)-scale:]
+}
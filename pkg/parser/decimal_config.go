@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+)
+
+type DecimalConfig struct {
+	Name             string   `yaml:"name"`
+	Type             string   `yaml:"type"`
+	DecimalSeparator string   `yaml:"decimalSeparator"`
+	IgnoreCharacters string   `yaml:"ignoreCharacters"`
+	Scale            *int     `yaml:"scale"`
+	Rounding         string   `yaml:"rounding"`
+	JsonFormat       string   `yaml:"jsonFormat"`
+	NullValues       []string `yaml:"nullValues"`
+	Default          *string  `yaml:"default"`
+	Logger           *logrus.Entry
+	DefaultValue     interface{}
+}
+
+func (config *DecimalConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
+	config.Logger = log
+
+	if config.Name == "" {
+		return errors.New("decimal.name is required")
+	}
+
+	if config.DecimalSeparator == "" {
+		log.Debug("decimal.decimalSeparator not defined. Assuming '.'")
+		config.DecimalSeparator = "."
+	}
+
+	if config.Scale == nil {
+		log.Debug("decimal.scale not defined. The values will keep all their decimals")
+	} else if *config.Scale < 0 {
+		return errors.New("decimal.scale must be positive or zero")
+	}
+
+	if config.Rounding == "" {
+		log.Debug("decimal.rounding not defined. Assuming 'halfUp'")
+		config.Rounding = "halfUp"
+	}
+	if _, exists := decimalRoundingModes[config.Rounding]; !exists {
+		return fmt.Errorf("decimal.rounding: Unsupported value '%v'. The supported values are 'halfUp', 'halfEven', 'up', 'down', 'ceiling' and 'floor'.", config.Rounding)
+	}
+
+	if config.JsonFormat == "" {
+		log.Debug("decimal.jsonFormat not defined. Assuming 'number'")
+		config.JsonFormat = "number"
+	}
+	if config.JsonFormat != "number" && config.JsonFormat != "string" {
+		return fmt.Errorf("decimal.jsonFormat: Unsupported value '%v'. The supported values are 'number' and 'string'.", config.JsonFormat)
+	}
+
+	var err error
+	config.DefaultValue, err = parseDefaultValue(config.Default, NewDecimal(config).parse)
+	if err != nil {
+		return fmt.Errorf("decimal.default: %w", err)
+	}
+
+	return nil
+}
+
+func (config *DecimalConfig) GetName() string {
+	return config.Name
+}
+
+func (config *DecimalConfig) Primitive() bool {
+	return true
+}
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"math/big"
+	"regexp"
+	"testing"
+)
+
+func TestDecimalParse(t *testing.T) {
+	scale := func(scale int) *int {
+		return &scale
+	}
+
+	for _, testCase := range []struct {
+		name   string
+		config *DecimalConfig
+		value  string
+		expect string
+	}{
+		{"integer", &DecimalConfig{}, "42", "42"},
+		{"decimals", &DecimalConfig{}, "0.30", "0.3"},
+		{"negative", &DecimalConfig{}, "-0012.50", "-12.5"},
+		{"positive", &DecimalConfig{}, "+1.5", "1.5"},
+		{"big", &DecimalConfig{}, "123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+		{"separator", &DecimalConfig{DecimalSeparator: ",", IgnoreCharacters: ". "}, "1.234.567,89", "1234567.89"},
+		{"scale", &DecimalConfig{Scale: scale(2)}, "1.5", "1.50"},
+		{"scale zero", &DecimalConfig{Scale: scale(0)}, "1.5", "2"},
+		{"half up", &DecimalConfig{Scale: scale(2)}, "1.005", "1.01"},
+		{"half up negative", &DecimalConfig{Scale: scale(2)}, "-1.005", "-1.01"},
+		{"half up below", &DecimalConfig{Scale: scale(2)}, "1.00499", "1.00"},
+		{"half even", &DecimalConfig{Scale: scale(1), Rounding: "halfEven"}, "0.25", "0.2"},
+		{"half even odd", &DecimalConfig{Scale: scale(1), Rounding: "halfEven"}, "0.35", "0.4"},
+		{"half even above", &DecimalConfig{Scale: scale(1), Rounding: "halfEven"}, "0.2501", "0.3"},
+		{"up", &DecimalConfig{Scale: scale(1), Rounding: "up"}, "-0.21", "-0.3"},
+		{"down", &DecimalConfig{Scale: scale(1), Rounding: "down"}, "-0.29", "-0.2"},
+		{"ceiling", &DecimalConfig{Scale: scale(1), Rounding: "ceiling"}, "0.21", "0.3"},
+		{"ceiling negative", &DecimalConfig{Scale: scale(1), Rounding: "ceiling"}, "-0.29", "-0.2"},
+		{"floor", &DecimalConfig{Scale: scale(1), Rounding: "floor"}, "0.29", "0.2"},
+		{"floor negative", &DecimalConfig{Scale: scale(1), Rounding: "floor"}, "-0.21", "-0.3"},
+		{"carry", &DecimalConfig{Scale: scale(2)}, "9.999", "10.00"},
+		{"negative zero", &DecimalConfig{Scale: scale(1), Rounding: "down"}, "-0.01", "0.0"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.config.Name = "decimal"
+			decimal := newParserForTests(t, testCase.config, nil)
+			got, err := decimal.Parse(testCase.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got := got.(DecimalValue).String(); got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		decimal := newParserForTests(t, &DecimalConfig{Name: "decimal"}, nil)
+		for _, value := range []string{"", "abc", "1.2.3", "1e5", ".5", "1,5"} {
+			if got, err := decimal.Parse(value); err == nil {
+				t.Fatalf("Expected an error for '%v', got '%v'", value, got)
+			}
+		}
+	})
+	t.Run("comparable", func(t *testing.T) {
+		decimal := newParserForTests(t, &DecimalConfig{Name: "decimal"}, nil)
+		a, err := decimal.Parse("1.50")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		b, err := decimal.Parse("01.5")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if a != b {
+			t.Fatalf("Expected '%#v' to be equal to '%#v'", a, b)
+		}
+	})
+	t.Run("exact", func(t *testing.T) {
+		decimal := newParserForTests(t, &DecimalConfig{Name: "decimal"}, nil)
+		a, _ := decimal.Parse("0.1")
+		b, _ := decimal.Parse("0.2")
+		sum := a.(DecimalValue).Rat()
+		sum.Add(sum, b.(DecimalValue).Rat())
+		if expect := big.NewRat(3, 10); sum.Cmp(expect) != 0 {
+			t.Fatalf("Expected '%v', got '%v'", expect, sum)
+		}
+	})
+	t.Run("json", func(t *testing.T) {
+		for jsonFormat, expect := range map[string]string{
+			"number": `{"price":12.50}`,
+			"string": `{"price":"12.50"}`,
+		} {
+			decimal := newParserForTests(t, &DecimalConfig{Name: "decimal", Scale: scale(2), JsonFormat: jsonFormat}, nil)
+			value, err := decimal.Parse("12.5")
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+
+			data, err := json.Marshal(map[string]interface{}{"price": value})
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got := string(data); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		}
+	})
+}
+
+func TestDecimalGetRegexpPattern(t *testing.T) {
+	decimal := newParserForTests(t, &DecimalConfig{Name: "decimal", DecimalSeparator: ",", IgnoreCharacters: "."}, nil)
+	patternRegexp, err := regexp.Compile("^(" + decimal.GetRegexpPattern() + ")$")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	for value, expect := range map[string]bool{
+		"1":           true,
+		"-1,5":        true,
+		"1.234.567,8": true,
+		"1,":          false,
+		"abc":         false,
+	} {
+		t.Run(value, func(t *testing.T) {
+			if got := patternRegexp.MatchString(value); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		})
+	}
+	t.Run("no capturing group", func(t *testing.T) {
+		if expect, got := 1, patternRegexp.NumSubexp(); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
+
+func TestDecimalConfigValidate(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	negativeScale := -1
+	for _, testCase := range []struct {
+		name   string
+		config *DecimalConfig
+	}{
+		{"scale", &DecimalConfig{Name: "a", Scale: &negativeScale}},
+		{"rounding", &DecimalConfig{Name: "a", Rounding: "wrong"}},
+		{"json format", &DecimalConfig{Name: "a", JsonFormat: "wrong"}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.config.Validate(map[string]Config{}, log); err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestDecimalValueKey(t *testing.T) {
+	stringValue, err := NewDecimalValue("1.5", 2, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	numberValue, err := NewDecimalValue("1.50", -1, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if stringValue == numberValue {
+		t.Fatalf("Expected the values with different options to be different, got %v", stringValue)
+	}
+	if stringValue.Key() != numberValue.Key() {
+		t.Fatalf("Expected the keys to be equal, got %v and %v", stringValue.Key(), numberValue.Key())
+	}
+
+	otherValue, err := NewDecimalValue("1.51", 2, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if otherValue.Key() == stringValue.Key() {
+		t.Fatalf("Expected the keys of different numbers to be different, got %v", otherValue.Key())
+	}
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// The values returned by the decimal parser. They are exact, unlike
+// float64 values. Two values representing the same number have the
+// same Key, whatever the options of their parsers.
+type DecimalValue struct {
+	// The number, without leading zeros or trailing fractional zeros
+	value        string
+	scale        int
+	jsonAsString bool
+}
+
+var decimalValueRegexp = regexp.MustCompile(`^(-?)([0-9]+)(?:\.([0-9]+))?$`)
+
+// Creates a value from a number written like "-123.45". It is written
+// with the given number of decimals (or the minimal number of decimals
+// if the scale is negative), and cannot have more decimals than the scale.
+func NewDecimalValue(value string, scale int, jsonAsString bool) (DecimalValue, error) {
+	match := decimalValueRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return DecimalValue{}, fmt.Errorf("The value '%v' is not a valid decimal number.", value)
+	}
+
+	sign := match[1]
+	integerPart := strings.TrimLeft(match[2], "0")
+	fractionalPart := strings.TrimRight(match[3], "0")
+	if integerPart == "" {
+		integerPart = "0"
+	}
+	if scale >= 0 && len(fractionalPart) > scale {
+		return DecimalValue{}, fmt.Errorf("The value '%v' has more than %v decimals.", value, scale)
+	}
+
+	canonical := integerPart
+	if fractionalPart != "" {
+		canonical += "." + fractionalPart
+	}
+	if canonical != "0" {
+		canonical = sign + canonical
+	}
+
+	return DecimalValue{
+		value:        canonical,
+		scale:        scale,
+		jsonAsString: jsonAsString,
+	}, nil
+}
+
+// Returns the same number, with the minimal number of decimals and
+// written as a JSON number, which identifies the value whatever the
+// options of its parser. It is the value to use as map key.
+func (value DecimalValue) Key() DecimalValue {
+	return DecimalValue{
+		value:        value.value,
+		scale:        -1,
+		jsonAsString: false,
+	}
+}
+
+// The number of decimals with which the value is written,
+// or a negative number if it uses the minimal number of decimals
+func (value DecimalValue) Scale() int {
+	return value.scale
+}
+
+func (value DecimalValue) JsonAsString() bool {
+	return value.jsonAsString
+}
+
+func (value DecimalValue) Rat() *big.Rat {
+	rat, _ := new(big.Rat).SetString(value.value)
+	return rat
+}
+
+func (value DecimalValue) String() string {
+	if value.scale <= 0 {
+		return value.value
+	}
+
+	decimals := 0
+	if separatorIndex := strings.IndexByte(value.value, '.'); separatorIndex >= 0 {
+		decimals = len(value.value) - separatorIndex - 1
+	}
+
+	result := value.value
+	if decimals == 0 {
+		result += "."
+	}
+	return result + strings.Repeat("0", value.scale-decimals)
+}
+
+func (value DecimalValue) MarshalJSON() ([]byte, error) {
+	if value.jsonAsString {
+		return json.Marshal(value.String())
+	}
+
+	return []byte(value.String()), nil
+}
+
+// Stores the value in databases in it's normalized form,
+// so that equal numbers are stored with the same value
+func (value DecimalValue) Value() (driver.Value, error) {
+	return value.value, nil
+}
@@ -27,6 +27,10 @@ func (float *Float) Primitive() bool {
 	return float.config.Primitive()
 }
 
+func (float *Float) GetConfig() Config {
+	return float.config
+}
+
 func (float *Float) GetRegexpPattern() string {
 	separator := regexp.QuoteMeta(float.config.DecimalSeparator)
 	ignore := regexp.QuoteMeta(float.config.IgnoreCharacters)
@@ -38,6 +42,10 @@ func (float *Float) GetRegexpPattern() string {
 }
 
 func (float *Float) Parse(value string) (interface{}, error) {
+	return parseNullable(float.config.NullValues, float.config.DefaultValue, value, float.parse)
+}
+
+func (float *Float) parse(value string) (interface{}, error) {
 	cleanedValue := util.RemoveCharacters(value, float.config.IgnoreCharacters)
 	if float.config.DecimalSeparator != "." {
 		cleanedValue = strings.ReplaceAll(cleanedValue, float.config.DecimalSeparator, ".")
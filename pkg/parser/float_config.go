@@ -2,15 +2,19 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"github.com/sirupsen/logrus"
 )
 
 type FloatConfig struct {
-	Name             string `yaml:"name"`
-	Type             string `yaml:"type"`
-	DecimalSeparator string `yaml:"decimalSeparator"`
-	IgnoreCharacters string `yaml:"ignoreCharacters"`
+	Name             string   `yaml:"name"`
+	Type             string   `yaml:"type"`
+	DecimalSeparator string   `yaml:"decimalSeparator"`
+	IgnoreCharacters string   `yaml:"ignoreCharacters"`
+	NullValues       []string `yaml:"nullValues"`
+	Default          *string  `yaml:"default"`
 	Logger           *logrus.Entry
+	DefaultValue     interface{}
 }
 
 func (config *FloatConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
@@ -24,6 +28,12 @@ func (config *FloatConfig) Validate(parsers map[string]Config, log *logrus.Entry
 		return errors.New("float.decimalSeparator is required")
 	}
 
+	var err error
+	config.DefaultValue, err = parseDefaultValue(config.Default, NewFloat(config).parse)
+	if err != nil {
+		return fmt.Errorf("float.default: %w", err)
+	}
+
 	return nil
 }
 
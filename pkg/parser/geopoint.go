@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type GeoPoint struct {
+	config *GeoPointConfig
+}
+
+func NewGeoPoint(
+	config *GeoPointConfig,
+) *GeoPoint {
+	return &GeoPoint{
+		config: config,
+	}
+}
+
+func (geoPoint *GeoPoint) Name() string {
+	return geoPoint.config.Name
+}
+
+func (geoPoint *GeoPoint) Primitive() bool {
+	return geoPoint.config.Primitive()
+}
+
+func (geoPoint *GeoPoint) GetConfig() Config {
+	return geoPoint.config
+}
+
+func (geoPoint *GeoPoint) GetRegexpPattern() string {
+	coordinate := `[-+]?[0-9]+(?:\.[0-9]+)?`
+	return coordinate + `\s*` + regexp.QuoteMeta(geoPoint.config.Separator) + `\s*` + coordinate
+}
+
+func (geoPoint *GeoPoint) Parse(value string) (interface{}, error) {
+	return parseNullable(geoPoint.config.NullValues, geoPoint.config.DefaultValue, value, geoPoint.parse)
+}
+
+func (geoPoint *GeoPoint) parse(value string) (interface{}, error) {
+	parts := strings.Split(value, geoPoint.config.Separator)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("The value '%v' is not a valid geographic point.", value)
+	}
+
+	coordinates := make([]float64, 2)
+	for i, part := range parts {
+		coordinate, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("The value '%v' is not a valid geographic point: %w", value, err)
+		}
+		coordinates[i] = coordinate
+	}
+
+	if geoPoint.config.Order == "lonlat" {
+		return NewGeoPointValue(coordinates[1], coordinates[0])
+	}
+
+	return NewGeoPointValue(coordinates[0], coordinates[1])
+}
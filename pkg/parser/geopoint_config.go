@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+)
+
+type GeoPointConfig struct {
+	Name         string   `yaml:"name"`
+	Type         string   `yaml:"type"`
+	Separator    string   `yaml:"separator"`
+	Order        string   `yaml:"order"`
+	NullValues   []string `yaml:"nullValues"`
+	Default      *string  `yaml:"default"`
+	Logger       *logrus.Entry
+	DefaultValue interface{}
+}
+
+func (config *GeoPointConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
+	config.Logger = log
+
+	if config.Name == "" {
+		return errors.New("geopoint.name is required")
+	}
+
+	if config.Separator == "" {
+		log.Debug("geopoint.separator not defined. Assuming ','")
+		config.Separator = ","
+	}
+
+	if config.Order == "" {
+		log.Debug("geopoint.order not defined. Assuming 'latlon'")
+		config.Order = "latlon"
+	}
+	if config.Order != "latlon" && config.Order != "lonlat" {
+		return fmt.Errorf("geopoint.order: Unsupported value '%v'. The supported values are 'latlon' and 'lonlat'.", config.Order)
+	}
+
+	var err error
+	config.DefaultValue, err = parseDefaultValue(config.Default, NewGeoPoint(config).parse)
+	if err != nil {
+		return fmt.Errorf("geopoint.default: %w", err)
+	}
+
+	return nil
+}
+
+func (config *GeoPointConfig) GetName() string {
+	return config.Name
+}
+
+func (config *GeoPointConfig) Primitive() bool {
+	return true
+}
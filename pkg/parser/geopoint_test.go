@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+func TestGeoPointParse(t *testing.T) {
+	for _, testCase := range []struct {
+		name      string
+		config    *GeoPointConfig
+		value     string
+		latitude  float64
+		longitude float64
+	}{
+		{"normal", &GeoPointConfig{}, "48.8566,2.3522", 48.8566, 2.3522},
+		{"spaces", &GeoPointConfig{}, "-33.8688, 151.2093", -33.8688, 151.2093},
+		{"integers", &GeoPointConfig{}, "10,-20", 10, -20},
+		{"separator", &GeoPointConfig{Separator: ";"}, "48.8566;2.3522", 48.8566, 2.3522},
+		{"lonlat", &GeoPointConfig{Order: "lonlat"}, "2.3522,48.8566", 48.8566, 2.3522},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.config.Name = "geopoint"
+			geoPoint := newParserForTests(t, testCase.config, nil)
+			got, err := geoPoint.Parse(testCase.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if expect := (GeoPointValue{testCase.latitude, testCase.longitude}); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		geoPoint := newParserForTests(t, &GeoPointConfig{Name: "geopoint"}, nil)
+		for _, value := range []string{"", "abc", "48.8566", "1,2,3", "91,0", "0,181", "a,b"} {
+			if got, err := geoPoint.Parse(value); err == nil {
+				t.Fatalf("Expected an error for '%v', got '%v'", value, got)
+			}
+		}
+	})
+}
+
+func TestGeoPointGetRegexpPattern(t *testing.T) {
+	geoPoint := newParserForTests(t, &GeoPointConfig{Name: "geopoint"}, nil)
+	patternRegexp := regexp.MustCompile("^" + geoPoint.GetRegexpPattern() + "$")
+	for value, expect := range map[string]bool{
+		"48.8566,2.3522":     true,
+		"-33.8688, 151.2093": true,
+		"10,-20":             true,
+		"48.8566":            false,
+		"a,b":                false,
+	} {
+		if got := patternRegexp.MatchString(value); got != expect {
+			t.Fatalf("Expected '%v' for '%v', got '%v'", expect, value, got)
+		}
+	}
+}
+
+func TestGeoPointValue(t *testing.T) {
+	paris := GeoPointValue{48.8566, 2.3522}
+	london := GeoPointValue{51.5074, -0.1278}
+
+	t.Run("distance", func(t *testing.T) {
+		if got := paris.DistanceTo(london); got < 343000 || got > 344000 {
+			t.Fatalf("Expected about 343.5km, got '%v'", got)
+		}
+		if got := paris.DistanceTo(paris); got != 0 {
+			t.Fatalf("Expected 0, got '%v'", got)
+		}
+	})
+	t.Run("json", func(t *testing.T) {
+		got, err := json.Marshal(paris)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect := `{"latitude":48.8566,"longitude":2.3522}`; string(got) != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, string(got))
+		}
+	})
+	t.Run("string", func(t *testing.T) {
+		if expect, got := "48.8566,2.3522", paris.String(); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
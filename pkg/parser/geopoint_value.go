@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// The mean radius of the earth, in meters
+const EarthRadius = 6371008.8
+
+// The values returned by the geopoint parser. Two values
+// representing the same point are equal (with ==).
+type GeoPointValue struct {
+	latitude  float64
+	longitude float64
+}
+
+// The latitude must be between -90 and 90,
+// and the longitude between -180 and 180.
+func NewGeoPointValue(latitude float64, longitude float64) (GeoPointValue, error) {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return GeoPointValue{}, fmt.Errorf("The latitude '%v' is not between -90 and 90.", latitude)
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return GeoPointValue{}, fmt.Errorf("The longitude '%v' is not between -180 and 180.", longitude)
+	}
+
+	return GeoPointValue{
+		latitude:  latitude,
+		longitude: longitude,
+	}, nil
+}
+
+func (value GeoPointValue) Latitude() float64 {
+	return value.latitude
+}
+
+func (value GeoPointValue) Longitude() float64 {
+	return value.longitude
+}
+
+// Returns the great-circle distance between both points, in meters,
+// using the haversine formula and a spherical earth.
+func (value GeoPointValue) DistanceTo(other GeoPointValue) float64 {
+	latitude1 := value.latitude * math.Pi / 180
+	latitude2 := other.latitude * math.Pi / 180
+	deltaLatitude := latitude2 - latitude1
+	deltaLongitude := (other.longitude - value.longitude) * math.Pi / 180
+
+	a := math.Sin(deltaLatitude/2)*math.Sin(deltaLatitude/2) +
+		math.Cos(latitude1)*math.Cos(latitude2)*math.Sin(deltaLongitude/2)*math.Sin(deltaLongitude/2)
+
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func (value GeoPointValue) String() string {
+	return strconv.FormatFloat(value.latitude, 'f', -1, 64) + "," + strconv.FormatFloat(value.longitude, 'f', -1, 64)
+}
+
+func (value GeoPointValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]float64{
+		"latitude":  value.latitude,
+		"longitude": value.longitude,
+	})
+}
+
+// Stores the value in databases as a "latitude,longitude" string
+func (value GeoPointValue) Value() (driver.Value, error) {
+	return value.String(), nil
+}
@@ -26,6 +26,10 @@ func (integer *Integer) Primitive() bool {
 	return integer.config.Primitive()
 }
 
+func (integer *Integer) GetConfig() Config {
+	return integer.config
+}
+
 func (integer *Integer) GetRegexpPattern() string {
 	ignore := regexp.QuoteMeta(integer.config.IgnoreCharacters)
 	ignoreBegin := ""
@@ -36,6 +40,10 @@ func (integer *Integer) GetRegexpPattern() string {
 }
 
 func (integer *Integer) Parse(value string) (interface{}, error) {
+	return parseNullable(integer.config.NullValues, integer.config.DefaultValue, value, integer.parse)
+}
+
+func (integer *Integer) parse(value string) (interface{}, error) {
 	cleanedValue := util.RemoveCharacters(value, integer.config.IgnoreCharacters)
 	integerValue, err := strconv.Atoi(cleanedValue)
 	return int64(integerValue), err
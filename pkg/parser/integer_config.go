@@ -2,14 +2,18 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"github.com/sirupsen/logrus"
 )
 
 type IntegerConfig struct {
-	Name             string `yaml:"name"`
-	Type             string `yaml:"type"`
-	IgnoreCharacters string `yaml:"ignoreCharacters"`
+	Name             string   `yaml:"name"`
+	Type             string   `yaml:"type"`
+	IgnoreCharacters string   `yaml:"ignoreCharacters"`
+	NullValues       []string `yaml:"nullValues"`
+	Default          *string  `yaml:"default"`
 	Logger           *logrus.Entry
+	DefaultValue     interface{}
 }
 
 func (config *IntegerConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
@@ -19,6 +23,12 @@ func (config *IntegerConfig) Validate(parsers map[string]Config, log *logrus.Ent
 		return errors.New("integer.name is required")
 	}
 
+	var err error
+	config.DefaultValue, err = parseDefaultValue(config.Default, NewInteger(config).parse)
+	if err != nil {
+		return fmt.Errorf("integer.default: %w", err)
+	}
+
 	return nil
 }
 
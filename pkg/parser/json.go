@@ -24,11 +24,19 @@ func (json *Json) Primitive() bool {
 	return json.config.Primitive()
 }
 
+func (json *Json) GetConfig() Config {
+	return json.config
+}
+
 func (json *Json) GetRegexpPattern() string {
 	return "" // Not matchable because it's not a primitive
 }
 
 func (json *Json) Parse(value string) (interface{}, error) {
+	return parseNullable(json.config.NullValues, json.config.DefaultValue, value, json.parse)
+}
+
+func (json *Json) parse(value string) (interface{}, error) {
 	var data interface{}
 	err := jsonPackage.Unmarshal([]byte(value), &data)
 	return data, err
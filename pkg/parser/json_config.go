@@ -2,13 +2,17 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"github.com/sirupsen/logrus"
 )
 
 type JsonConfig struct {
-	Name   string `yaml:"name"`
-	Type   string `yaml:"type"`
-	Logger *logrus.Entry
+	Name         string   `yaml:"name"`
+	Type         string   `yaml:"type"`
+	NullValues   []string `yaml:"nullValues"`
+	Default      *string  `yaml:"default"`
+	Logger       *logrus.Entry
+	DefaultValue interface{}
 }
 
 func (config *JsonConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
@@ -18,6 +22,12 @@ func (config *JsonConfig) Validate(parsers map[string]Config, log *logrus.Entry)
 		return errors.New("json.name is required")
 	}
 
+	var err error
+	config.DefaultValue, err = parseDefaultValue(config.Default, NewJson(config).parse)
+	if err != nil {
+		return fmt.Errorf("json.default: %w", err)
+	}
+
 	return nil
 }
 
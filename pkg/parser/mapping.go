@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/util"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Mapping struct {
+	config       *MappingConfig
+	lock         sync.RWMutex
+	values       map[string]interface{}
+	defaultValue interface{}
+	loaded       bool
+	callbacks    util.Callbacks
+}
+
+var mappingNotLoadedError = errors.New("Mapping not loaded")
+
+// Implemented by the parsers whose regexp pattern
+// can change after they have been created
+type ChangingParser interface {
+	Parser
+
+	// Registers a callback, called every time the pattern
+	// changes, and returns a function removing it
+	OnChange(callback func()) func()
+}
+
+// When the values are loaded from an input, the
+// mapping cannot be used until SetValues is called
+func NewMapping(config *MappingConfig) *Mapping {
+	mapping := &Mapping{
+		config: config,
+	}
+	if config.Input == "" {
+		mapping.values = config.Values
+		mapping.defaultValue = config.DefaultValue
+		mapping.loaded = true
+	}
+
+	return mapping
+}
+
+func (mapping *Mapping) Name() string {
+	return mapping.config.Name
+}
+
+func (mapping *Mapping) Primitive() bool {
+	return mapping.config.Primitive()
+}
+
+func (mapping *Mapping) GetConfig() Config {
+	return mapping.config
+}
+
+func (mapping *Mapping) Config() *MappingConfig {
+	return mapping.config
+}
+
+// Returns the name of the input from which the values must
+// be loaded, or an empty string if they are defined inline
+func (mapping *Mapping) InputName() string {
+	return mapping.config.Input
+}
+
+// Replaces the mapped values. The keys are the raw values,
+// and the values must be primitive. They are kept as returned
+// by the parsers of the mapping input.
+func (mapping *Mapping) SetValues(values map[string]interface{}) error {
+	for key, value := range values {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return fmt.Errorf("The value of the key '%v' of the mapping '%v' is not a primitive value.", key, mapping.config.Name)
+		}
+	}
+
+	defaultValue, err := parseDefaultValue(mapping.config.Default, func(value string) (interface{}, error) {
+		return mapping.config.getMappedValue(values, value)
+	})
+	if err != nil {
+		return fmt.Errorf("The default value of the mapping '%v' is invalid: %w", mapping.config.Name, err)
+	}
+
+	mapping.lock.Lock()
+	mapping.values = values
+	mapping.defaultValue = defaultValue
+	mapping.loaded = true
+	mapping.lock.Unlock()
+
+	mapping.callbacks.Call()
+
+	return nil
+}
+
+func (mapping *Mapping) OnChange(callback func()) func() {
+	return mapping.callbacks.Add(callback)
+}
+
+// Returns true if the given parser is this mapping, or
+// if it delegates the parsing of its values to it
+func (mapping *Mapping) IsUsedBy(parser Parser, parsers List) bool {
+	visited := make(map[string]bool)
+	for parser != nil && !visited[parser.Name()] {
+		if parser == Parser(mapping) {
+			return true
+		}
+		visited[parser.Name()] = true
+
+		configurableParser, isConfigurable := parser.(ConfigurableParser)
+		if !isConfigurable {
+			return false
+		}
+		config, isDelegating := configurableParser.GetConfig().(delegatingConfig)
+		if !isDelegating {
+			return false
+		}
+		parser = parsers[config.getDelegatedParserName()]
+	}
+
+	return false
+}
+
+// Only the mapped values are matched, unless there is a value for
+// the unknown ones. The pattern must not contain any capturing group.
+func (mapping *Mapping) GetRegexpPattern() string {
+	if mapping.config.UnknownValue != nil {
+		return ".+"
+	}
+
+	mapping.lock.RLock()
+	defer mapping.lock.RUnlock()
+
+	if len(mapping.values) == 0 {
+		return `[^\s\S]`
+	}
+
+	// Sorting by decreasing length, so that the longest key is matched
+	// first when a key is a prefix of another one
+	keys := make([]string, 0, len(mapping.values))
+	for key := range mapping.values {
+		keys = append(keys, regexp.QuoteMeta(key))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	return "(?:" + strings.Join(keys, "|") + ")"
+}
+
+func (mapping *Mapping) Parse(value string) (interface{}, error) {
+	mapping.lock.RLock()
+	defer mapping.lock.RUnlock()
+
+	if !mapping.loaded {
+		return nil, fmt.Errorf("The values of the mapping '%v' have not been loaded from the input '%v': %w", mapping.config.Name, mapping.config.Input, mappingNotLoadedError)
+	}
+
+	return parseNullable(mapping.config.NullValues, mapping.defaultValue, value, mapping.parse)
+}
+
+// Must be called with the read lock
+func (mapping *Mapping) parse(value string) (interface{}, error) {
+	return mapping.config.getMappedValue(mapping.values, value)
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+)
+
+type MappingConfig struct {
+	Name          string                 `yaml:"name"`
+	Type          string                 `yaml:"type"`
+	Values        map[string]interface{} `yaml:"values"`
+	Input         string                 `yaml:"input"`
+	KeyProperty   string                 `yaml:"keyProperty"`
+	ValueProperty string                 `yaml:"valueProperty"`
+	UnknownValue  *interface{}           `yaml:"unknownValue"`
+	NullValues    []string               `yaml:"nullValues"`
+	Default       *string                `yaml:"default"`
+	Logger        *logrus.Entry
+	DefaultValue  interface{}
+}
+
+func (config *MappingConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
+	config.Logger = log
+
+	if config.Name == "" {
+		return errors.New("mapping.name is required")
+	}
+
+	if config.Input == "" {
+		if config.Values == nil {
+			return errors.New("mapping: Either values or input is required")
+		}
+		if config.KeyProperty != "" || config.ValueProperty != "" {
+			return errors.New("mapping: keyProperty and valueProperty can only be used with an input")
+		}
+	} else {
+		if config.Values != nil {
+			return errors.New("mapping: values and input cannot be used at the same time")
+		}
+		if config.KeyProperty == "" {
+			return errors.New("mapping.keyProperty is required when using an input")
+		}
+		if config.ValueProperty == "" {
+			return errors.New("mapping.valueProperty is required when using an input")
+		}
+	}
+
+	for key, value := range config.Values {
+		normalizedValue, err := normalizeMappingValue(value)
+		if err != nil {
+			return fmt.Errorf("mapping.values.%v: %w", key, err)
+		}
+		config.Values[key] = normalizedValue
+	}
+
+	if config.UnknownValue == nil {
+		log.Debug("mapping.unknownValue not defined. The unknown values will not be parsed")
+	} else {
+		normalizedValue, err := normalizeMappingValue(*config.UnknownValue)
+		if err != nil {
+			return fmt.Errorf("mapping.unknownValue: %w", err)
+		}
+		*config.UnknownValue = normalizedValue
+	}
+
+	// The default value of a mapping using an input
+	// is parsed every time its values are loaded
+	if config.Input == "" {
+		var err error
+		config.DefaultValue, err = parseDefaultValue(config.Default, func(value string) (interface{}, error) {
+			return config.getMappedValue(config.Values, value)
+		})
+		if err != nil {
+			return fmt.Errorf("mapping.default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Returns the value to which the given raw value is converted
+func (config *MappingConfig) getMappedValue(values map[string]interface{}, value string) (interface{}, error) {
+	if mappedValue, exists := values[value]; exists {
+		return mappedValue, nil
+	}
+
+	if config.UnknownValue != nil {
+		return *config.UnknownValue, nil
+	}
+
+	return nil, fmt.Errorf("The value '%v' was found but is not declared in the mapping '%v'.", value, config.Name)
+}
+
+func (config *MappingConfig) GetName() string {
+	return config.Name
+}
+
+func (config *MappingConfig) Primitive() bool {
+	return true
+}
+
+// Converts the inline values decoded from the configuration
+// to the types returned by the other parsers (int64 or float64).
+// The null values are kept as nil.
+func normalizeMappingValue(value interface{}) (interface{}, error) {
+	switch value.(type) {
+	case nil, string, bool, int64, float64:
+		return value, nil
+	case int:
+		return int64(value.(int)), nil
+	case int32:
+		return int64(value.(int32)), nil
+	case uint:
+		return int64(value.(uint)), nil
+	case float32:
+		return float64(value.(float32)), nil
+	default:
+		return nil, fmt.Errorf("The value '%v' is not a string, boolean, integer or float.", value)
+	}
+}
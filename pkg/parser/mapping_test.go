@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"github.com/sirupsen/logrus"
+	"regexp"
+	"testing"
+)
+
+func TestMappingParse(t *testing.T) {
+	values := map[string]interface{}{
+		"A":  "active",
+		"I":  1,
+		"F":  1.5,
+		"T":  true,
+		"Id": int64(2),
+		"N":  nil,
+	}
+
+	t.Run("values", func(t *testing.T) {
+		mapping := newParserForTests(t, &MappingConfig{Name: "mapping", Values: values}, nil)
+		for value, expect := range map[string]interface{}{
+			"A":  "active",
+			"I":  int64(1),
+			"F":  1.5,
+			"T":  true,
+			"Id": int64(2),
+			"N":  nil,
+		} {
+			got, err := mapping.Parse(value)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got != expect {
+				t.Fatalf("Expected '%#v', got '%#v'", expect, got)
+			}
+		}
+	})
+	t.Run("unknown", func(t *testing.T) {
+		mapping := newParserForTests(t, &MappingConfig{Name: "mapping", Values: values}, nil)
+		if got, err := mapping.Parse("X"); err == nil {
+			t.Fatalf("Expected an error, got '%v'", got)
+		}
+	})
+	t.Run("unknown value", func(t *testing.T) {
+		var unknownValue interface{} = 0
+		mapping := newParserForTests(t, &MappingConfig{Name: "mapping", Values: values, UnknownValue: &unknownValue}, nil)
+		got, err := mapping.Parse("X")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect := int64(0); got != expect {
+			t.Fatalf("Expected '%#v', got '%#v'", expect, got)
+		}
+	})
+	t.Run("input", func(t *testing.T) {
+		mapping := newParserForTests(t, &MappingConfig{Name: "mapping", Input: "statuses", KeyProperty: "code", ValueProperty: "label"}, nil).(*Mapping)
+		if got, err := mapping.Parse("A"); err == nil {
+			t.Fatalf("Expected an error before loading the values, got '%v'", got)
+		}
+
+		if err := mapping.SetValues(map[string]interface{}{"A": "active"}); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		got, err := mapping.Parse("A")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect := "active"; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+
+		if err := mapping.SetValues(map[string]interface{}{"A": []interface{}{}}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+
+		decimal, err := NewDecimalValue("1.50", 2, false)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if err := mapping.SetValues(map[string]interface{}{"D": decimal}); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		got, err = mapping.Parse("D")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if got != decimal {
+			t.Fatalf("Expected '%v', got '%v'", decimal, got)
+		}
+	})
+	t.Run("input default", func(t *testing.T) {
+		defaultValue := "A"
+		mapping := newParserForTests(t, &MappingConfig{Name: "mapping", Input: "statuses", KeyProperty: "code", ValueProperty: "label", NullValues: []string{""}, Default: &defaultValue}, nil).(*Mapping)
+		if err := mapping.SetValues(map[string]interface{}{"I": "inactive"}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+
+		if err := mapping.SetValues(map[string]interface{}{"A": "active"}); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		got, err := mapping.Parse("")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect := "active"; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+}
+
+func TestMappingGetRegexpPattern(t *testing.T) {
+	mapping := newParserForTests(t, &MappingConfig{
+		Name:   "mapping",
+		Values: map[string]interface{}{"a": 1, "a.b": 2, "c": 3},
+	}, nil)
+	patternRegexp, err := regexp.Compile("^(" + mapping.GetRegexpPattern() + ")$")
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	for value, expect := range map[string]bool{
+		"a":   true,
+		"a.b": true,
+		"c":   true,
+		"a-b": false,
+		"d":   false,
+		"":    false,
+	} {
+		t.Run(value, func(t *testing.T) {
+			if got := patternRegexp.MatchString(value); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		})
+	}
+	t.Run("empty", func(t *testing.T) {
+		mapping := newParserForTests(t, &MappingConfig{Name: "mapping", Values: map[string]interface{}{}}, nil)
+		if got := regexp.MustCompile(mapping.GetRegexpPattern()).MatchString("a"); got {
+			t.Fatalf("Expected an empty mapping to match nothing")
+		}
+	})
+}
+
+func TestMappingConfigValidate(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	var unknownValue interface{} = []interface{}{1}
+	for _, testCase := range []struct {
+		name   string
+		config *MappingConfig
+	}{
+		{"no values", &MappingConfig{Name: "a"}},
+		{"values and input", &MappingConfig{Name: "a", Values: map[string]interface{}{}, Input: "b", KeyProperty: "k", ValueProperty: "v"}},
+		{"key property", &MappingConfig{Name: "a", Input: "b", ValueProperty: "v"}},
+		{"value property", &MappingConfig{Name: "a", Input: "b", KeyProperty: "k"}},
+		{"properties without input", &MappingConfig{Name: "a", Values: map[string]interface{}{}, KeyProperty: "k"}},
+		{"non-primitive value", &MappingConfig{Name: "a", Values: map[string]interface{}{"a": map[string]interface{}{}}}},
+		{"non-primitive unknown value", &MappingConfig{Name: "a", Values: map[string]interface{}{}, UnknownValue: &unknownValue}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.config.Validate(map[string]Config{}, log); err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+		})
+	}
+}
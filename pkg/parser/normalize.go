@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"fmt"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+	"strings"
+	"unicode"
+)
+
+// The available steps, by name
+var normalizeSteps = map[string]func(value string) string{
+	"trim":       strings.TrimSpace,
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"nfc":        norm.NFC.String,
+	"nfd":        norm.NFD.String,
+	"nfkc":       norm.NFKC.String,
+	"nfkd":       norm.NFKD.String,
+	"width":      foldWidth,
+	"kana":       foldKana,
+	"diacritics": removeDiacritics,
+}
+
+type Normalize struct {
+	config       *NormalizeConfig
+	parsers      List
+	defaultValue delegatedDefaultValue
+}
+
+func NewNormalize(
+	config *NormalizeConfig,
+	parsers List,
+) *Normalize {
+	return &Normalize{
+		config:  config,
+		parsers: parsers,
+	}
+}
+
+func (normalize *Normalize) Name() string {
+	return normalize.config.Name
+}
+
+func (normalize *Normalize) Primitive() bool {
+	return normalize.config.Primitive()
+}
+
+func (normalize *Normalize) GetConfig() Config {
+	return normalize.config
+}
+
+func (normalize *Normalize) GetRegexpPattern() string {
+	return ".+"
+}
+
+// Applies the steps in order, then parses the
+// result with the delegated parser
+func (normalize *Normalize) Parse(value string) (interface{}, error) {
+	return normalize.defaultValue.parseNullable(normalize.config.NullValues, value, normalize.parse)
+}
+
+// The delegated parser may not exist before all the parsers have been created
+func (normalize *Normalize) parseDefaultValue() error {
+	return normalize.defaultValue.parse(normalize.config.Default, normalize.parse)
+}
+
+func (normalize *Normalize) parse(value string) (interface{}, error) {
+	for _, step := range normalize.config.Steps {
+		value = normalizeSteps[step](value)
+	}
+
+	// Need to find this at runtime, because the required parser
+	// may not be created before the current one
+	parser, parserExists := normalize.parsers[normalize.config.Parser]
+	if !parserExists {
+		return nil, fmt.Errorf("The parser %v was not found in parser list", normalize.config.Parser)
+	}
+
+	return parser.Parse(value)
+}
+
+// Converts the full-width latin characters to their usual (narrow) form,
+// and the half-width katakana to their usual (wide) form
+func foldWidth(value string) string {
+	return width.Fold.String(value)
+}
+
+// Converts the katakana to hiragana
+func foldKana(value string) string {
+	return strings.Map(func(character rune) rune {
+		if (character >= 'ァ' && character <= 'ヶ') || character == 'ヽ' || character == 'ヾ' {
+			return character - ('ァ' - 'ぁ')
+		}
+		return character
+	}, value)
+}
+
+// Removes the combining marks, like accents, after decomposing the characters.
+// The transformer is created on each call because it is not thread-safe.
+func removeDiacritics(value string) string {
+	result, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), value)
+	if err != nil {
+		return value
+	}
+
+	return result
+}
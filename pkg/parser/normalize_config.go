@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+)
+
+type NormalizeConfig struct {
+	Name         string   `yaml:"name"`
+	Type         string   `yaml:"type"`
+	Steps        []string `yaml:"steps"`
+	Parser       string   `yaml:"parser"`
+	NullValues   []string `yaml:"nullValues"`
+	Default      *string  `yaml:"default"`
+	Logger       *logrus.Entry
+	ParserConfig Config
+}
+
+func (config *NormalizeConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
+	config.Logger = log
+
+	if config.Name == "" {
+		return errors.New("normalize.name is required")
+	}
+
+	if len(config.Steps) == 0 {
+		return errors.New("normalize.steps must contain at least one step")
+	}
+	for stepIndex, step := range config.Steps {
+		if _, stepExists := normalizeSteps[step]; !stepExists {
+			return fmt.Errorf("normalize.steps[%v]: Unknown step '%v'.", stepIndex, step)
+		}
+	}
+
+	if config.Parser == "" {
+		log.Debug("normalize.parser not defined. Assuming 'string'")
+		config.Parser = "string"
+	}
+	if config.Parser == config.Name {
+		return errors.New("normalize.parser: The parser cannot delegate to itself.")
+	}
+
+	parserConfig, parserExists := parsers[config.Parser]
+	if !parserExists {
+		return fmt.Errorf("Parser '%v' not found in parsers list.", config.Parser)
+	}
+	config.ParserConfig = parserConfig
+
+	if err := checkDelegationCycle(config.Name, config.Parser, parsers); err != nil {
+		return fmt.Errorf("normalize.parser: %w", err)
+	}
+
+	return nil
+}
+
+func (config *NormalizeConfig) getDelegatedParserName() string {
+	if config.Parser == "" {
+		return "string"
+	}
+
+	return config.Parser
+}
+
+func (config *NormalizeConfig) GetName() string {
+	return config.Name
+}
+
+// The values are primitive if the ones of the delegated parser are
+func (config *NormalizeConfig) Primitive() bool {
+	return config.ParserConfig == nil || config.ParserConfig.Primitive()
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestNormalizeParse(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	parserConfigs := map[string]Config{
+		"string":  &StringConfig{Name: "string"},
+		"integer": &IntegerConfig{Name: "integer"},
+	}
+	parsers := List{
+		"string":  NewMock(),
+		"integer": NewInteger(&IntegerConfig{}),
+	}
+
+	for _, testCase := range []struct {
+		name   string
+		steps  []string
+		parser string
+		value  string
+		expect interface{}
+	}{
+		{"trim", []string{"trim"}, "", " 　foo\t", "foo"},
+		{"lower", []string{"lower"}, "", "FooÉ", "fooé"},
+		{"upper", []string{"upper"}, "", "fooé", "FOOÉ"},
+		{"nfc", []string{"nfc"}, "", "e\u0301", "\u00e9"},
+		{"nfd", []string{"nfd"}, "", "\u00e9", "e\u0301"},
+		{"nfkc", []string{"nfkc"}, "", "ｶﾞ①", "ガ1"},
+		{"nfkd", []string{"nfkd"}, "", "①", "1"},
+		{"width", []string{"width"}, "", "ＡＢＣ１２３ｶﾀｶﾅ", "ABC123カタカナ"},
+		{"kana", []string{"kana"}, "", "カタカナとひらがなヴ", "かたかなとひらがなゔ"},
+		{"diacritics", []string{"diacritics"}, "", "Élève à Noël", "Eleve a Noel"},
+		{"ordered", []string{"width", "kana", "trim", "lower"}, "", " ＡＢＣ ｶﾀｶﾅ ", "abc かたかな"},
+		{"delegated", []string{"width"}, "integer", "１２３", int64(123)},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := &NormalizeConfig{
+				Name:   "normalize",
+				Steps:  testCase.steps,
+				Parser: testCase.parser,
+			}
+			if err := config.Validate(parserConfigs, log); err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+
+			got, err := NewNormalize(config, parsers).Parse(testCase.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got != testCase.expect {
+				t.Fatalf("Expected '%#v', got '%#v'", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeConfigValidate(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	parsers := map[string]Config{
+		"string": &StringConfig{Name: "string"},
+	}
+
+	for _, testCase := range []struct {
+		name   string
+		config *NormalizeConfig
+	}{
+		{"no steps", &NormalizeConfig{Name: "a"}},
+		{"unknown step", &NormalizeConfig{Name: "a", Steps: []string{"trim", "wrong"}}},
+		{"unknown parser", &NormalizeConfig{Name: "a", Steps: []string{"trim"}, Parser: "wrong"}},
+		{"itself", &NormalizeConfig{Name: "a", Steps: []string{"trim"}, Parser: "a"}},
+		{"indirectly itself", &NormalizeConfig{Name: "a", Steps: []string{"trim"}, Parser: "b"}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			parsers := map[string]Config{
+				"string": parsers["string"],
+				"a":      testCase.config,
+				"b":      &RegexpConfig{Name: "b", Pattern: "b", Parser: "a"},
+			}
+			if err := testCase.config.Validate(parsers, log); err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/util"
+	"sort"
+	"sync"
+)
+
+// Returns nil for the values declared as null values in the config of a
+// parser, or the already parsed default value when there is one. The other
+// values are parsed with the given function.
+func parseNullable(
+	nullValues []string,
+	defaultValue interface{},
+	value string,
+	parse func(value string) (interface{}, error),
+) (interface{}, error) {
+	if !util.IsInArray(value, nullValues) {
+		return parse(value)
+	}
+
+	return defaultValue, nil
+}
+
+// Parses the default value of a config, which is nil when there is none
+func parseDefaultValue(
+	defaultValue *string,
+	parse func(value string) (interface{}, error),
+) (interface{}, error) {
+	if defaultValue == nil {
+		return nil, nil
+	}
+
+	return parse(*defaultValue)
+}
+
+// Implemented by the parsers delegating the parsing of their default
+// value to another parser, which must already exist to parse it
+type delegatingParser interface {
+	parseDefaultValue() error
+}
+
+// The default value of a parser delegating to another parser.
+// It is parsed again every time the delegated values may change.
+type delegatedDefaultValue struct {
+	lock  sync.RWMutex
+	value interface{}
+	err   error
+}
+
+func (defaultValue *delegatedDefaultValue) parse(
+	value *string,
+	parse func(value string) (interface{}, error),
+) error {
+	parsedValue, err := parseDefaultValue(value, parse)
+
+	defaultValue.lock.Lock()
+	defer defaultValue.lock.Unlock()
+
+	defaultValue.value = parsedValue
+	defaultValue.err = err
+
+	return err
+}
+
+func (defaultValue *delegatedDefaultValue) parseNullable(
+	nullValues []string,
+	value string,
+	parse func(value string) (interface{}, error),
+) (interface{}, error) {
+	if !util.IsInArray(value, nullValues) {
+		return parse(value)
+	}
+
+	defaultValue.lock.RLock()
+	defer defaultValue.lock.RUnlock()
+
+	return defaultValue.value, defaultValue.err
+}
+
+// Parses the default values of the parsers delegating to other parsers.
+// It must be called once all the parsers have been created, and every
+// time the values of a mapping have been loaded.
+func ParseDelegatedDefaultValues(parsers List) error {
+	parserNames := make([]string, 0, len(parsers))
+	for parserName := range parsers {
+		parserNames = append(parserNames, parserName)
+	}
+	sort.Strings(parserNames)
+
+	for _, parserName := range parserNames {
+		if parser, isDelegating := parsers[parserName].(delegatingParser); isDelegating {
+			// It will be parsed again once the mapping is loaded
+			if err := parser.parseDefaultValue(); err != nil && !errors.Is(err, mappingNotLoadedError) {
+				return fmt.Errorf("The default value of the parser '%v' is invalid: %w", parserName, err)
+			}
+		}
+	}
+
+	return nil
+}
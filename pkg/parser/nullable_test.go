@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"github.com/sirupsen/logrus"
+	"regexp"
+	"testing"
+)
+
+func TestParseNullable(t *testing.T) {
+	defaultValue := "0"
+	for _, testCase := range []struct {
+		name   string
+		parser Parser
+		value  string
+		expect interface{}
+	}{
+		{"not null", NewInteger(&IntegerConfig{NullValues: []string{"", "NULL"}}), "42", int64(42)},
+		{"null", NewInteger(&IntegerConfig{NullValues: []string{"", "NULL"}}), "NULL", nil},
+		{"empty", NewInteger(&IntegerConfig{NullValues: []string{"", "NULL"}}), "", nil},
+		{"default", NewInteger(&IntegerConfig{NullValues: []string{"\\N"}, Default: &defaultValue, DefaultValue: int64(0)}), "\\N", int64(0)},
+		{"boolean", NewBoolean(&BooleanConfig{TrueValues: []string{"Y"}, FalseValues: []string{"N"}, NullValues: []string{"-"}}), "-", nil},
+		{"delegated", NewRegexp(&RegexpConfig{PatternRegexp: regexp.MustCompile("[0-9]+"), Parser: "integer", NullValues: []string{"n/a"}}, List{"integer": NewInteger(&IntegerConfig{})}), "n/a", nil},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := testCase.parser.Parse(testCase.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got != testCase.expect {
+				t.Fatalf("Expected '%#v', got '%#v'", testCase.expect, got)
+			}
+		})
+	}
+	t.Run("invalid", func(t *testing.T) {
+		integer := NewInteger(&IntegerConfig{NullValues: []string{"NULL"}})
+		if got, err := integer.Parse("null"); err == nil {
+			t.Fatalf("Expected an error, got '%v'", got)
+		}
+	})
+}
+
+func TestParseDefaultValue(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	validDefault := "42"
+	invalidDefault := "abc"
+
+	t.Run("validated", func(t *testing.T) {
+		config := &IntegerConfig{Name: "integer", NullValues: []string{""}, Default: &validDefault}
+		if err := config.Validate(map[string]Config{}, log); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := int64(42), config.DefaultValue; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		config := &IntegerConfig{Name: "integer", Default: &invalidDefault}
+		if err := config.Validate(map[string]Config{}, log); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("mapping", func(t *testing.T) {
+		config := &MappingConfig{Name: "mapping", Values: map[string]interface{}{"a": "b"}, Default: &invalidDefault}
+		if err := config.Validate(map[string]Config{}, log); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	for _, testCase := range []struct {
+		name         string
+		defaultValue *string
+		expectError  bool
+	}{
+		{"delegated", &validDefault, false},
+		{"invalid delegated", &invalidDefault, true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			configs := map[string]Config{
+				"integer": &IntegerConfig{Name: "integer"},
+				"regexp":  &RegexpConfig{Name: "regexp", Pattern: ".+", Parser: "integer", NullValues: []string{""}, Default: testCase.defaultValue},
+			}
+			for _, config := range configs {
+				if err := config.Validate(configs, log); err != nil {
+					t.Fatalf("Unexpected error: '%+v'", err)
+				}
+			}
+
+			parsers, err := NewFromConfigs(configs)
+			if testCase.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got, err := parsers["regexp"].Parse(""); err != nil || got != int64(42) {
+				t.Fatalf("Expected '42', got '%v' and '%+v'", got, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"github.com/sirupsen/logrus"
+	"reflect"
 )
 
 type Parser interface {
@@ -24,22 +25,12 @@ func NewFromConfig(
 	config Config,
 	parsers List,
 ) (Parser, error) {
-	switch config.(type) {
-	case *StringConfig:
-		return NewString(config.(*StringConfig))
-	case *IntegerConfig:
-		return NewInteger(config.(*IntegerConfig)), nil
-	case *FloatConfig:
-		return NewFloat(config.(*FloatConfig)), nil
-	case *BooleanConfig:
-		return NewBoolean(config.(*BooleanConfig)), nil
-	case *JsonConfig:
-		return NewJson(config.(*JsonConfig)), nil
-	case *SplitConfig:
-		return NewSplit(config.(*SplitConfig), parsers), nil
-	default:
+	registration, exists := registrationsByConfigType[reflect.TypeOf(config)]
+	if !exists {
 		return nil, fmt.Errorf("Unknown parser config type: %#v", config)
 	}
+
+	return registration.constructor(config, parsers)
 }
 
 func NewFromConfigs(
@@ -54,6 +45,10 @@ func NewFromConfigs(
 		parsers[parserName] = parser
 	}
 
+	if err := ParseDelegatedDefaultValues(parsers); err != nil {
+		return nil, err
+	}
+
 	return parsers, nil
 }
 
@@ -113,6 +108,31 @@ func Compare(a interface{}, b interface{}) (*bool, error) {
 		}
 		result := (aBool == false && bBool == true)
 		return &result, nil
+	case DecimalValue:
+		aDecimal := a.(DecimalValue)
+		bDecimal, bIsDecimal := b.(DecimalValue)
+		if !bIsDecimal {
+			return nil, fmt.Errorf("Cannot compare a decimal with '%#v'", b)
+		}
+
+		comparison := aDecimal.Rat().Cmp(bDecimal.Rat())
+		if comparison == 0 {
+			return nil, nil
+		}
+		result := comparison < 0
+		return &result, nil
+	case DateTimeValue:
+		aDateTime := a.(DateTimeValue)
+		bDateTime, bIsDateTime := b.(DateTimeValue)
+		if !bIsDateTime {
+			return nil, fmt.Errorf("Cannot compare a datetime with '%#v'", b)
+		}
+
+		if aDateTime.Time().Equal(bDateTime.Time()) {
+			return nil, nil
+		}
+		result := aDateTime.Time().Before(bDateTime.Time())
+		return &result, nil
 	}
 
 	return nil, fmt.Errorf("Unhandled type for sorting object '%#v'", a)
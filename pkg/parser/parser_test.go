@@ -1,9 +1,46 @@
 package parser
 
 import (
+	"github.com/sirupsen/logrus"
 	"testing"
+	"time"
 )
 
+// Validates the given config and creates its parser, like when loading
+// the configuration. The parsers it can delegate to are created from
+// the given configs.
+func newParserForTests(t *testing.T, config Config, parsers map[string]Config) Parser {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	for _, parserConfig := range parsers {
+		if err := parserConfig.Validate(parsers, log); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+	}
+	if err := config.Validate(parsers, log); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	delegatedParsers, err := NewFromConfigs(parsers)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	parser, err := NewFromConfig(config, delegatedParsers)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	return parser
+}
+
+func newDateTimeForTests(day int) DateTimeValue {
+	return NewDateTimeValue(time.Date(2021, 2, day, 0, 0, 0, 0, time.UTC), time.UTC, time.RFC3339)
+}
+
+func newDecimalForTests(value string) DecimalValue {
+	decimal, _ := NewDecimalValue(value, -1, false)
+	return decimal
+}
+
 func TestCompare(t *testing.T) {
 	for _, testCase := range []struct {
 		name        string
@@ -27,6 +64,14 @@ func TestCompare(t *testing.T) {
 		{"bool a < b", false, true, false, true},
 		{"bool a = b", false, false, true, false},
 		{"bool a > b", true, false, false, false},
+
+		{"decimal a < b", newDecimalForTests("0.3"), newDecimalForTests("0.31"), false, true},
+		{"decimal a = b", newDecimalForTests("0.3"), newDecimalForTests("0.30"), true, false},
+		{"decimal a > b", newDecimalForTests("-0.3"), newDecimalForTests("-0.31"), false, false},
+
+		{"datetime a < b", newDateTimeForTests(1), newDateTimeForTests(2), false, true},
+		{"datetime a = b", newDateTimeForTests(1), newDateTimeForTests(1), true, false},
+		{"datetime a > b", newDateTimeForTests(2), newDateTimeForTests(1), false, false},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			result, err := Compare(testCase.a, testCase.b)
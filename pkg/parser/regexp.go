@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"fmt"
+	"regexp/syntax"
+)
+
+type Regexp struct {
+	config       *RegexpConfig
+	parsers      List
+	defaultValue delegatedDefaultValue
+}
+
+func NewRegexp(
+	config *RegexpConfig,
+	parsers List,
+) *Regexp {
+	return &Regexp{
+		config:  config,
+		parsers: parsers,
+	}
+}
+
+func (regexpParser *Regexp) Name() string {
+	return regexpParser.config.Name
+}
+
+func (regexpParser *Regexp) Primitive() bool {
+	return regexpParser.config.Primitive()
+}
+
+func (regexpParser *Regexp) GetConfig() Config {
+	return regexpParser.config
+}
+
+// Without a replace template, the values must contain a match of the pattern.
+// The groups and anchors of the pattern are removed, because they would
+// change the meaning of the route regexp which contains this pattern,
+// and the surrounding characters cannot span several path segments.
+func (regexpParser *Regexp) GetRegexpPattern() string {
+	if regexpParser.config.Replace != nil {
+		return ".+"
+	}
+
+	pattern, err := syntax.Parse(regexpParser.config.Pattern, syntax.Perl)
+	if err != nil {
+		return ".+"
+	}
+
+	return "[^/]*(?:" + removeRegexpGroupsAndAnchors(pattern).String() + ")[^/]*"
+}
+
+func removeRegexpGroupsAndAnchors(pattern *syntax.Regexp) *syntax.Regexp {
+	switch pattern.Op {
+	case syntax.OpCapture:
+		return removeRegexpGroupsAndAnchors(pattern.Sub[0])
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}
+	}
+
+	for i, sub := range pattern.Sub {
+		pattern.Sub[i] = removeRegexpGroupsAndAnchors(sub)
+	}
+
+	return pattern
+}
+
+// With a replace template, every match of the pattern is replaced by it.
+// Otherwise, the value is the first group (or the whole match if there
+// is no group) of the first match. The resulting string is then
+// parsed by the delegated parser.
+func (regexpParser *Regexp) Parse(value string) (interface{}, error) {
+	return regexpParser.defaultValue.parseNullable(regexpParser.config.NullValues, value, regexpParser.parse)
+}
+
+// The delegated parser may not exist before all the parsers have been created
+func (regexpParser *Regexp) parseDefaultValue() error {
+	return regexpParser.defaultValue.parse(regexpParser.config.Default, regexpParser.parse)
+}
+
+func (regexpParser *Regexp) parse(value string) (interface{}, error) {
+	var result string
+	if regexpParser.config.Replace != nil {
+		result = regexpParser.config.PatternRegexp.ReplaceAllString(value, *regexpParser.config.Replace)
+	} else {
+		match := regexpParser.config.PatternRegexp.FindStringSubmatch(value)
+		if match == nil {
+			return nil, fmt.Errorf("The value '%v' does not match the pattern of the regexp parser '%v'.", value, regexpParser.config.Name)
+		}
+
+		result = match[0]
+		if len(match) > 1 {
+			result = match[1]
+		}
+	}
+
+	// Need to find this at runtime, because the required parser
+	// may not be created before the current one
+	parser, parserExists := regexpParser.parsers[regexpParser.config.Parser]
+	if !parserExists {
+		return nil, fmt.Errorf("The parser %v was not found in parser list", regexpParser.config.Parser)
+	}
+
+	return parser.Parse(result)
+}
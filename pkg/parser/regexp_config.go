@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"regexp"
+)
+
+type RegexpConfig struct {
+	Name          string   `yaml:"name"`
+	Type          string   `yaml:"type"`
+	Pattern       string   `yaml:"pattern"`
+	Replace       *string  `yaml:"replace"`
+	Parser        string   `yaml:"parser"`
+	NullValues    []string `yaml:"nullValues"`
+	Default       *string  `yaml:"default"`
+	Logger        *logrus.Entry
+	PatternRegexp *regexp.Regexp
+	ParserConfig  Config
+}
+
+func (config *RegexpConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
+	config.Logger = log
+
+	if config.Name == "" {
+		return errors.New("regexp.name is required")
+	}
+
+	if config.Pattern == "" {
+		return errors.New("regexp.pattern is required")
+	}
+
+	var err error
+	config.PatternRegexp, err = regexp.Compile(config.Pattern)
+	if err != nil {
+		return fmt.Errorf("regexp.pattern: Error while parsing regexp: %w", err)
+	}
+
+	if config.Parser == "" {
+		log.Debug("regexp.parser not defined. Assuming 'string'")
+		config.Parser = "string"
+	}
+	if config.Parser == config.Name {
+		return errors.New("regexp.parser: The parser cannot delegate to itself.")
+	}
+
+	parserConfig, parserExists := parsers[config.Parser]
+	if !parserExists {
+		return fmt.Errorf("Parser '%v' not found in parsers list.", config.Parser)
+	}
+	config.ParserConfig = parserConfig
+
+	if err := checkDelegationCycle(config.Name, config.Parser, parsers); err != nil {
+		return fmt.Errorf("regexp.parser: %w", err)
+	}
+
+	return nil
+}
+
+func (config *RegexpConfig) getDelegatedParserName() string {
+	if config.Parser == "" {
+		return "string"
+	}
+
+	return config.Parser
+}
+
+// Implemented by the configs of the parsers delegating
+// the parsing of their values to another parser
+type delegatingConfig interface {
+	getDelegatedParserName() string
+}
+
+// Follows the chain of delegated parsers, which may not be validated
+// yet, to make sure that it never comes back to the given parser
+func checkDelegationCycle(name string, delegatedParser string, parsers map[string]Config) error {
+	visited := map[string]bool{name: true}
+	for {
+		if visited[delegatedParser] {
+			return fmt.Errorf("The delegation chain of the parser '%v' loops on the parser '%v'.", name, delegatedParser)
+		}
+		visited[delegatedParser] = true
+
+		config, isDelegating := parsers[delegatedParser].(delegatingConfig)
+		if !isDelegating {
+			return nil
+		}
+		delegatedParser = config.getDelegatedParserName()
+	}
+}
+
+func (config *RegexpConfig) GetName() string {
+	return config.Name
+}
+
+// The values are primitive if the ones of the delegated parser are
+func (config *RegexpConfig) Primitive() bool {
+	return config.ParserConfig == nil || config.ParserConfig.Primitive()
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"github.com/sirupsen/logrus"
+	"regexp"
+	"testing"
+)
+
+var regexpParsersForTests = map[string]Config{
+	"string":  &StringConfig{Name: "string"},
+	"integer": &IntegerConfig{Name: "integer"},
+}
+
+func TestRegexpParse(t *testing.T) {
+	replace := "$2-$1"
+	for _, testCase := range []struct {
+		name   string
+		config *RegexpConfig
+		value  string
+		expect interface{}
+	}{
+		{"group", &RegexpConfig{Name: "regexp", Pattern: `^([A-Z]+-[0-9]+)`}, "ABC-1234 (old)", "ABC-1234"},
+		{"whole match", &RegexpConfig{Name: "regexp", Pattern: `[0-9]+`}, "ABC-1234 (old)", "1234"},
+		{"delegated", &RegexpConfig{Name: "regexp", Pattern: `-([0-9]+)`, Parser: "integer"}, "ABC-1234 (old)", int64(1234)},
+		{"replace", &RegexpConfig{Name: "regexp", Pattern: `([A-Z]+)-([0-9]+)`, Replace: &replace}, "ABC-1234 (old)", "1234-ABC (old)"},
+		{"replace without match", &RegexpConfig{Name: "regexp", Pattern: `([A-Z]+)-([0-9]+)`, Replace: &replace}, "foo", "foo"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := newParserForTests(t, testCase.config, regexpParsersForTests).Parse(testCase.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got != testCase.expect {
+				t.Fatalf("Expected '%#v', got '%#v'", testCase.expect, got)
+			}
+		})
+	}
+
+	t.Run("no match", func(t *testing.T) {
+		parser := newParserForTests(t, &RegexpConfig{Name: "regexp", Pattern: `[0-9]+`}, regexpParsersForTests)
+		if got, err := parser.Parse("foo"); err == nil {
+			t.Fatalf("Expected an error, got '%v'", got)
+		}
+	})
+	t.Run("delegated error", func(t *testing.T) {
+		parser := newParserForTests(t, &RegexpConfig{Name: "regexp", Pattern: `[A-Z]+`, Parser: "integer"}, regexpParsersForTests)
+		if got, err := parser.Parse("ABC-1234"); err == nil {
+			t.Fatalf("Expected an error, got '%v'", got)
+		}
+	})
+}
+
+func TestRegexpGetRegexpPattern(t *testing.T) {
+	replace := "$1"
+	for _, testCase := range []struct {
+		name   string
+		config *RegexpConfig
+		expect map[string]bool
+	}{
+		{
+			name:   "match",
+			config: &RegexpConfig{Name: "regexp", Pattern: `^([A-Z]+)-(?P<id>[0-9]+)$`},
+			expect: map[string]bool{"ABC-1234": true, "ABC-1234 (old)": true, "ABC": false},
+		},
+		{
+			name:   "replace",
+			config: &RegexpConfig{Name: "regexp", Pattern: `([A-Z]+)`, Replace: &replace},
+			expect: map[string]bool{"ABC-1234": true, "1234": true, "": false},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			parser := newParserForTests(t, testCase.config, regexpParsersForTests)
+			regexp, err := regexp.Compile("^/foo/(" + parser.GetRegexpPattern() + ")/bar$")
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if expect, got := 1, regexp.NumSubexp(); got != expect {
+				t.Fatalf("Expected '%v' groups, got '%v'", expect, got)
+			}
+
+			for value, expect := range testCase.expect {
+				if got := regexp.MatchString("/foo/" + value + "/bar"); got != expect {
+					t.Fatalf("Expected '%v' for '%v', got '%v'", expect, value, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRegexpConfigValidate(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+	parsers := map[string]Config{
+		"string": &StringConfig{Name: "string"},
+		"json":   &JsonConfig{Name: "json"},
+	}
+
+	t.Run("default parser", func(t *testing.T) {
+		config := &RegexpConfig{Name: "a", Pattern: "a"}
+		if err := config.Validate(parsers, log); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := "string", config.Parser; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if !config.Primitive() {
+			t.Fatalf("Expected the config to be primitive")
+		}
+	})
+	t.Run("chain", func(t *testing.T) {
+		parsers := map[string]Config{
+			"string": parsers["string"],
+			"a":      &RegexpConfig{Name: "a", Pattern: "a", Parser: "b"},
+			"b":      &RegexpConfig{Name: "b", Pattern: "b"},
+		}
+		if err := parsers["a"].Validate(parsers, log); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+	})
+	t.Run("not primitive", func(t *testing.T) {
+		config := &RegexpConfig{Name: "a", Pattern: "a", Parser: "json"}
+		if err := config.Validate(parsers, log); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if config.Primitive() {
+			t.Fatalf("Expected the config to not be primitive")
+		}
+	})
+	for _, testCase := range []struct {
+		name   string
+		config *RegexpConfig
+	}{
+		{"pattern", &RegexpConfig{Name: "a"}},
+		{"invalid pattern", &RegexpConfig{Name: "a", Pattern: "("}},
+		{"parser", &RegexpConfig{Name: "a", Pattern: "a", Parser: "wrong"}},
+		{"itself", &RegexpConfig{Name: "a", Pattern: "a", Parser: "a"}},
+		{"indirectly itself", &RegexpConfig{Name: "a", Pattern: "a", Parser: "b"}},
+		{"cycle", &RegexpConfig{Name: "a", Pattern: "a", Parser: "c"}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			parsers := map[string]Config{
+				"string": parsers["string"],
+				"a":      testCase.config,
+				"b":      &RegexpConfig{Name: "b", Pattern: "b", Parser: "a"},
+				"c":      &RegexpConfig{Name: "c", Pattern: "c", Parser: "d"},
+				"d":      &RegexpConfig{Name: "d", Pattern: "d", Parser: "c"},
+			}
+			if err := testCase.config.Validate(parsers, log); err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Creates a parser from its validated config, and the
+// other parsers it may delegate the parsing to.
+type Constructor = func(config Config, parsers List) (Parser, error)
+
+type registration struct {
+	newConfig   func() Config
+	constructor Constructor
+}
+
+var registrations = map[string]*registration{}
+var registrationsByConfigType = map[reflect.Type]*registration{}
+
+func init() {
+	Register("string", func() Config { return &StringConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewString(config.(*StringConfig))
+	})
+	Register("integer", func() Config { return &IntegerConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewInteger(config.(*IntegerConfig)), nil
+	})
+	Register("float", func() Config { return &FloatConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewFloat(config.(*FloatConfig)), nil
+	})
+	Register("decimal", func() Config { return &DecimalConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewDecimal(config.(*DecimalConfig)), nil
+	})
+	Register("geopoint", func() Config { return &GeoPointConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewGeoPoint(config.(*GeoPointConfig)), nil
+	})
+	Register("boolean", func() Config { return &BooleanConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewBoolean(config.(*BooleanConfig)), nil
+	})
+	Register("json", func() Config { return &JsonConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewJson(config.(*JsonConfig)), nil
+	})
+	Register("datetime", func() Config { return &DateTimeConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewDateTime(config.(*DateTimeConfig))
+	})
+	Register("mapping", func() Config { return &MappingConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewMapping(config.(*MappingConfig)), nil
+	})
+	Register("normalize", func() Config { return &NormalizeConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewNormalize(config.(*NormalizeConfig), parsers), nil
+	})
+	Register("regexp", func() Config { return &RegexpConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewRegexp(config.(*RegexpConfig), parsers), nil
+	})
+	Register("split", func() Config { return &SplitConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewSplit(config.(*SplitConfig), parsers), nil
+	})
+}
+
+// Makes a type of parser available under the given "type" value.
+// It must be called from an init function.
+func Register(typeName string, newConfig func() Config, constructor Constructor) {
+	if _, exists := registrations[typeName]; exists {
+		panic(fmt.Sprintf("The parser type '%v' is already registered.", typeName))
+	}
+
+	configType := reflect.TypeOf(newConfig())
+	if _, exists := registrationsByConfigType[configType]; exists {
+		panic(fmt.Sprintf("The parser config type '%v' is already registered.", configType))
+	}
+
+	registration := &registration{
+		newConfig:   newConfig,
+		constructor: constructor,
+	}
+	registrations[typeName] = registration
+	registrationsByConfigType[configType] = registration
+}
+
+// Returns a new, empty config of the given parser type
+func NewConfig(typeName string) (Config, bool) {
+	registration, exists := registrations[typeName]
+	if !exists {
+		return nil, false
+	}
+
+	return registration.newConfig(), true
+}
+
+// Returns the sorted names of the registered parser types
+func TypeNames() []string {
+	typeNames := make([]string, 0, len(registrations))
+	for typeName := range registrations {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	return typeNames
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+type registryTestConfig struct {
+	Name string `yaml:"name"`
+}
+
+func (config *registryTestConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
+	return nil
+}
+
+func (config *registryTestConfig) GetName() string {
+	return config.Name
+}
+
+func (config *registryTestConfig) Primitive() bool {
+	return true
+}
+
+func TestRegister(t *testing.T) {
+	Register("registryTest", func() Config { return &registryTestConfig{} }, func(config Config, parsers List) (Parser, error) {
+		return NewMockWithPrefix(config.(*registryTestConfig).Name), nil
+	})
+
+	t.Run("new config", func(t *testing.T) {
+		config, exists := NewConfig("registryTest")
+		if !exists {
+			t.Fatalf("Expected the type to exist")
+		}
+		if _, isTestConfig := config.(*registryTestConfig); !isTestConfig {
+			t.Fatalf("Expected a '*registryTestConfig', got '%#v'", config)
+		}
+	})
+	t.Run("new from config", func(t *testing.T) {
+		parser, err := NewFromConfig(&registryTestConfig{Name: "foo"}, List{})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := "foo", parser.(*Mock).prefix; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("type names", func(t *testing.T) {
+		found := false
+		for _, typeName := range TypeNames() {
+			found = found || typeName == "registryTest"
+		}
+		if !found {
+			t.Fatalf("Expected the type to be listed in '%v'", TypeNames())
+		}
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("Expected a panic")
+			}
+		}()
+		Register("registryTest", func() Config { return &registryTestConfig{} }, nil)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		if _, exists := NewConfig("wrong"); exists {
+			t.Fatalf("Expected the type to not exist")
+		}
+	})
+}
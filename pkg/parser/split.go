@@ -6,8 +6,9 @@ import (
 )
 
 type Split struct {
-	config  *SplitConfig
-	parsers List
+	config       *SplitConfig
+	parsers      List
+	defaultValue delegatedDefaultValue
 }
 
 func NewSplit(
@@ -28,11 +29,24 @@ func (split *Split) Primitive() bool {
 	return split.config.Primitive()
 }
 
+func (split *Split) GetConfig() Config {
+	return split.config
+}
+
 func (split *Split) GetRegexpPattern() string {
 	return "" // Not matchable because it's not a primitive
 }
 
 func (split *Split) Parse(value string) (interface{}, error) {
+	return split.defaultValue.parseNullable(split.config.NullValues, value, split.parse)
+}
+
+// The delegated parser may not exist before all the parsers have been created
+func (split *Split) parseDefaultValue() error {
+	return split.defaultValue.parse(split.config.Default, split.parse)
+}
+
+func (split *Split) parse(value string) (interface{}, error) {
 	var values []string
 	if split.config.IsDelimiterARegexp() {
 		values = split.config.DelimiterRegexp.Split(value, -1)
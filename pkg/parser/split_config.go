@@ -8,11 +8,13 @@ import (
 )
 
 type SplitConfig struct {
-	Name              string  `yaml:"name"`
-	Type              string  `yaml:"type"`
-	Delimiter         *string `yaml:"delimiter"`
-	DelimiterIsRegexp *bool   `yaml:"delimiterIsRegexp"`
-	Parser            string  `yaml:"parser"`
+	Name              string   `yaml:"name"`
+	Type              string   `yaml:"type"`
+	Delimiter         *string  `yaml:"delimiter"`
+	DelimiterIsRegexp *bool    `yaml:"delimiterIsRegexp"`
+	Parser            string   `yaml:"parser"`
+	NullValues        []string `yaml:"nullValues"`
+	Default           *string  `yaml:"default"`
 	Logger            *logrus.Entry
 	DelimiterRegexp   *regexp.Regexp
 }
@@ -40,11 +40,19 @@ func (str *String) Primitive() bool {
 	return str.config.Primitive()
 }
 
+func (str *String) GetConfig() Config {
+	return str.config
+}
+
 func (str *String) GetRegexpPattern() string {
 	return ".+"
 }
 
 func (str *String) Parse(value string) (interface{}, error) {
+	return parseNullable(str.config.NullValues, str.config.DefaultValue, value, str.parse)
+}
+
+func (str *String) parse(value string) (interface{}, error) {
 	if str.config.ConvertFromCharset != "" {
 		rInUTF8 := transform.NewReader(strings.NewReader(value), str.decoder)
 		convertedValue, err := ioutil.ReadAll(rInUTF8)
@@ -2,14 +2,18 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"github.com/sirupsen/logrus"
 )
 
 type StringConfig struct {
-	Name               string `yaml:"name"`
-	Type               string `yaml:"type"`
-	ConvertFromCharset string `yaml:"convertFromCharset"`
+	Name               string   `yaml:"name"`
+	Type               string   `yaml:"type"`
+	ConvertFromCharset string   `yaml:"convertFromCharset"`
+	NullValues         []string `yaml:"nullValues"`
+	Default            *string  `yaml:"default"`
 	Logger             *logrus.Entry
+	DefaultValue       interface{}
 }
 
 func (config *StringConfig) Validate(parsers map[string]Config, log *logrus.Entry) error {
@@ -19,6 +23,17 @@ func (config *StringConfig) Validate(parsers map[string]Config, log *logrus.Entr
 		return errors.New("string.name is required")
 	}
 
+	if config.Default != nil {
+		parser, err := NewString(config)
+		if err != nil {
+			return fmt.Errorf("string.default: %w", err)
+		}
+		config.DefaultValue, err = parseDefaultValue(config.Default, parser.parse)
+		if err != nil {
+			return fmt.Errorf("string.default: %w", err)
+		}
+	}
+
 	// The ConvertFromCharset will be validated at runtime.
 	// The default value is empty string (= don't convert)
 	return nil
@@ -0,0 +1,221 @@
+package rodb
+
+import (
+	"bytes"
+	"fmt"
+	configPackage "github.com/rodb-io/rodb/pkg/config"
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/output"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"io"
+	"reflect"
+)
+
+// Gives access to the inputs, indexes and outputs of a configuration
+// from Go code, without starting it's services. It can be used by
+// multiple goroutines at the same time.
+type Database struct {
+	config  *configPackage.Config
+	parsers parser.List
+	inputs  input.List
+	indexes index.List
+	outputs output.List
+}
+
+// Creates all the components of the given configuration, which
+// must have been created by configPackage.NewConfig or loaded from
+// a file. The indexes are built (or loaded) before returning.
+func Open(config *configPackage.Config) (*Database, error) {
+	parsers, err := parser.NewFromConfigs(config.Parsers)
+	if err != nil {
+		return nil, fmt.Errorf("Error initializing parsers: %w", err)
+	}
+
+	// The components created before an error must be closed
+	database := &Database{
+		config:  config,
+		parsers: parsers,
+		inputs:  make(input.List),
+		indexes: make(index.List),
+		outputs: make(output.List),
+	}
+
+	inputs, err := input.NewFromConfigs(config.Inputs, database.parsers)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("Error initializing inputs: %w", err)
+	}
+	database.inputs = inputs
+
+	if err := input.LoadParserMappings(database.parsers, database.inputs); err != nil {
+		database.Close()
+		return nil, fmt.Errorf("Error loading the mapping parsers: %w", err)
+	}
+
+	indexes, err := index.NewFromConfigs(config.Indexes, database.inputs)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("Error initializing indexes: %w", err)
+	}
+	database.indexes = indexes
+
+	outputs, err := output.NewFromConfigs(config.Outputs, database.inputs, database.indexes, database.parsers)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("Error initializing outputs: %w", err)
+	}
+	database.outputs = outputs
+
+	return database, nil
+}
+
+// Loads the configuration file (or directory) at the given path,
+// and opens it. The services declared in the file are ignored.
+func OpenYamlFile(configPath string, log *logrus.Logger) (*Database, error) {
+	config, err := configPackage.NewConfigFromYamlFile(configPath, log)
+	if err != nil {
+		return nil, fmt.Errorf("Error initializing config: %w", err)
+	}
+
+	return Open(config)
+}
+
+// Closes all the components. The database can not be used afterwards.
+func (database *Database) Close() error {
+	errs := []error{
+		output.Close(database.outputs),
+		index.Close(database.indexes),
+		input.Close(database.inputs),
+		parser.Close(database.parsers),
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the response of the given output, as it would be sent by
+// a service. The error returned by the output (for example when no
+// record matches the parameters) is returned as an error.
+func (database *Database) Query(outputName string, params map[string]string) ([]byte, error) {
+	queriedOutput, outputExists := database.outputs[outputName]
+	if !outputExists {
+		return nil, fmt.Errorf("Output '%v' not found in outputs list.", outputName)
+	}
+
+	for paramName := range params {
+		if !queriedOutput.HasParameter(paramName) {
+			return nil, fmt.Errorf("The output '%v' does not have a parameter named '%v'.", outputName, paramName)
+		}
+	}
+
+	var responseError error
+	response := &bytes.Buffer{}
+	err := queriedOutput.Handle(
+		params,
+		nil,
+		func(err error) error {
+			responseError = err
+			return nil
+		},
+		func() io.Writer {
+			return response
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if responseError != nil {
+		return nil, responseError
+	}
+
+	return response.Bytes(), nil
+}
+
+// Returns the data of the records of the given input, which match all
+// the given filters (indexed by property) using the given index.
+// When the index name is empty, the default index (which scans the
+// whole input) is used.
+// The filters must have the types returned by the parsers (for example
+// int64 for an integer, or a parser.DecimalValue for a decimal). The
+// other Go integer and float types are converted to int64 and float64.
+func (database *Database) Lookup(
+	inputName string,
+	indexName string,
+	filters map[string]interface{},
+) ([]map[string]interface{}, error) {
+	lookupInput, inputExists := database.inputs[inputName]
+	if !inputExists {
+		return nil, fmt.Errorf("Input '%v' not found in inputs list.", inputName)
+	}
+
+	if indexName == "" {
+		indexName = "default"
+	}
+	lookupIndex, indexExists := database.indexes[indexName]
+	if !indexExists {
+		return nil, fmt.Errorf("Index '%v' not found in indexes list.", indexName)
+	}
+
+	indexConfig := database.config.Indexes[indexName]
+	if !indexConfig.DoesHandleInput(database.config.Inputs[inputName]) {
+		return nil, fmt.Errorf("The index '%v' does not handle the input '%v'.", indexName, inputName)
+	}
+	normalizedFilters := make(map[string]interface{}, len(filters))
+	for property, value := range filters {
+		if !indexConfig.DoesHandleProperty(property) {
+			return nil, fmt.Errorf("The index '%v' does not handle the property '%v'.", indexName, property)
+		}
+		normalizedFilters[property] = normalizeFilterValue(value)
+	}
+
+	nextPosition, err := lookupIndex.GetRecordPositions(lookupInput, normalizedFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]map[string]interface{}, 0)
+	for {
+		position, err := nextPosition()
+		if err != nil {
+			return nil, err
+		}
+		if position == nil {
+			break
+		}
+
+		record, err := lookupInput.Get(*position)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := record.All()
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, data)
+	}
+
+	return records, nil
+}
+
+// Converts the Go numeric types to the ones returned by the parsers
+func normalizeFilterValue(value interface{}) interface{} {
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectValue.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(reflectValue.Uint())
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float()
+	default:
+		return value
+	}
+}
@@ -0,0 +1,149 @@
+package rodb
+
+import (
+	"github.com/rodb-io/rodb/pkg/config"
+	"github.com/rodb-io/rodb/pkg/index"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/output"
+	"github.com/rodb-io/rodb/pkg/output/parameter"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func openDatabaseForTests(t *testing.T) *Database {
+	path := filepath.Join(t.TempDir(), "users.csv")
+	if err := ioutil.WriteFile(path, []byte("1,alice\n2,bob\n3,alice\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	config, err := config.NewConfig(config.Components{
+		Inputs: []input.Config{
+			&input.CsvConfig{
+				Name: "users",
+				Path: path,
+				Columns: []*input.CsvColumnConfig{
+					{Name: "id", Parser: "integer"},
+					{Name: "name"},
+				},
+			},
+		},
+		Indexes: []index.Config{
+			&index.MapConfig{
+				Name:       "ids",
+				Input:      "users",
+				Properties: []string{"id", "name"},
+			},
+		},
+		Outputs: []output.Config{
+			&output.JsonObjectConfig{
+				Name:  "user",
+				Input: "users",
+				Parameters: map[string]*parameter.ParameterConfig{
+					"id": {
+						Property: "id",
+						Index:    "ids",
+						Parser:   "integer",
+					},
+				},
+			},
+		},
+	}, logrus.New())
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	database, err := Open(config)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	t.Cleanup(func() {
+		if err := database.Close(); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+	})
+
+	return database
+}
+
+func TestDatabaseQuery(t *testing.T) {
+	database := openDatabaseForTests(t)
+
+	t.Run("found", func(t *testing.T) {
+		response, err := database.Query("user", map[string]string{"id": "2"})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := "{\"id\":2,\"name\":\"bob\"}\n", string(response); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		if _, err := database.Query("user", map[string]string{"id": "4"}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("unknown output", func(t *testing.T) {
+		if _, err := database.Query("users", map[string]string{}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("unknown parameter", func(t *testing.T) {
+		if _, err := database.Query("user", map[string]string{"name": "bob"}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
+
+func TestDatabaseLookup(t *testing.T) {
+	database := openDatabaseForTests(t)
+
+	t.Run("index", func(t *testing.T) {
+		records, err := database.Lookup("users", "ids", map[string]interface{}{"name": "alice"})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		expect := []map[string]interface{}{
+			{"id": int64(1), "name": "alice"},
+			{"id": int64(3), "name": "alice"},
+		}
+		if !reflect.DeepEqual(records, expect) {
+			t.Fatalf("Expected '%v', got '%v'", expect, records)
+		}
+	})
+	t.Run("default index", func(t *testing.T) {
+		records, err := database.Lookup("users", "", map[string]interface{}{"id": int64(2)})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := 1, len(records); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("converted integer", func(t *testing.T) {
+		for _, id := range []interface{}{2, int32(2), uint8(2)} {
+			records, err := database.Lookup("users", "ids", map[string]interface{}{"id": id})
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			expect := []map[string]interface{}{
+				{"id": int64(2), "name": "bob"},
+			}
+			if !reflect.DeepEqual(records, expect) {
+				t.Fatalf("Expected '%v', got '%v'", expect, records)
+			}
+		}
+	})
+	t.Run("unknown input", func(t *testing.T) {
+		if _, err := database.Lookup("wrong", "ids", map[string]interface{}{}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("unhandled property", func(t *testing.T) {
+		if _, err := database.Lookup("users", "ids", map[string]interface{}{"wrong": 1}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+}
@@ -2,20 +2,15 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/output"
 	"github.com/rodb-io/rodb/pkg/util"
 	"github.com/sirupsen/logrus"
-	"io"
-	"io/ioutil"
 	goLog "log"
 	"net"
 	"net/http"
-	"net/url"
-	"regexp"
 	"sync"
 )
 
@@ -26,56 +21,37 @@ type Http struct {
 	httpServer     *http.Server
 	httpsServer    *http.Server
 	waitGroup      *sync.WaitGroup
-	routes         []*httpRoute
 	lastHttpError  error
 	lastHttpsError error
-}
+	reload         ReloadFunc
 
-type httpRoute struct {
-	config     HttpRouteConfig
-	path       *regexp.Regexp
-	parameters []string
-	output     output.Output
+	// The handler can be replaced by a reload, while the listeners are kept
+	handler     *httpHandler
+	handlerLock sync.RWMutex
+	// The functions removing the callbacks registered on each input
+	watchedInputs map[input.Input]func()
 }
 
 func NewHttp(
 	config *HttpConfig,
 	outputs map[string]output.Output,
+	reload ReloadFunc,
 ) (*Http, error) {
 	service := &Http{
 		config:         config,
 		waitGroup:      &sync.WaitGroup{},
 		lastHttpError:  nil,
 		lastHttpsError: nil,
+		reload:         reload,
+		watchedInputs:  make(map[input.Input]func()),
 	}
 
-	service.routes = make([]*httpRoute, 0, len(config.Routes))
-	for _, route := range config.Routes {
-		output, outputExists := outputs[route.Output]
-		if !outputExists {
-			return nil, fmt.Errorf("Output '%v' not found in outputs list.", route.Output)
-		}
-
-		routePath, parameters, err := service.createPathRegexp(*route, output)
-		if err != nil {
-			return nil, fmt.Errorf("Cannot build regexp from route path '%v': %w", route.Path, err)
-		}
-
-		for _, paramName := range parameters {
-			if !output.HasParameter(paramName) {
-				return nil, fmt.Errorf("Output '%v' does not have a parameter called '%v'.", route.Output, paramName)
-			}
-		}
-
-		service.routes = append(service.routes, &httpRoute{
-			config:     *route,
-			path:       routePath,
-			parameters: parameters,
-			output:     output,
-		})
+	handler, err := newHttpHandler(config, outputs)
+	if err != nil {
+		return nil, err
 	}
+	service.setHandler(handler)
 
-	var err error
 	if config.Http != nil {
 		service.httpListener, service.httpServer, err = service.createServer(config.Http.Listen)
 		if err != nil {
@@ -116,186 +92,176 @@ func (service *Http) createServer(listen string) (net.Listener, *http.Server, er
 
 	server := &http.Server{
 		ErrorLog: goLog.New(service.config.Logger.WriterLevel(logrus.ErrorLevel), "", 0),
-		Handler:  service.getHandlerFunc(),
+		Handler:  http.HandlerFunc(service.serveHttp),
 	}
 
 	return listener, server, nil
 }
 
-func (service *Http) Name() string {
-	return service.config.Name
-}
-
-func (service *Http) Address() string {
-	if service.config.Https != nil {
-		return "https://" + util.GetAddress(service.httpsListener.Addr())
-	} else {
-		return "http://" + util.GetAddress(service.httpListener.Addr())
+// Replaces the handler, and returns the previous one
+func (service *Http) setHandler(handler *httpHandler) *httpHandler {
+	service.handlerLock.Lock()
+	previousHandler := service.handler
+	service.handler = handler
+	service.handlerLock.Unlock()
+
+	// The responses cache must be emptied when the data changes
+	for input := range handler.getInputs() {
+		if _, isWatched := service.watchedInputs[input]; !isWatched {
+			service.watchedInputs[input] = input.OnChange(service.purgeResponseCache)
+		}
 	}
+
+	return previousHandler
 }
 
-// Returns a regular expression to match a string, and the list of param names
-// (matching the sub-expressions of the regexp)
-func (service *Http) createPathRegexp(
-	routeConfig HttpRouteConfig,
-	output output.Output,
-) (*regexp.Regexp, []string, error) {
-	paramRegexp, err := regexp.Compile("{([^}]+)}")
-	if err != nil {
-		return nil, nil, err
-	}
+// Removes the callbacks of a replaced handler, and stops watching the inputs
+// which are not used anymore. The pending requests of the replaced handler
+// must be done, because they could still use it's inputs.
+func (service *Http) releaseHandler(previousHandler *httpHandler) {
+	previousHandler.close()
 
-	paramMatches := paramRegexp.FindAllStringSubmatch(routeConfig.Path, -1)
-	params := make([]string, len(paramMatches))
-	for i, paramMatch := range paramMatches {
-		params[i] = paramMatch[1]
+	service.handlerLock.RLock()
+	usedInputs := service.handler.getInputs()
+	service.handlerLock.RUnlock()
+
+	for input, unsubscribe := range service.watchedInputs {
+		if !usedInputs[input] {
+			unsubscribe()
+			delete(service.watchedInputs, input)
+		}
 	}
+}
 
-	parts := paramRegexp.Split(routeConfig.Path, -1)
-	path := parts[0]
-	for partIndex := 1; partIndex < len(parts); partIndex++ {
-		paramIndex := partIndex - 1
-		paramName := params[paramIndex]
+// Returns the current handler, which must be released once the request
+// has been handled, so that a reload can wait for the pending requests
+func (service *Http) acquireHandler() *httpHandler {
+	service.handlerLock.RLock()
+	defer service.handlerLock.RUnlock()
 
-		parser, err := output.GetParameterParser(paramName)
-		if err != nil {
-			return nil, nil, err
-		}
+	handler := service.handler
+	handler.pendingRequests.Add(1)
 
-		if !parser.Primitive() {
-			return nil, nil, fmt.Errorf("Cannot use the parser '%v' as route parameter because it's not a primitive.", parser.Name())
-		}
+	return handler
+}
 
-		paramPattern := parser.GetRegexpPattern()
-		path = path + "(" + paramPattern + ")" + parts[partIndex]
+func (service *Http) purgeResponseCache() {
+	service.handlerLock.RLock()
+	defer service.handlerLock.RUnlock()
+
+	if service.handler.responseCache != nil {
+		service.handler.responseCache.Purge()
 	}
+}
 
-	regexp, err := regexp.Compile("^" + path + "$")
-	if err != nil {
-		return nil, nil, err
+func (service *Http) serveHttp(response http.ResponseWriter, request *http.Request) {
+	handler := service.acquireHandler()
+
+	// The reload waits for the pending requests,
+	// so it can not be one of them
+	if handler.isReloadRequest(request) {
+		handler.pendingRequests.Done()
+		service.handleReloadRequest(handler, response, request)
+		return
 	}
 
-	return regexp, params, nil
+	defer handler.pendingRequests.Done()
+	handler.ServeHTTP(response, request)
 }
 
-func (service *Http) getHandlerFunc() http.HandlerFunc {
-	return func(response http.ResponseWriter, request *http.Request) {
-		response.Header().Set("X-Powered-By", "RODB (https://rodb-io.github.io/rodb/)")
-
-		route := service.getMatchingRoute(request)
-		if route == nil {
-			errToSend := errors.New("No matching route was found")
-			err2 := service.sendErrorResponse(response, http.StatusNotFound, errToSend)
-			if err2 != nil {
-				service.config.Logger.Errorf("Error '%+v' while sending the error '%+v'", errToSend, err2)
-			}
-			return
-		}
+func (service *Http) handleReloadRequest(handler *httpHandler, response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("X-Powered-By", poweredBy)
 
-		payload, err := service.getPayload(route, request.Body)
-		if err != nil {
-			err2 := service.sendErrorResponse(response, http.StatusInternalServerError, err)
-			if err2 != nil {
-				service.config.Logger.Errorf("Error '%+v' while sending the error '%+v'", err, err2)
-			}
-			return
+	// The reload can rebuild the indexes, and is
+	// only accepted from the local machine
+	if !isLoopbackRequest(request) {
+		err := errors.New("The reload can only be requested from the local machine.")
+		if err2 := handler.sendErrorResponse(response, http.StatusForbidden, err); err2 != nil {
+			handler.config.Logger.Errorf("Error '%+v' while sending the error '%+v'", err, err2)
 		}
+		return
+	}
 
-		params := service.getParams(route, request.URL)
-		sendError := func(err error) error {
-			status := http.StatusInternalServerError
-			if errors.Is(err, record.RecordNotFoundError) {
-				status = http.StatusNotFound
-			}
-
-			return service.sendErrorResponse(response, status, err)
+	if service.reload == nil {
+		err := errors.New("The reload is not available.")
+		if err2 := handler.sendErrorResponse(response, http.StatusNotFound, err); err2 != nil {
+			handler.config.Logger.Errorf("Error '%+v' while sending the error '%+v'", err, err2)
 		}
-		sendSuccess := func() io.Writer {
-			response.Header().Set("Content-Type", route.output.ResponseType()+"; charset=UTF-8")
-			response.WriteHeader(http.StatusOK)
-			return io.Writer(response)
-		}
-		if err := route.output.Handle(params, payload, sendError, sendSuccess); err != nil {
-			service.config.Logger.Errorf("Unhandled error while handling the route '%v': %v", route.config.Path, err)
-		}
-
 		return
 	}
-}
 
-func (service *Http) sendErrorResponse(
-	response http.ResponseWriter,
-	status int,
-	err error,
-) error {
-	var data []byte
-	var outputType string = service.config.ErrorsType
-	switch outputType {
-	case "application/json":
-		data, err = json.Marshal(map[string]interface{}{
-			"error": err.Error(),
-		})
-		if err != nil {
-			return err
-		}
-	default:
-		response.Header().Set("Content-Type", "text/plain; charset=UTF-8")
-		response.WriteHeader(status)
-		_, err = response.Write([]byte(err.Error()))
-		if err != nil {
-			return err
+	if err := service.reload(); err != nil {
+		if err2 := handler.sendErrorResponse(response, http.StatusInternalServerError, err); err2 != nil {
+			handler.config.Logger.Errorf("Error '%+v' while sending the error '%+v'", err, err2)
 		}
+		return
+	}
 
-		return fmt.Errorf("ErrorResponse type '%v' is not supported by the HTTP service", service.config.ErrorsType)
+	response.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	response.Header().Set("Cache-Control", "no-store")
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write([]byte(`{"reloaded":true}`)); err != nil {
+		handler.config.Logger.Errorf("Error while sending the reload response: %v", err)
 	}
+}
 
-	response.Header().Set("Content-Type", outputType+"; charset=UTF-8")
-	response.WriteHeader(status)
-	_, err = response.Write(data)
+func isLoopbackRequest(request *http.Request) bool {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
 	if err != nil {
-		return err
+		return false
 	}
 
-	return nil
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
 
-func (service *Http) getMatchingRoute(request *http.Request) *httpRoute {
-	for _, route := range service.routes {
-		expectedPayloadType := route.output.ExpectedPayloadType()
-		isValidGet := (request.Method == http.MethodGet && expectedPayloadType == nil)
-		isValidPost := request.Method == http.MethodPost &&
-			expectedPayloadType != nil &&
-			request.Header.Get("Content-Type") == *expectedPayloadType
-		if (isValidGet || isValidPost) && route.path.MatchString(request.URL.Path) {
-			return route
-		}
+func (service *Http) Reload(config Config, outputs map[string]output.Output) (func(), error) {
+	httpConfig, isHttpConfig := config.(*HttpConfig)
+	if !isHttpConfig {
+		return nil, fmt.Errorf("The service '%v' can not be replaced by a service of another type without restarting.", service.Name())
+	}
+	if !isSameHttpListener(service.config.Http, httpConfig.Http) || !isSameHttpsListener(service.config.Https, httpConfig.Https) {
+		return nil, fmt.Errorf("The http and https settings of the service '%v' can not be changed without restarting.", service.Name())
 	}
 
-	return nil
+	handler, err := newHttpHandler(httpConfig, outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		previousHandler := service.setHandler(handler)
+		previousHandler.pendingRequests.Wait()
+		service.releaseHandler(previousHandler)
+	}, nil
 }
 
-func (service *Http) getParams(route *httpRoute, url *url.URL) map[string]string {
-	// Getting params from the query string
-	params := make(map[string]string)
-	for k, v := range url.Query() {
-		params[k] = v[0]
+func isSameHttpListener(a *HttpHttpConfig, b *HttpHttpConfig) bool {
+	if a == nil || b == nil {
+		return a == b
 	}
 
-	// Adding params from the path's regex
-	outputMatches := route.path.FindStringSubmatch(url.Path)
-	for i, paramName := range route.parameters {
-		params[paramName] = outputMatches[i+1]
+	return *a == *b
+}
+
+func isSameHttpsListener(a *HttpHttpsConfig, b *HttpHttpsConfig) bool {
+	if a == nil || b == nil {
+		return a == b
 	}
 
-	return params
+	return *a == *b
 }
 
-func (service *Http) getPayload(route *httpRoute, body io.Reader) ([]byte, error) {
-	if route.output.ExpectedPayloadType() != nil {
-		return ioutil.ReadAll(body)
-	}
+func (service *Http) Name() string {
+	return service.config.Name
+}
 
-	return make([]byte, 0), nil
+func (service *Http) Address() string {
+	if service.config.Https != nil {
+		return "https://" + util.GetAddress(service.httpsListener.Addr())
+	} else {
+		return "http://" + util.GetAddress(service.httpListener.Addr())
+	}
 }
 
 func (service *Http) Wait() error {
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The data can only change when the inputs files are modified,
+// so the validators of a response are derived from the state
+// of the inputs it depends on and from the request parameters.
+type httpCacheValidators struct {
+	etag         string
+	lastModified time.Time
+}
+
+func getHttpCacheValidators(
+	route *httpRoute,
+	params map[string]string,
+) (*httpCacheValidators, error) {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "route:%q\n", route.config.Path)
+
+	inputs := route.output.Inputs()
+	inputNames := make([]string, 0, len(inputs))
+	for inputName := range inputs {
+		inputNames = append(inputNames, inputName)
+	}
+	sort.Strings(inputNames)
+
+	lastModified := time.Time{}
+	for _, inputName := range inputNames {
+		input := inputs[inputName]
+		modTime, err := input.ModTime()
+		if err != nil {
+			return nil, fmt.Errorf("Cannot get the modification time of the input '%v': %w", inputName, err)
+		}
+		size, err := input.Size()
+		if err != nil {
+			return nil, fmt.Errorf("Cannot get the size of the input '%v': %w", inputName, err)
+		}
+
+		fmt.Fprintf(hash, "input:%q:%v:%v\n", inputName, modTime.UnixNano(), size)
+		if modTime.After(lastModified) {
+			lastModified = modTime
+		}
+	}
+
+	paramNames := make([]string, 0, len(params))
+	for paramName := range params {
+		paramNames = append(paramNames, paramName)
+	}
+	sort.Strings(paramNames)
+	for _, paramName := range paramNames {
+		fmt.Fprintf(hash, "param:%q:%q\n", paramName, params[paramName])
+	}
+
+	// The ETag is weak because the same data can be sent
+	// with different encodings, depending on the compression
+	return &httpCacheValidators{
+		etag:         `W/"` + hex.EncodeToString(hash.Sum(nil)) + `"`,
+		lastModified: lastModified.UTC().Truncate(time.Second),
+	}, nil
+}
+
+// Checks the conditional headers of the request. As required by the RFC 7232,
+// If-Modified-Since is ignored when If-None-Match is given.
+// The "*" value of If-None-Match is not handled here, see matchesAnyETag.
+func (validators *httpCacheValidators) isNotModified(request *http.Request) bool {
+	if ifNoneMatch := request.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return validators.matchesETag(ifNoneMatch)
+	}
+
+	ifModifiedSince := request.Header.Get("If-Modified-Since")
+	if ifModifiedSince != "" && !validators.lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+
+		return !validators.lastModified.After(since)
+	}
+
+	return false
+}
+
+// Uses the weak comparison, which is the one expected for If-None-Match
+func (validators *httpCacheValidators) matchesETag(ifNoneMatch string) bool {
+	expectedETag := strings.TrimPrefix(validators.etag, "W/")
+	for _, etag := range strings.Split(ifNoneMatch, ",") {
+		etag = strings.TrimSpace(etag)
+		if strings.TrimPrefix(etag, "W/") == expectedETag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// If-None-Match "*" matches any current representation. It can only be
+// checked once the output has started a successful response, because
+// the resource may not exist.
+func (validators *httpCacheValidators) matchesAnyETag(request *http.Request) bool {
+	for _, etag := range strings.Split(request.Header.Get("If-None-Match"), ",") {
+		if strings.TrimSpace(etag) == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (validators *httpCacheValidators) setHeaders(header http.Header) {
+	header.Set("ETag", validators.etag)
+	if !validators.lastModified.IsZero() {
+		header.Set("Last-Modified", validators.lastModified.Format(http.TimeFormat))
+	}
+}
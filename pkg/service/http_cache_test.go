@@ -0,0 +1,133 @@
+package service
+
+import (
+	inputPackage "github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	outputPackage "github.com/rodb-io/rodb/pkg/output"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetHttpCacheValidators(t *testing.T) {
+	parser := parser.NewMock()
+	input := inputPackage.NewMock(parser, []record.Record{
+		record.NewStringPropertiesMockRecord(map[string]string{"a": "b"}, 0),
+	})
+	modTime := time.Date(2021, 5, 3, 10, 20, 30, 500, time.UTC)
+	input.SetModTime(modTime)
+
+	output := outputPackage.NewMock(parser)
+	output.MockInputs = inputPackage.List{"mock": input}
+	route := &httpRoute{
+		config: HttpRouteConfig{Path: "/foo"},
+		output: output,
+	}
+
+	validators, err := getHttpCacheValidators(route, map[string]string{"id": "1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	t.Run("last modified", func(t *testing.T) {
+		if expect, got := modTime.Truncate(time.Second), validators.lastModified; !got.Equal(expect) {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("stable", func(t *testing.T) {
+		sameValidators, err := getHttpCacheValidators(route, map[string]string{"id": "1"})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := validators.etag, sameValidators.etag; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("different params", func(t *testing.T) {
+		otherValidators, err := getHttpCacheValidators(route, map[string]string{"id": "2"})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if otherValidators.etag == validators.etag {
+			t.Fatalf("Expected a different ETag, got '%v'", otherValidators.etag)
+		}
+	})
+	t.Run("modified input", func(t *testing.T) {
+		input.SetModTime(modTime.Add(time.Second))
+		defer input.SetModTime(modTime)
+
+		otherValidators, err := getHttpCacheValidators(route, map[string]string{"id": "1"})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if otherValidators.etag == validators.etag {
+			t.Fatalf("Expected a different ETag, got '%v'", otherValidators.etag)
+		}
+	})
+}
+
+func TestHttpCacheValidatorsIsNotModified(t *testing.T) {
+	validators := &httpCacheValidators{
+		etag:         `W/"abc"`,
+		lastModified: time.Date(2021, 5, 3, 10, 20, 30, 0, time.UTC),
+	}
+
+	for _, testCase := range []struct {
+		name            string
+		ifNoneMatch     string
+		ifModifiedSince string
+		expect          bool
+	}{
+		{"no condition", "", "", false},
+		{"same etag", `W/"abc"`, "", true},
+		{"strong etag", `"abc"`, "", true},
+		{"etag list", `"def", W/"abc"`, "", true},
+		{"any etag", "*", "", false},
+		{"different etag", `W/"def"`, "", false},
+		{"not modified since", "", "Mon, 03 May 2021 10:20:30 GMT", true},
+		{"modified since", "", "Mon, 03 May 2021 10:20:29 GMT", false},
+		{"invalid date", "", "yesterday", false},
+		{"etag has precedence", `W/"def"`, "Mon, 03 May 2021 10:20:30 GMT", false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := &http.Request{Header: http.Header{}}
+			if testCase.ifNoneMatch != "" {
+				request.Header.Set("If-None-Match", testCase.ifNoneMatch)
+			}
+			if testCase.ifModifiedSince != "" {
+				request.Header.Set("If-Modified-Since", testCase.ifModifiedSince)
+			}
+
+			if got := validators.isNotModified(request); got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestHttpCacheValidatorsMatchesAnyETag(t *testing.T) {
+	validators := &httpCacheValidators{etag: `W/"abc"`}
+
+	for _, testCase := range []struct {
+		name        string
+		ifNoneMatch string
+		expect      bool
+	}{
+		{"no condition", "", false},
+		{"any etag", "*", true},
+		{"etag list", `"def", *`, true},
+		{"same etag", `W/"abc"`, false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := &http.Request{Header: http.Header{}}
+			if testCase.ifNoneMatch != "" {
+				request.Header.Set("If-None-Match", testCase.ifNoneMatch)
+			}
+
+			if got := validators.matchesAnyETag(request); got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"compress/gzip"
+	"fmt"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Buffers the beginning of a response until it reaches the minimum size,
+// then compresses it using the negotiated encoding. The headers are only
+// written once we know wether or not the response will be compressed.
+type httpCompressedResponse struct {
+	response      http.ResponseWriter
+	config        *HttpCompressionConfig
+	encoding      string
+	status        int
+	buffer        []byte
+	encoder       io.WriteCloser
+	headerWritten bool
+}
+
+func newHttpCompressedResponse(
+	response http.ResponseWriter,
+	config *HttpCompressionConfig,
+	encoding string,
+	status int,
+) *httpCompressedResponse {
+	return &httpCompressedResponse{
+		response:      response,
+		config:        config,
+		encoding:      encoding,
+		status:        status,
+		buffer:        make([]byte, 0, *config.MinSize),
+		encoder:       nil,
+		headerWritten: false,
+	}
+}
+
+func (compressed *httpCompressedResponse) Write(data []byte) (int, error) {
+	if compressed.encoder != nil {
+		return compressed.encoder.Write(data)
+	}
+	if compressed.headerWritten {
+		return compressed.response.Write(data)
+	}
+
+	compressed.buffer = append(compressed.buffer, data...)
+	if len(compressed.buffer) >= *compressed.config.MinSize {
+		if err := compressed.startEncoder(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+func (compressed *httpCompressedResponse) startEncoder() error {
+	encoder, err := newHttpCompressionEncoder(compressed.response, compressed.encoding, compressed.config.Level)
+	if err != nil {
+		return err
+	}
+
+	compressed.response.Header().Set("Content-Encoding", compressed.encoding)
+	compressed.response.Header().Del("Content-Length")
+	compressed.response.WriteHeader(compressed.status)
+	compressed.headerWritten = true
+	compressed.encoder = encoder
+
+	if _, err := compressed.encoder.Write(compressed.buffer); err != nil {
+		return err
+	}
+	compressed.buffer = nil
+
+	return nil
+}
+
+// Flushes the remaining data. Responses smaller than the
+// minimum size are sent without any compression.
+func (compressed *httpCompressedResponse) Close() error {
+	if compressed.encoder != nil {
+		return compressed.encoder.Close()
+	}
+	if compressed.headerWritten {
+		return nil
+	}
+
+	compressed.response.WriteHeader(compressed.status)
+	compressed.headerWritten = true
+	_, err := compressed.response.Write(compressed.buffer)
+	compressed.buffer = nil
+
+	return err
+}
+
+func newHttpCompressionEncoder(writer io.Writer, encoding string, level string) (io.WriteCloser, error) {
+	switch encoding {
+	case "gzip":
+		gzipLevel := map[string]int{
+			"fastest": gzip.BestSpeed,
+			"default": gzip.DefaultCompression,
+			"best":    gzip.BestCompression,
+		}[level]
+		return gzip.NewWriterLevel(writer, gzipLevel)
+	case "br":
+		brotliLevel := map[string]int{
+			"fastest": brotli.BestSpeed,
+			"default": brotli.DefaultCompression,
+			"best":    brotli.BestCompression,
+		}[level]
+		return brotli.NewWriterLevel(writer, brotliLevel), nil
+	case "zstd":
+		zstdLevel := map[string]zstd.EncoderLevel{
+			"fastest": zstd.SpeedFastest,
+			"default": zstd.SpeedDefault,
+			"best":    zstd.SpeedBestCompression,
+		}[level]
+		return zstd.NewWriter(writer, zstd.WithEncoderLevel(zstdLevel))
+	default:
+		return nil, fmt.Errorf("The encoding '%v' is not supported by the HTTP service", encoding)
+	}
+}
+
+// Returns the preferred encoding accepted by the client, or an empty
+// string if none of the configured algorithms is acceptable.
+// When the client gives the same weight to multiple encodings,
+// the order of the configured algorithms is used.
+func getHttpAcceptedEncoding(acceptEncoding string, algorithms []string) string {
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name == "" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+
+			parsedWeight, err := strconv.ParseFloat(param[2:], 64)
+			if err != nil {
+				weight = 0
+			} else {
+				weight = parsedWeight
+			}
+		}
+
+		weights[name] = weight
+	}
+
+	bestEncoding := ""
+	bestWeight := 0.0
+	for _, algorithm := range algorithms {
+		weight, exists := weights[algorithm]
+		if !exists {
+			weight, exists = weights["*"]
+		}
+		if exists && weight > bestWeight {
+			bestEncoding = algorithm
+			bestWeight = weight
+		}
+	}
+
+	return bestEncoding
+}
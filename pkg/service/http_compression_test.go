@@ -0,0 +1,114 @@
+package service
+
+import (
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetHttpAcceptedEncoding(t *testing.T) {
+	algorithms := []string{"br", "zstd", "gzip"}
+	for _, testCase := range []struct {
+		name           string
+		acceptEncoding string
+		expect         string
+	}{
+		{"empty", "", ""},
+		{"single", "gzip", "gzip"},
+		{"unsupported", "deflate, compress", ""},
+		{"server preference", "gzip, br", "br"},
+		{"weights", "br;q=0.5, gzip;q=0.8", "gzip"},
+		{"refused", "br;q=0, gzip", "gzip"},
+		{"wildcard", "*", "br"},
+		{"wildcard refusing", "*, br;q=0", "zstd"},
+		{"case and spaces", " GZIP ; q=1.0 ", "gzip"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := getHttpAcceptedEncoding(testCase.acceptEncoding, algorithms); got != testCase.expect {
+				t.Fatalf("Expected '%v', got '%v'", testCase.expect, got)
+			}
+		})
+	}
+}
+
+func TestHttpCompressedResponse(t *testing.T) {
+	minSize := 10
+	config := &HttpCompressionConfig{
+		Algorithms: HttpCompressionAlgorithms,
+		MinSize:    &minSize,
+		Level:      "default",
+	}
+
+	decoders := map[string]func(reader io.Reader) (io.Reader, error){
+		"gzip": func(reader io.Reader) (io.Reader, error) {
+			return gzip.NewReader(reader)
+		},
+		"br": func(reader io.Reader) (io.Reader, error) {
+			return brotli.NewReader(reader), nil
+		},
+		"zstd": func(reader io.Reader) (io.Reader, error) {
+			return zstd.NewReader(reader)
+		},
+	}
+
+	for encoding, decoder := range decoders {
+		t.Run(encoding, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			compressed := newHttpCompressedResponse(recorder, config, encoding, http.StatusOK)
+
+			data := strings.Repeat("Hello World! ", 10)
+			for _, part := range []string{data[:5], data[5:]} {
+				if _, err := compressed.Write([]byte(part)); err != nil {
+					t.Fatalf("Unexpected error: '%+v'", err)
+				}
+			}
+			if err := compressed.Close(); err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+
+			if expect, got := encoding, recorder.Header().Get("Content-Encoding"); got != expect {
+				t.Fatalf("Expected Content-Encoding '%v', got '%v'", expect, got)
+			}
+
+			reader, err := decoder(recorder.Body)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			body, err := ioutil.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if got := string(body); got != data {
+				t.Fatalf("Expected body '%v', got '%v'", data, got)
+			}
+		})
+	}
+	t.Run("too small", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		compressed := newHttpCompressedResponse(recorder, config, "gzip", http.StatusOK)
+
+		data := "Hello"
+		if _, err := compressed.Write([]byte(data)); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if err := compressed.Close(); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if got := recorder.Header().Get("Content-Encoding"); got != "" {
+			t.Fatalf("Expected no Content-Encoding, got '%v'", got)
+		}
+		if got := recorder.Body.String(); got != data {
+			t.Fatalf("Expected body '%v', got '%v'", data, got)
+		}
+		if expect, got := http.StatusOK, recorder.Code; got != expect {
+			t.Fatalf("Expected status %v, got %v", expect, got)
+		}
+	})
+}
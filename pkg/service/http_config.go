@@ -7,16 +7,21 @@ import (
 	"github.com/rodb-io/rodb/pkg/util"
 	"github.com/sirupsen/logrus"
 	"os"
+	"strings"
 )
 
 type HttpConfig struct {
-	Name       string             `yaml:"name"`
-	Type       string             `yaml:"type"`
-	Http       *HttpHttpConfig    `yaml:"http"`
-	Https      *HttpHttpsConfig   `yaml:"https"`
-	ErrorsType string             `yaml:"errorsType"`
-	Routes     []*HttpRouteConfig `yaml:"routes"`
-	Logger     *logrus.Entry
+	Name           string                 `yaml:"name"`
+	Type           string                 `yaml:"type"`
+	Http           *HttpHttpConfig        `yaml:"http"`
+	Https          *HttpHttpsConfig       `yaml:"https"`
+	ErrorsType     string                 `yaml:"errorsType"`
+	Compression    *HttpCompressionConfig `yaml:"compression"`
+	Cache          *HttpCacheConfig       `yaml:"cache"`
+	CacheStatsPath string                 `yaml:"cacheStatsPath"`
+	ReloadPath     string                 `yaml:"reloadPath"`
+	Routes         []*HttpRouteConfig     `yaml:"routes"`
+	Logger         *logrus.Entry
 }
 
 type HttpHttpConfig struct {
@@ -29,9 +34,23 @@ type HttpHttpsConfig struct {
 	PrivateKeyPath  string `yaml:"privateKeyPath"`
 }
 
+type HttpCompressionConfig struct {
+	Algorithms []string `yaml:"algorithms"`
+	MinSize    *int     `yaml:"minSize"`
+	Level      string   `yaml:"level"`
+}
+
+type HttpCacheConfig struct {
+	MaxSize *int64 `yaml:"maxSize"`
+}
+
+var HttpCompressionAlgorithms = []string{"br", "zstd", "gzip"}
+var HttpCompressionLevels = []string{"fastest", "default", "best"}
+
 type HttpRouteConfig struct {
-	Output string `yaml:"output"`
-	Path   string `yaml:"path"`
+	Output       string `yaml:"output"`
+	Path         string `yaml:"path"`
+	CacheControl string `yaml:"cacheControl"`
 }
 
 func (config *HttpConfig) GetName() string {
@@ -64,6 +83,29 @@ func (config *HttpConfig) Validate(outputs map[string]output.Config, log *logrus
 		}
 	}
 
+	if config.Compression != nil {
+		if err := config.Compression.Validate(log); err != nil {
+			return fmt.Errorf("http.compression.%w", err)
+		}
+	}
+
+	if config.Cache != nil {
+		if err := config.Cache.Validate(log); err != nil {
+			return fmt.Errorf("http.cache.%w", err)
+		}
+	}
+
+	if config.CacheStatsPath != "" && !strings.HasPrefix(config.CacheStatsPath, "/") {
+		return errors.New("http.cacheStatsPath: The path must start with a '/'.")
+	}
+
+	if config.ReloadPath != "" && !strings.HasPrefix(config.ReloadPath, "/") {
+		return errors.New("http.reloadPath: The path must start with a '/'.")
+	}
+	if config.ReloadPath != "" && config.ReloadPath == config.CacheStatsPath {
+		return errors.New("http.reloadPath: The path is already used by cacheStatsPath.")
+	}
+
 	if len(config.Routes) == 0 {
 		return errors.New("routes is empty. At least one route is required to start an HTTP service.")
 	}
@@ -78,6 +120,13 @@ func (config *HttpConfig) Validate(outputs map[string]output.Config, log *logrus
 			return fmt.Errorf("http.routes[%v]: Duplicate path '%v' in array.", i, routeConfig.Path)
 		}
 		alreadyExistingPaths[routeConfig.Path] = true
+
+		if routeConfig.Path == config.CacheStatsPath {
+			return fmt.Errorf("http.routes[%v]: The path '%v' is already used by cacheStatsPath.", i, routeConfig.Path)
+		}
+		if routeConfig.Path == config.ReloadPath {
+			return fmt.Errorf("http.routes[%v]: The path '%v' is already used by reloadPath.", i, routeConfig.Path)
+		}
 	}
 
 	if !util.IsInArray(config.ErrorsType, []string{
@@ -127,6 +176,62 @@ func (config *HttpHttpsConfig) Validate(log *logrus.Entry) error {
 	return nil
 }
 
+func (config *HttpCompressionConfig) Validate(log *logrus.Entry) error {
+	if config.Algorithms == nil {
+		log.Debugf("http.compression.algorithms is not set. Defaulting to %v", HttpCompressionAlgorithms)
+		config.Algorithms = HttpCompressionAlgorithms
+	}
+	if len(config.Algorithms) == 0 {
+		return errors.New("algorithms: At least one algorithm is required to enable the compression.")
+	}
+
+	alreadyExistingAlgorithms := make(map[string]bool)
+	for i, algorithm := range config.Algorithms {
+		if !util.IsInArray(algorithm, HttpCompressionAlgorithms) {
+			allowedValues := `"` + strings.Join(HttpCompressionAlgorithms, `", "`) + `"`
+			return fmt.Errorf("algorithms[%v]: The algorithm '%v' is not supported. Allowed values: %v", i, algorithm, allowedValues)
+		}
+
+		if _, alreadyExists := alreadyExistingAlgorithms[algorithm]; alreadyExists {
+			return fmt.Errorf("algorithms[%v]: Duplicate algorithm '%v' in array.", i, algorithm)
+		}
+		alreadyExistingAlgorithms[algorithm] = true
+	}
+
+	if config.MinSize == nil {
+		defaultMinSize := 1024
+		log.Debugf("http.compression.minSize is not set. Defaulting to %v", defaultMinSize)
+		config.MinSize = &defaultMinSize
+	}
+	if *config.MinSize < 0 {
+		return errors.New("minSize: The value cannot be negative.")
+	}
+
+	if config.Level == "" {
+		log.Debug("http.compression.level is not set. Defaulting to 'default'")
+		config.Level = "default"
+	}
+	if !util.IsInArray(config.Level, HttpCompressionLevels) {
+		allowedValues := `"` + strings.Join(HttpCompressionLevels, `", "`) + `"`
+		return fmt.Errorf("level: The level '%v' is not supported. Allowed values: %v", config.Level, allowedValues)
+	}
+
+	return nil
+}
+
+func (config *HttpCacheConfig) Validate(log *logrus.Entry) error {
+	if config.MaxSize == nil {
+		defaultMaxSize := int64(64 * 1024 * 1024)
+		log.Debugf("http.cache.maxSize is not set. Defaulting to %v", defaultMaxSize)
+		config.MaxSize = &defaultMaxSize
+	}
+	if *config.MaxSize <= 0 {
+		return errors.New("maxSize: The value must be a positive and non-zero number.")
+	}
+
+	return nil
+}
+
 func (config *HttpRouteConfig) Validate(outputs map[string]output.Config, log *logrus.Entry) error {
 	if config.Output == "" {
 		return fmt.Errorf("output is empty. This field is required")
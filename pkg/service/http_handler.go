@@ -0,0 +1,425 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	"github.com/rodb-io/rodb/pkg/output"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/rodb-io/rodb/pkg/util"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+)
+
+const poweredBy = "RODB (https://rodb-io.github.io/rodb/)"
+
+// Handles the requests with a given configuration and outputs.
+// A reload of the service creates a new handler.
+type httpHandler struct {
+	config          *HttpConfig
+	routes          []*httpRoute
+	responseCache   *util.Lru
+	pendingRequests sync.WaitGroup
+	// Remove the callbacks registered by this handler
+	unsubscribes []func()
+}
+
+type httpRoute struct {
+	config     HttpRouteConfig
+	pathLock   sync.RWMutex
+	path       *regexp.Regexp
+	parameters []string
+	output     output.Output
+}
+
+func (route *httpRoute) getPath() *regexp.Regexp {
+	route.pathLock.RLock()
+	defer route.pathLock.RUnlock()
+
+	return route.path
+}
+
+func (route *httpRoute) setPath(path *regexp.Regexp) {
+	route.pathLock.Lock()
+	defer route.pathLock.Unlock()
+
+	route.path = path
+}
+
+func newHttpHandler(
+	config *HttpConfig,
+	outputs map[string]output.Output,
+) (*httpHandler, error) {
+	handler := &httpHandler{
+		config:        config,
+		responseCache: nil,
+	}
+
+	if config.Cache != nil {
+		handler.responseCache = util.NewLru(*config.Cache.MaxSize)
+	}
+
+	handler.routes = make([]*httpRoute, 0, len(config.Routes))
+	for _, route := range config.Routes {
+		output, outputExists := outputs[route.Output]
+		if !outputExists {
+			return nil, fmt.Errorf("Output '%v' not found in outputs list.", route.Output)
+		}
+
+		routePath, parameters, err := handler.createPathRegexp(*route, output)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot build regexp from route path '%v': %w", route.Path, err)
+		}
+
+		for _, paramName := range parameters {
+			if !output.HasParameter(paramName) {
+				return nil, fmt.Errorf("Output '%v' does not have a parameter called '%v'.", route.Output, paramName)
+			}
+		}
+
+		handlerRoute := &httpRoute{
+			config:     *route,
+			path:       routePath,
+			parameters: parameters,
+			output:     output,
+		}
+		handler.routes = append(handler.routes, handlerRoute)
+
+		// The patterns of some parsers (like the mappings loaded
+		// from an input) change when their values are reloaded
+		for _, paramName := range parameters {
+			paramParser, err := output.GetParameterParser(paramName)
+			if err != nil {
+				return nil, err
+			}
+			if changingParser, isChanging := paramParser.(parser.ChangingParser); isChanging {
+				handler.unsubscribes = append(handler.unsubscribes, changingParser.OnChange(func() {
+					handler.updatePathRegexp(handlerRoute)
+				}))
+			}
+		}
+	}
+
+	return handler, nil
+}
+
+// Returns the inputs used by the outputs of the routes
+func (handler *httpHandler) getInputs() map[input.Input]bool {
+	inputs := make(map[input.Input]bool)
+	for _, route := range handler.routes {
+		for _, routeInput := range route.output.Inputs() {
+			inputs[routeInput] = true
+		}
+	}
+
+	return inputs
+}
+
+// Removes the callbacks of the handler, once it has been replaced
+func (handler *httpHandler) close() {
+	for _, unsubscribe := range handler.unsubscribes {
+		unsubscribe()
+	}
+	handler.unsubscribes = nil
+}
+
+func (handler *httpHandler) isReloadRequest(request *http.Request) bool {
+	return handler.config.ReloadPath != "" &&
+		request.Method == http.MethodPost &&
+		request.URL.Path == handler.config.ReloadPath
+}
+
+// Returns a regular expression to match a string, and the list of param names
+// (matching the sub-expressions of the regexp)
+func (handler *httpHandler) createPathRegexp(
+	routeConfig HttpRouteConfig,
+	output output.Output,
+) (*regexp.Regexp, []string, error) {
+	paramRegexp, err := regexp.Compile("{([^}]+)}")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paramMatches := paramRegexp.FindAllStringSubmatch(routeConfig.Path, -1)
+	params := make([]string, len(paramMatches))
+	for i, paramMatch := range paramMatches {
+		params[i] = paramMatch[1]
+	}
+
+	parts := paramRegexp.Split(routeConfig.Path, -1)
+	path := parts[0]
+	for partIndex := 1; partIndex < len(parts); partIndex++ {
+		paramIndex := partIndex - 1
+		paramName := params[paramIndex]
+
+		paramParser, err := output.GetParameterParser(paramName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !paramParser.Primitive() {
+			return nil, nil, fmt.Errorf("Cannot use the parser '%v' as route parameter because it's not a primitive.", paramParser.Name())
+		}
+
+		paramPattern := paramParser.GetRegexpPattern()
+		path = path + "(" + paramPattern + ")" + parts[partIndex]
+	}
+
+	regexp, err := regexp.Compile("^" + path + "$")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return regexp, params, nil
+}
+
+func (handler *httpHandler) updatePathRegexp(route *httpRoute) {
+	routePath, _, err := handler.createPathRegexp(route.config, route.output)
+	if err != nil {
+		handler.config.Logger.Errorf("Cannot rebuild regexp from route path '%v': %v", route.config.Path, err)
+		return
+	}
+
+	route.setPath(routePath)
+}
+
+func (handler *httpHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("X-Powered-By", poweredBy)
+
+	if handler.config.CacheStatsPath != "" && request.Method == http.MethodGet && request.URL.Path == handler.config.CacheStatsPath {
+		if err := handler.sendCacheStats(response); err != nil {
+			handler.config.Logger.Errorf("Error while sending the cache statistics: %v", err)
+		}
+		return
+	}
+
+	route, pathMatches := handler.getMatchingRoute(request)
+	if route == nil {
+		errToSend := errors.New("No matching route was found")
+		err2 := handler.sendErrorResponse(response, http.StatusNotFound, errToSend)
+		if err2 != nil {
+			handler.config.Logger.Errorf("Error '%+v' while sending the error '%+v'", errToSend, err2)
+		}
+		return
+	}
+
+	payload, err := handler.getPayload(route, request.Body)
+	if err != nil {
+		err2 := handler.sendErrorResponse(response, http.StatusInternalServerError, err)
+		if err2 != nil {
+			handler.config.Logger.Errorf("Error '%+v' while sending the error '%+v'", err, err2)
+		}
+		return
+	}
+
+	params := handler.getParams(route, pathMatches, request.URL)
+
+	var validators *httpCacheValidators = nil
+	if request.Method == http.MethodGet {
+		validators, err = getHttpCacheValidators(route, params)
+		if err != nil {
+			err2 := handler.sendErrorResponse(response, http.StatusInternalServerError, err)
+			if err2 != nil {
+				handler.config.Logger.Errorf("Error '%+v' while sending the error '%+v'", err, err2)
+			}
+			return
+		}
+
+		if validators.isNotModified(request) {
+			handler.setSuccessHeaders(response, route, validators)
+			response.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	failed := false
+	sendError := func(err error) error {
+		failed = true
+		status := http.StatusInternalServerError
+		if errors.Is(err, record.RecordNotFoundError) {
+			status = http.StatusNotFound
+		}
+
+		return handler.sendErrorResponse(response, status, err)
+	}
+	var compressedResponse *httpCompressedResponse = nil
+	var cacheWriter *httpResponseCacheWriter = nil
+	sendSuccess := func() io.Writer {
+		var writer io.Writer
+		if validators != nil && validators.matchesAnyETag(request) {
+			handler.setSuccessHeaders(response, route, validators)
+			response.WriteHeader(http.StatusNotModified)
+			writer = ioutil.Discard
+		} else {
+			writer = handler.getSuccessWriter(request, response, route, validators, &compressedResponse)
+		}
+		if cacheWriter != nil {
+			return io.MultiWriter(writer, cacheWriter)
+		}
+
+		return writer
+	}
+
+	cacheKey := ""
+	if handler.responseCache != nil && request.Method == http.MethodGet {
+		cacheKey = getHttpResponseCacheKey(route, params)
+		if cachedBody, exists := handler.responseCache.Get(cacheKey); exists {
+			response.Header().Set("X-Cache", "HIT")
+			if _, err := sendSuccess().Write(cachedBody.([]byte)); err != nil {
+				handler.config.Logger.Errorf("Error while sending the cached response of the route '%v': %v", route.config.Path, err)
+			}
+			handler.closeCompressedResponse(route, compressedResponse)
+			return
+		}
+
+		response.Header().Set("X-Cache", "MISS")
+		cacheWriter = newHttpResponseCacheWriter(*handler.config.Cache.MaxSize - int64(len(cacheKey)))
+	}
+
+	handleErr := route.output.Handle(params, payload, sendError, sendSuccess)
+	if handleErr != nil {
+		handler.config.Logger.Errorf("Unhandled error while handling the route '%v': %v", route.config.Path, handleErr)
+	}
+	handler.closeCompressedResponse(route, compressedResponse)
+
+	if cacheWriter != nil && handleErr == nil && !failed {
+		if body := cacheWriter.Bytes(); body != nil {
+			handler.responseCache.Add(cacheKey, body, int64(len(cacheKey)+len(body)))
+		}
+	}
+}
+
+func (handler *httpHandler) getSuccessWriter(
+	request *http.Request,
+	response http.ResponseWriter,
+	route *httpRoute,
+	validators *httpCacheValidators,
+	compressedResponse **httpCompressedResponse,
+) io.Writer {
+	response.Header().Set("Content-Type", route.output.ResponseType()+"; charset=UTF-8")
+	handler.setSuccessHeaders(response, route, validators)
+
+	if handler.config.Compression != nil {
+		encoding := getHttpAcceptedEncoding(request.Header.Get("Accept-Encoding"), handler.config.Compression.Algorithms)
+		if encoding != "" {
+			*compressedResponse = newHttpCompressedResponse(response, handler.config.Compression, encoding, http.StatusOK)
+			return io.Writer(*compressedResponse)
+		}
+	}
+
+	response.WriteHeader(http.StatusOK)
+	return io.Writer(response)
+}
+
+func (handler *httpHandler) closeCompressedResponse(route *httpRoute, compressedResponse *httpCompressedResponse) {
+	if compressedResponse == nil {
+		return
+	}
+
+	if err := compressedResponse.Close(); err != nil {
+		handler.config.Logger.Errorf("Error while compressing the response of the route '%v': %v", route.config.Path, err)
+	}
+}
+
+// Sets the headers shared by the successful and the not modified responses
+func (handler *httpHandler) setSuccessHeaders(
+	response http.ResponseWriter,
+	route *httpRoute,
+	validators *httpCacheValidators,
+) {
+	if handler.config.Compression != nil {
+		response.Header().Add("Vary", "Accept-Encoding")
+	}
+	if route.config.CacheControl != "" {
+		response.Header().Set("Cache-Control", route.config.CacheControl)
+	}
+	if validators != nil {
+		validators.setHeaders(response.Header())
+	}
+}
+
+func (handler *httpHandler) sendErrorResponse(
+	response http.ResponseWriter,
+	status int,
+	err error,
+) error {
+	var data []byte
+	var outputType string = handler.config.ErrorsType
+	switch outputType {
+	case "application/json":
+		data, err = json.Marshal(map[string]interface{}{
+			"error": err.Error(),
+		})
+		if err != nil {
+			return err
+		}
+	default:
+		response.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+		response.WriteHeader(status)
+		_, err = response.Write([]byte(err.Error()))
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("ErrorResponse type '%v' is not supported by the HTTP service", handler.config.ErrorsType)
+	}
+
+	response.Header().Set("Content-Type", outputType+"; charset=UTF-8")
+	response.WriteHeader(status)
+	_, err = response.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Returns the matching route, with the submatches of it's path. The path
+// regexp can change at any time, so the submatches must not be computed again.
+func (handler *httpHandler) getMatchingRoute(request *http.Request) (*httpRoute, []string) {
+	for _, route := range handler.routes {
+		expectedPayloadType := route.output.ExpectedPayloadType()
+		isValidGet := (request.Method == http.MethodGet && expectedPayloadType == nil)
+		isValidPost := request.Method == http.MethodPost &&
+			expectedPayloadType != nil &&
+			request.Header.Get("Content-Type") == *expectedPayloadType
+		if !isValidGet && !isValidPost {
+			continue
+		}
+
+		if pathMatches := route.getPath().FindStringSubmatch(request.URL.Path); pathMatches != nil {
+			return route, pathMatches
+		}
+	}
+
+	return nil, nil
+}
+
+func (handler *httpHandler) getParams(route *httpRoute, pathMatches []string, url *url.URL) map[string]string {
+	// Getting params from the query string
+	params := make(map[string]string)
+	for k, v := range url.Query() {
+		params[k] = v[0]
+	}
+
+	// Adding params from the path's regex
+	for i, paramName := range route.parameters {
+		params[paramName] = pathMatches[i+1]
+	}
+
+	return params
+}
+
+func (handler *httpHandler) getPayload(route *httpRoute, body io.Reader) ([]byte, error) {
+	if route.output.ExpectedPayloadType() != nil {
+		return ioutil.ReadAll(body)
+	}
+
+	return make([]byte, 0), nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/rodb-io/rodb/pkg/util"
+	"net/http"
+	"net/url"
+)
+
+type httpCacheStats struct {
+	Responses *util.LruStats           `json:"responses"`
+	Records   map[string]util.LruStats `json:"records"`
+}
+
+// Implemented by the inputs having a records cache
+type httpCachedInput interface {
+	CacheStats() util.LruStats
+}
+
+// The parameters are sorted by the encoding, so that the
+// same request always gets the same key, whatever the order
+// of the query string's parameters
+func getHttpResponseCacheKey(route *httpRoute, params map[string]string) string {
+	values := url.Values{}
+	for paramName, paramValue := range params {
+		values.Set(paramName, paramValue)
+	}
+
+	return route.config.Path + "?" + values.Encode()
+}
+
+// Copies the body of a response to add it to the cache.
+// The copy stops as soon as the body cannot fit in the cache.
+type httpResponseCacheWriter struct {
+	buffer   bytes.Buffer
+	maxSize  int64
+	overflow bool
+}
+
+func newHttpResponseCacheWriter(maxSize int64) *httpResponseCacheWriter {
+	return &httpResponseCacheWriter{
+		maxSize:  maxSize,
+		overflow: maxSize < 0,
+	}
+}
+
+func (writer *httpResponseCacheWriter) Write(data []byte) (int, error) {
+	if writer.overflow {
+		return len(data), nil
+	}
+
+	if int64(writer.buffer.Len()+len(data)) > writer.maxSize {
+		writer.overflow = true
+		writer.buffer = bytes.Buffer{}
+		return len(data), nil
+	}
+
+	return writer.buffer.Write(data)
+}
+
+// Returns nil if the body is too large to be cached
+func (writer *httpResponseCacheWriter) Bytes() []byte {
+	if writer.overflow {
+		return nil
+	}
+
+	return writer.buffer.Bytes()
+}
+
+func (handler *httpHandler) getCacheStats() *httpCacheStats {
+	stats := &httpCacheStats{
+		Responses: nil,
+		Records:   make(map[string]util.LruStats),
+	}
+
+	if handler.responseCache != nil {
+		responseStats := handler.responseCache.Stats()
+		stats.Responses = &responseStats
+	}
+
+	for _, route := range handler.routes {
+		for inputName, input := range route.output.Inputs() {
+			if cachedInput, isCached := input.(httpCachedInput); isCached {
+				stats.Records[inputName] = cachedInput.CacheStats()
+			}
+		}
+	}
+
+	return stats
+}
+
+func (handler *httpHandler) sendCacheStats(response http.ResponseWriter) error {
+	data, err := json.Marshal(handler.getCacheStats())
+	if err != nil {
+		return err
+	}
+
+	response.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	response.Header().Set("Cache-Control", "no-store")
+	response.WriteHeader(http.StatusOK)
+	_, err = response.Write(data)
+
+	return err
+}
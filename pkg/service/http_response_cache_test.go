@@ -0,0 +1,152 @@
+package service
+
+import (
+	"encoding/json"
+	inputPackage "github.com/rodb-io/rodb/pkg/input"
+	"github.com/rodb-io/rodb/pkg/input/record"
+	outputPackage "github.com/rodb-io/rodb/pkg/output"
+	"github.com/rodb-io/rodb/pkg/parser"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGetHttpResponseCacheKey(t *testing.T) {
+	route := &httpRoute{
+		config: HttpRouteConfig{Path: "/foo"},
+	}
+
+	key := getHttpResponseCacheKey(route, map[string]string{"b": "2", "a": "1"})
+	if expect, got := "/foo?a=1&b=2", key; got != expect {
+		t.Fatalf("Expected '%v', got '%v'", expect, got)
+	}
+}
+
+func TestHttpResponseCacheWriter(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		writer := newHttpResponseCacheWriter(10)
+		for _, data := range []string{"Hello", " you"} {
+			if n, err := writer.Write([]byte(data)); err != nil || n != len(data) {
+				t.Fatalf("Unexpected write result: %v, %v", n, err)
+			}
+		}
+		if expect, got := "Hello you", string(writer.Bytes()); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("too large", func(t *testing.T) {
+		writer := newHttpResponseCacheWriter(10)
+		for _, data := range []string{"Hello", " world", "!"} {
+			if n, err := writer.Write([]byte(data)); err != nil || n != len(data) {
+				t.Fatalf("Unexpected write result: %v, %v", n, err)
+			}
+		}
+		if got := writer.Bytes(); got != nil {
+			t.Fatalf("Expected nil, got '%v'", string(got))
+		}
+	})
+	t.Run("key too large", func(t *testing.T) {
+		writer := newHttpResponseCacheWriter(-1)
+		if _, err := writer.Write([]byte{}); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if got := writer.Bytes(); got != nil {
+			t.Fatalf("Expected nil, got '%v'", string(got))
+		}
+	})
+}
+
+func TestHttpResponseCache(t *testing.T) {
+	maxSize := int64(1024 * 1024)
+	config := &HttpConfig{
+		Http: &HttpHttpConfig{
+			Listen: ":0", // Auto-assign port
+		},
+		ErrorsType:     "application/json",
+		Cache:          &HttpCacheConfig{MaxSize: &maxSize},
+		CacheStatsPath: "/stats",
+		Logger:         logrus.NewEntry(logrus.StandardLogger()),
+		Routes: []*HttpRouteConfig{
+			{
+				Path:   "/foo",
+				Output: "mock",
+			},
+		},
+	}
+
+	parser := parser.NewMock()
+	input := inputPackage.NewMock(parser, []record.Record{})
+	output := outputPackage.NewMock(parser)
+	output.MockInputs = inputPackage.List{"mock": input}
+
+	calls := 0
+	output.MockOutput = func(params map[string]string) ([]byte, error) {
+		calls++
+		return []byte("Hello " + params["name"] + "!"), nil
+	}
+
+	server, err := NewHttp(config, outputPackage.List{"mock": output}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	defer server.Close()
+
+	get := func(path string) (*http.Response, string) {
+		response, err := http.Get(server.Address() + path)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		defer response.Body.Close()
+
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		return response, string(body)
+	}
+
+	t.Run("miss and hit", func(t *testing.T) {
+		for i, expectCache := range []string{"MISS", "HIT"} {
+			response, body := get("/foo?name=Universe")
+			if expect, got := "Hello Universe!", body; got != expect {
+				t.Fatalf("Expected body '%+v', got '%+v'", expect, got)
+			}
+			if got := response.Header.Get("X-Cache"); got != expectCache {
+				t.Fatalf("Request %v: expected X-Cache '%+v', got '%+v'", i, expectCache, got)
+			}
+		}
+		if expect, got := 1, calls; got != expect {
+			t.Fatalf("Expected the output to be called %v times, got %v", expect, got)
+		}
+	})
+	t.Run("invalidation", func(t *testing.T) {
+		input.TriggerChange()
+
+		response, _ := get("/foo?name=Universe")
+		if expect, got := "MISS", response.Header.Get("X-Cache"); got != expect {
+			t.Fatalf("Expected X-Cache '%+v', got '%+v'", expect, got)
+		}
+		if expect, got := 2, calls; got != expect {
+			t.Fatalf("Expected the output to be called %v times, got %v", expect, got)
+		}
+	})
+	t.Run("stats", func(t *testing.T) {
+		_, body := get("/stats")
+
+		stats := &httpCacheStats{}
+		if err := json.Unmarshal([]byte(body), stats); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if stats.Responses == nil {
+			t.Fatalf("Expected to get the responses statistics")
+		}
+		if expect, got := uint64(1), stats.Responses.Hits; got != expect {
+			t.Fatalf("Expected %v hits, got %v", expect, got)
+		}
+		if expect, got := uint64(2), stats.Responses.Misses; got != expect {
+			t.Fatalf("Expected %v misses, got %v", expect, got)
+		}
+	})
+}
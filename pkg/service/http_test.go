@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/json"
+	"github.com/rodb-io/rodb/pkg/input"
 	"github.com/rodb-io/rodb/pkg/input/record"
 	outputPackage "github.com/rodb-io/rodb/pkg/output"
 	"github.com/rodb-io/rodb/pkg/parser"
@@ -12,6 +13,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestHttp(t *testing.T) {
@@ -23,8 +25,9 @@ func TestHttp(t *testing.T) {
 		Logger:     logrus.NewEntry(logrus.StandardLogger()),
 		Routes: []*HttpRouteConfig{
 			{
-				Path:   "/foo",
-				Output: "mock",
+				Path:         "/foo",
+				Output:       "mock",
+				CacheControl: "public, max-age=60",
 			},
 		},
 	}
@@ -33,7 +36,7 @@ func TestHttp(t *testing.T) {
 	outputs := outputPackage.List{
 		"mock": output,
 	}
-	server, err := NewHttp(config, outputs)
+	server, err := NewHttp(config, outputs, nil)
 	if err != nil {
 		t.Fatalf("Unexpected error: '%+v'", err)
 	}
@@ -65,6 +68,72 @@ func TestHttp(t *testing.T) {
 			t.Fatalf("Expected Content-Type starting with '%+v', got '%+v'", expect, got)
 		}
 	})
+	t.Run("not modified", func(t *testing.T) {
+		output.MockOutput = func(params map[string]string) ([]byte, error) {
+			return []byte("Hello " + params["name"] + "!"), nil
+		}
+
+		response, err := http.Get(server.Address() + "/foo?name=Universe")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		response.Body.Close()
+
+		etag := response.Header.Get("ETag")
+		if etag == "" {
+			t.Fatalf("Expected to get an ETag header")
+		}
+		if got, expect := response.Header.Get("Cache-Control"), "public, max-age=60"; got != expect {
+			t.Fatalf("Expected Cache-Control '%+v', got '%+v'", expect, got)
+		}
+
+		request, err := http.NewRequest(http.MethodGet, server.Address()+"/foo?name=Universe", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		request.Header.Set("If-None-Match", etag)
+		response, err = http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		response.Body.Close()
+
+		if expect, got := http.StatusNotModified, response.StatusCode; got != expect {
+			t.Fatalf("Expected status %+v, got '%+v'", expect, got)
+		}
+		if got := response.Header.Get("ETag"); got != etag {
+			t.Fatalf("Expected ETag '%+v', got '%+v'", etag, got)
+		}
+	})
+	t.Run("not modified any", func(t *testing.T) {
+		for _, testCase := range []struct {
+			name   string
+			err    error
+			expect int
+		}{
+			{"found", nil, http.StatusNotModified},
+			{"not found", record.RecordNotFoundError, http.StatusNotFound},
+		} {
+			output.MockOutput = func(params map[string]string) ([]byte, error) {
+				return []byte("Hello!"), testCase.err
+			}
+
+			request, err := http.NewRequest(http.MethodGet, server.Address()+"/foo", nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			request.Header.Set("If-None-Match", "*")
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			response.Body.Close()
+
+			if got := response.StatusCode; got != testCase.expect {
+				t.Fatalf("%v: Expected status %+v, got '%+v'", testCase.name, testCase.expect, got)
+			}
+		}
+	})
 	t.Run("404", func(t *testing.T) {
 		output.MockOutput = func(params map[string]string) ([]byte, error) {
 			return nil, record.RecordNotFoundError
@@ -99,6 +168,170 @@ func TestHttp(t *testing.T) {
 	})
 }
 
+func TestHttpReload(t *testing.T) {
+	newConfig := func(path string) *HttpConfig {
+		return &HttpConfig{
+			Http: &HttpHttpConfig{
+				Listen: ":0", // Auto-assign port
+			},
+			ErrorsType: "application/json",
+			ReloadPath: "/reload",
+			Logger:     logrus.NewEntry(logrus.StandardLogger()),
+			Routes: []*HttpRouteConfig{
+				{
+					Path:   path,
+					Output: "mock",
+				},
+			},
+		}
+	}
+	get := func(url string) (int, string) {
+		response, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		defer response.Body.Close()
+
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		return response.StatusCode, string(body)
+	}
+
+	parser := parser.NewMock()
+	oldInput := input.NewMock(parser, []record.Record{})
+	oldOutput := outputPackage.NewMock(parser)
+	oldOutput.MockInputs = input.List{"input": oldInput}
+	oldOutput.MockOutput = func(params map[string]string) ([]byte, error) {
+		return []byte("old"), nil
+	}
+	newInput := input.NewMock(parser, []record.Record{})
+	newOutput := outputPackage.NewMock(parser)
+	newOutput.MockInputs = input.List{"input": newInput}
+	newOutput.MockOutput = func(params map[string]string) ([]byte, error) {
+		return []byte("new"), nil
+	}
+
+	reloadCount := 0
+	server, err := NewHttp(newConfig("/foo"), outputPackage.List{"mock": oldOutput}, func() error {
+		reloadCount++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	defer server.Close()
+	address := server.Address()
+
+	t.Run("apply", func(t *testing.T) {
+		apply, err := server.Reload(newConfig("/bar"), outputPackage.List{"mock": newOutput})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if _, body := get(address + "/foo"); body != "old" {
+			t.Fatalf("Expected the reload to not be applied yet, got '%v'", body)
+		}
+
+		apply()
+
+		if got := server.Address(); got != address {
+			t.Fatalf("Expected the address '%v' to be kept, got '%v'", address, got)
+		}
+		if status, _ := get(address + "/foo"); status != http.StatusNotFound {
+			t.Fatalf("Expected status '%v', got '%v'", http.StatusNotFound, status)
+		}
+		if _, body := get(address + "/bar"); body != "new" {
+			t.Fatalf("Expected 'new', got '%v'", body)
+		}
+
+		if _, isWatched := server.watchedInputs[oldInput]; isWatched {
+			t.Fatalf("Expected the replaced input not to be watched anymore")
+		}
+		if _, isWatched := server.watchedInputs[newInput]; !isWatched {
+			t.Fatalf("Expected the new input to be watched")
+		}
+	})
+	t.Run("drain", func(t *testing.T) {
+		started := make(chan bool)
+		release := make(chan bool)
+		newOutput.MockOutput = func(params map[string]string) ([]byte, error) {
+			started <- true
+			<-release
+			return []byte("new"), nil
+		}
+
+		go func() {
+			if response, err := http.Get(address + "/bar"); err == nil {
+				response.Body.Close()
+			}
+		}()
+		<-started
+
+		apply, err := server.Reload(newConfig("/bar"), outputPackage.List{"mock": oldOutput})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		applied := make(chan bool)
+		go func() {
+			apply()
+			applied <- true
+		}()
+
+		select {
+		case <-applied:
+			t.Fatalf("Expected the reload to wait for the pending request")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		release <- true
+		<-applied
+
+		if _, body := get(address + "/bar"); body != "old" {
+			t.Fatalf("Expected 'old', got '%v'", body)
+		}
+	})
+	t.Run("different listener", func(t *testing.T) {
+		config := newConfig("/bar")
+		config.Http.Listen = "127.0.0.1:0"
+		if _, err := server.Reload(config, outputPackage.List{"mock": newOutput}); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("endpoint", func(t *testing.T) {
+		response, err := http.Post(address+"/reload", "application/json", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		response.Body.Close()
+
+		if expect, got := http.StatusOK, response.StatusCode; got != expect {
+			t.Fatalf("Expected status '%v', got '%v'", expect, got)
+		}
+		if expect, got := 1, reloadCount; got != expect {
+			t.Fatalf("Expected '%v' reloads, got '%v'", expect, got)
+		}
+	})
+}
+
+func TestIsLoopbackRequest(t *testing.T) {
+	for remoteAddr, expect := range map[string]bool{
+		"127.0.0.1:1234":   true,
+		"[::1]:1234":       true,
+		"192.168.1.2:1234": false,
+		"[2001:db8::1]:80": false,
+		"invalid":          false,
+	} {
+		t.Run(remoteAddr, func(t *testing.T) {
+			if got := isLoopbackRequest(&http.Request{RemoteAddr: remoteAddr}); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		})
+	}
+}
+
 func TestHttpOutputList(t *testing.T) {
 	config := &HttpConfig{
 		Http: &HttpHttpConfig{
@@ -125,19 +358,19 @@ func TestHttpOutputList(t *testing.T) {
 		"foo": outputFoo,
 		"bar": outputBar,
 		"baz": outputBaz,
-	})
+	}, nil)
 	defer server.Close()
 	if err != nil {
 		t.Fatalf("Unexpected error: '%+v'", err)
 	}
 
-	if expect, got := 2, len(server.routes); got != expect {
+	if expect, got := 2, len(server.handler.routes); got != expect {
 		t.Fatalf("Expected the server to hande %v routes, got %v", expect, got)
 	}
-	if expect, got := outputFoo, server.routes[0].output; got != expect {
+	if expect, got := outputFoo, server.handler.routes[0].output; got != expect {
 		t.Fatalf("Expected the first route to be '%+v' routes, got '%+v'", expect, got)
 	}
-	if expect, got := outputBaz, server.routes[1].output; got != expect {
+	if expect, got := outputBaz, server.handler.routes[1].output; got != expect {
 		t.Fatalf("Expected the first route to be '%+v' routes, got '%+v'", expect, got)
 	}
 }
@@ -167,7 +400,7 @@ func TestHttpGetMatchingRoute(t *testing.T) {
 		t.Fatalf("Unexpected error: '%+v'", err)
 	}
 
-	server := &Http{
+	handler := &httpHandler{
 		routes: []*httpRoute{
 			{
 				path:   getFooRegexp,
@@ -191,10 +424,11 @@ func TestHttpGetMatchingRoute(t *testing.T) {
 
 	t.Run("get", func(t *testing.T) {
 		expect := getBarOutput
-		got := server.getMatchingRoute(&http.Request{
+		route, _ := handler.getMatchingRoute(&http.Request{
 			Method: "GET",
 			URL:    requestUrl,
-		}).output
+		})
+		got := route.output
 		if got != expect {
 			t.Fatalf("Expected to get route '%+v', got '%+v'", expect, got)
 		}
@@ -203,11 +437,12 @@ func TestHttpGetMatchingRoute(t *testing.T) {
 		expect := postBarOutput
 		requestHeader := http.Header(map[string][]string{})
 		requestHeader.Set("Content-Type", payloadType)
-		got := server.getMatchingRoute(&http.Request{
+		route, _ := handler.getMatchingRoute(&http.Request{
 			Method: "POST",
 			URL:    requestUrl,
 			Header: requestHeader,
-		}).output
+		})
+		got := route.output
 		if got != expect {
 			t.Fatalf("Expected to get route '%+v', got '%+v'", expect, got)
 		}
@@ -216,7 +451,7 @@ func TestHttpGetMatchingRoute(t *testing.T) {
 		var expect *httpRoute = nil
 		requestHeader := http.Header(map[string][]string{})
 		requestHeader.Set("Content-Type", "application/xml")
-		got := server.getMatchingRoute(&http.Request{
+		got, _ := handler.getMatchingRoute(&http.Request{
 			Method: "POST",
 			URL:    requestUrl,
 			Header: requestHeader,
@@ -244,8 +479,8 @@ func TestHttpGetParams(t *testing.T) {
 			parameters: []string{"id"},
 		}
 
-		server := &Http{}
-		params := server.getParams(route, url)
+		handler := &httpHandler{}
+		params := handler.getParams(route, regexp.FindStringSubmatch(url.Path), url)
 
 		if got := params["id"]; got != "42" {
 			t.Fatalf("Expected param 'id' to be '42', got '%+v'", got)
@@ -266,12 +501,12 @@ func TestHttpGetPayload(t *testing.T) {
 		output := outputPackage.NewMock(parser)
 		output.MockPayloadType = &payloadType
 
-		server := &Http{}
+		handler := &httpHandler{}
 
 		data := "Hello World!"
 		body := strings.NewReader(data)
 
-		payload, err := server.getPayload(&httpRoute{output: output}, body)
+		payload, err := handler.getPayload(&httpRoute{output: output}, body)
 		if err != nil {
 			t.Fatalf("Unexpected error: '%+v'", err)
 		}
@@ -284,9 +519,9 @@ func TestHttpGetPayload(t *testing.T) {
 		output := outputPackage.NewMock(parser)
 		output.MockPayloadType = nil
 
-		server := &Http{}
+		handler := &httpHandler{}
 
-		payload, err := server.getPayload(&httpRoute{output: output}, nil)
+		payload, err := handler.getPayload(&httpRoute{output: output}, nil)
 		if err != nil {
 			t.Fatalf("Unexpected error: '%+v'", err)
 		}
@@ -300,9 +535,9 @@ func TestHttpCreatePathRegexp(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		parser := parser.NewMock()
 		output := outputPackage.NewMock(parser)
-		server := &Http{}
+		handler := &httpHandler{}
 
-		regexp, params, err := server.createPathRegexp(HttpRouteConfig{
+		regexp, params, err := handler.createPathRegexp(HttpRouteConfig{
 			Path: "/foo/{foo_id}/bar-{bar}-id",
 		}, output)
 		if err != nil {
@@ -334,12 +569,45 @@ func TestHttpCreatePathRegexp(t *testing.T) {
 			t.Fatalf("Expected to get '%+v' as a third match, got '%+v'", expect, got)
 		}
 	})
+	t.Run("regexp params", func(t *testing.T) {
+		regexpConfig := &parser.RegexpConfig{Name: "regexp", Pattern: "[0-9]+"}
+		if err := regexpConfig.Validate(map[string]parser.Config{
+			"string": &parser.StringConfig{Name: "string"},
+		}, logrus.NewEntry(logrus.StandardLogger())); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		output := outputPackage.NewMock(parser.NewRegexp(regexpConfig, parser.List{"string": parser.NewMock()}))
+		handler := &httpHandler{}
+
+		regexp, _, err := handler.createPathRegexp(HttpRouteConfig{
+			Path: "/foo/{a}/{b}",
+		}, output)
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		matches := regexp.FindStringSubmatch("/foo/id-12/34")
+		if expect, got := 3, len(matches); got != expect {
+			t.Fatalf("Expected to get %v match results, got %+v", expect, got)
+		}
+		if expect, got := "id-12", matches[1]; got != expect {
+			t.Fatalf("Expected to get '%v' as a second match, got '%+v'", expect, got)
+		}
+		if expect, got := "34", matches[2]; got != expect {
+			t.Fatalf("Expected to get '%v' as a third match, got '%+v'", expect, got)
+		}
+
+		testPath := "/foo/1/2/3"
+		if regexp.MatchString(testPath) {
+			t.Fatalf("Expected the regexp to not match the path '%+v'", testPath)
+		}
+	})
 	t.Run("no params", func(t *testing.T) {
 		parser := parser.NewMock()
 		output := outputPackage.NewMock(parser)
-		server := &Http{}
+		handler := &httpHandler{}
 
-		regexp, params, err := server.createPathRegexp(HttpRouteConfig{
+		regexp, params, err := handler.createPathRegexp(HttpRouteConfig{
 			Path: "/foo",
 		}, output)
 		if err != nil {
@@ -356,3 +624,56 @@ func TestHttpCreatePathRegexp(t *testing.T) {
 		}
 	})
 }
+
+func TestHttpUpdatePathRegexp(t *testing.T) {
+	mappingConfig := &parser.MappingConfig{
+		Name:          "mapping",
+		Input:         "input",
+		KeyProperty:   "key",
+		ValueProperty: "value",
+	}
+	if err := mappingConfig.Validate(map[string]parser.Config{}, logrus.NewEntry(logrus.StandardLogger())); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	mapping := parser.NewMapping(mappingConfig)
+
+	handler, err := newHttpHandler(&HttpConfig{
+		Routes: []*HttpRouteConfig{{Output: "output", Path: "/foo/{foo}"}},
+		Logger: logrus.NewEntry(logrus.StandardLogger()),
+	}, map[string]outputPackage.Output{
+		"output": outputPackage.NewMock(mapping),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	request := &http.Request{Method: "GET", URL: &url.URL{Path: "/foo/a"}}
+	if got, _ := handler.getMatchingRoute(request); got != nil {
+		t.Fatalf("Expected no route to match before loading the mapping, got '%+v'", got)
+	}
+
+	if err := mapping.SetValues(map[string]interface{}{"a": "b"}); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	route, pathMatches := handler.getMatchingRoute(request)
+	if route == nil {
+		t.Fatalf("Expected the route to match the new values of the mapping")
+	}
+
+	// The parameters of a matched request are kept when the regexp changes
+	if err := mapping.SetValues(map[string]interface{}{"c": "d"}); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if got := handler.getParams(route, pathMatches, request.URL)["foo"]; got != "a" {
+		t.Fatalf("Expected 'a', got '%+v'", got)
+	}
+
+	// A replaced handler does not follow the changes anymore
+	handler.close()
+	if err := mapping.SetValues(map[string]interface{}{"e": "f"}); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+	if got, _ := handler.getMatchingRoute(&http.Request{Method: "GET", URL: &url.URL{Path: "/foo/e"}}); got != nil {
+		t.Fatalf("Expected the closed handler to keep it's previous regexp, got '%+v'", got)
+	}
+}
@@ -1,6 +1,8 @@
 package service
 
-import ()
+import (
+	"github.com/rodb-io/rodb/pkg/output"
+)
 
 type Mock struct {
 }
@@ -17,6 +19,10 @@ func (service *Mock) Address() string {
 	return ""
 }
 
+func (service *Mock) Reload(config Config, outputs map[string]output.Output) (func(), error) {
+	return func() {}, nil
+}
+
 func (service *Mock) Wait() error {
 	return nil
 }
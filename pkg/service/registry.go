@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"github.com/rodb-io/rodb/pkg/output"
+	"reflect"
+	"sort"
+)
+
+// Creates a service from its validated config, and the
+// outputs it serves.
+type Constructor = func(config Config, outputs map[string]output.Output, reload ReloadFunc) (Service, error)
+
+type registration struct {
+	newConfig   func() Config
+	constructor Constructor
+}
+
+var registrations = map[string]*registration{}
+var registrationsByConfigType = map[reflect.Type]*registration{}
+
+func init() {
+	Register("http", func() Config { return &HttpConfig{} }, func(config Config, outputs map[string]output.Output, reload ReloadFunc) (Service, error) {
+		return NewHttp(config.(*HttpConfig), outputs, reload)
+	})
+}
+
+// Makes a type of service available under the given "type" value.
+func Register(typeName string, newConfig func() Config, constructor Constructor) {
+	if _, exists := registrations[typeName]; exists {
+		panic(fmt.Sprintf("The service type '%v' is already registered.", typeName))
+	}
+
+	configType := reflect.TypeOf(newConfig())
+	if _, exists := registrationsByConfigType[configType]; exists {
+		panic(fmt.Sprintf("The service config type '%v' is already registered.", configType))
+	}
+
+	registration := &registration{
+		newConfig:   newConfig,
+		constructor: constructor,
+	}
+	registrations[typeName] = registration
+	registrationsByConfigType[configType] = registration
+}
+
+// Returns a new, empty config of the given service type
+func NewConfig(typeName string) (Config, bool) {
+	registration, exists := registrations[typeName]
+	if !exists {
+		return nil, false
+	}
+
+	return registration.newConfig(), true
+}
+
+// Returns the sorted names of the registered service types
+func TypeNames() []string {
+	typeNames := make([]string, 0, len(registrations))
+	for typeName := range registrations {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	return typeNames
+}
@@ -4,15 +4,26 @@ import (
 	"fmt"
 	"github.com/rodb-io/rodb/pkg/output"
 	"github.com/sirupsen/logrus"
+	"reflect"
+	"sync"
 )
 
 type Service interface {
 	Name() string
 	Address() string
+
+	// Prepares the replacement of the configuration and outputs of the
+	// service. The returned function applies it, and returns once the
+	// requests that were using the previous outputs are finished.
+	Reload(config Config, outputs map[string]output.Output) (func(), error)
+
 	Wait() error
 	Close() error
 }
 
+// Reloads the whole configuration. The services can trigger it.
+type ReloadFunc = func() error
+
 type Config interface {
 	Validate(outputs map[string]output.Config, log *logrus.Entry) error
 	GetName() string
@@ -23,23 +34,25 @@ type List = map[string]Service
 func NewFromConfig(
 	config Config,
 	outputs map[string]output.Output,
+	reload ReloadFunc,
 ) (Service, error) {
-	switch config.(type) {
-	case *HttpConfig:
-		return NewHttp(config.(*HttpConfig), outputs)
-	default:
+	registration, exists := registrationsByConfigType[reflect.TypeOf(config)]
+	if !exists {
 		return nil, fmt.Errorf("Unknown service config type: %#v", config)
 	}
+
+	return registration.constructor(config, outputs, reload)
 }
 
 func NewFromConfigs(
 	configs map[string]Config,
 	outputs map[string]output.Output,
+	reload ReloadFunc,
 	log *logrus.Logger,
 ) (List, error) {
 	services := make(List)
 	for serviceName, serviceConfig := range configs {
-		service, err := NewFromConfig(serviceConfig, outputs)
+		service, err := NewFromConfig(serviceConfig, outputs, reload)
 		if err != nil {
 			return nil, err
 		}
@@ -51,6 +64,46 @@ func NewFromConfigs(
 	return services, nil
 }
 
+// Prepares the reload of all the services, which must be the same as the
+// running ones. Nothing is applied unless all the services are ready.
+func Reload(
+	services List,
+	configs map[string]Config,
+	outputs map[string]output.Output,
+) (func(), error) {
+	for serviceName := range services {
+		if _, exists := configs[serviceName]; !exists {
+			return nil, fmt.Errorf("The service '%v' can not be removed without restarting.", serviceName)
+		}
+	}
+
+	applyFunctions := make([]func(), 0, len(configs))
+	for serviceName, serviceConfig := range configs {
+		service, exists := services[serviceName]
+		if !exists {
+			return nil, fmt.Errorf("The service '%v' can not be added without restarting.", serviceName)
+		}
+
+		apply, err := service.Reload(serviceConfig, outputs)
+		if err != nil {
+			return nil, fmt.Errorf("%v service: %w", serviceName, err)
+		}
+		applyFunctions = append(applyFunctions, apply)
+	}
+
+	return func() {
+		waitGroup := sync.WaitGroup{}
+		for _, apply := range applyFunctions {
+			waitGroup.Add(1)
+			go func(apply func()) {
+				defer waitGroup.Done()
+				apply()
+			}(apply)
+		}
+		waitGroup.Wait()
+	}, nil
+}
+
 func Wait(services List) error {
 	for serviceName, service := range services {
 		if err := service.Wait(); err != nil {
@@ -3,6 +3,7 @@ package util
 import (
 	"bufio"
 	"io"
+	"math"
 )
 
 func GetBufferedReaderOffset(
@@ -19,17 +20,9 @@ func GetBufferedReaderOffset(
 	return offset - bufferSize, nil
 }
 
-func SetBufferedReaderOffset(
-	reader io.ReadSeeker,
-	buffer *bufio.Reader,
-	offset int64,
-) error {
-	_, err := reader.Seek(offset, io.SeekStart)
-	if err != nil {
-		return err
-	}
-
-	buffer.Reset(reader)
-
-	return nil
+// Creates a buffered reader starting at the given offset.
+// Since it only relies on ReadAt, any number of readers can
+// concurrently read the same file without any lock.
+func NewBufferedReaderAt(reader io.ReaderAt, offset int64) *bufio.Reader {
+	return bufio.NewReader(io.NewSectionReader(reader, offset, math.MaxInt64-offset))
 }
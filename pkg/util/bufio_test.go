@@ -36,16 +36,10 @@ func TestGetBufferedReaderOffset(t *testing.T) {
 	})
 }
 
-func TestSetBufferedReaderOffset(t *testing.T) {
+func TestNewBufferedReaderAt(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		reader := strings.NewReader("abcdef")
-		buffer := bufio.NewReader(reader)
-		if _, err := buffer.Read(make([]byte, 5)); err != nil {
-			t.Fatalf("Unexpected error: '%v'", err)
-		}
-		if err := SetBufferedReaderOffset(reader, buffer, 1); err != nil {
-			t.Fatalf("Unexpected error: '%v'", err)
-		}
+		buffer := NewBufferedReaderAt(reader, 1)
 
 		data := make([]byte, 2)
 		if _, err := buffer.Read(data); err != nil {
@@ -56,4 +50,25 @@ func TestSetBufferedReaderOffset(t *testing.T) {
 			t.Fatalf("Expected to get '%v', got '%v'", expect, string(data))
 		}
 	})
+	t.Run("concurrent", func(t *testing.T) {
+		reader := strings.NewReader("abcdef")
+		first := NewBufferedReaderAt(reader, 1)
+		second := NewBufferedReaderAt(reader, 4)
+
+		for _, testCase := range []struct {
+			buffer *bufio.Reader
+			expect string
+		}{
+			{second, "ef"},
+			{first, "bc"},
+		} {
+			data := make([]byte, 2)
+			if _, err := testCase.buffer.Read(data); err != nil {
+				t.Fatalf("Unexpected error: '%v'", err)
+			}
+			if string(data) != testCase.expect {
+				t.Fatalf("Expected to get '%v', got '%v'", testCase.expect, string(data))
+			}
+		}
+	})
 }
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"sync"
+)
+
+// A list of callbacks, in which each callback can be removed
+// by the one which added it. It is safe for concurrent use.
+type Callbacks struct {
+	lock    sync.Mutex
+	lastId  uint64
+	entries []callbackEntry
+}
+
+type callbackEntry struct {
+	id       uint64
+	callback func()
+}
+
+// Adds the callback, and returns a function removing it
+func (callbacks *Callbacks) Add(callback func()) func() {
+	callbacks.lock.Lock()
+	defer callbacks.lock.Unlock()
+
+	callbacks.lastId++
+	id := callbacks.lastId
+	callbacks.entries = append(callbacks.entries, callbackEntry{
+		id:       id,
+		callback: callback,
+	})
+
+	return func() {
+		callbacks.remove(id)
+	}
+}
+
+func (callbacks *Callbacks) remove(id uint64) {
+	callbacks.lock.Lock()
+	defer callbacks.lock.Unlock()
+
+	entries := make([]callbackEntry, 0, len(callbacks.entries))
+	for _, entry := range callbacks.entries {
+		if entry.id != id {
+			entries = append(entries, entry)
+		}
+	}
+	callbacks.entries = entries
+}
+
+// Calls the callbacks in the order in which they were added
+func (callbacks *Callbacks) Call() {
+	callbacks.lock.Lock()
+	entries := callbacks.entries
+	callbacks.lock.Unlock()
+
+	for _, entry := range entries {
+		entry.callback()
+	}
+}
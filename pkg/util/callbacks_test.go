@@ -0,0 +1,27 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCallbacks(t *testing.T) {
+	calls := make([]string, 0)
+	callbacks := &Callbacks{}
+	callbacks.Add(func() { calls = append(calls, "a") })
+	removeB := callbacks.Add(func() { calls = append(calls, "b") })
+	callbacks.Add(func() { calls = append(calls, "c") })
+
+	callbacks.Call()
+	if expect := []string{"a", "b", "c"}; !reflect.DeepEqual(expect, calls) {
+		t.Fatalf("Expected '%v', got '%v'", expect, calls)
+	}
+
+	removeB()
+	removeB()
+	calls = make([]string, 0)
+	callbacks.Call()
+	if expect := []string{"a", "c"}; !reflect.DeepEqual(expect, calls) {
+		t.Fatalf("Expected '%v', got '%v'", expect, calls)
+	}
+}
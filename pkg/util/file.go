@@ -0,0 +1,46 @@
+package util
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// A file that can only be read using positional reads,
+// which allows concurrent reads without any lock
+type ReadOnlyFile interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// Opens the given file for positional reads, either as a regular file
+// or as a memory-mapped one. When preloading, the whole file is read
+// once, so that it's data is already in the OS page cache.
+func OpenReadOnlyFile(path string, useMmap bool, preload bool) (ReadOnlyFile, error) {
+	if useMmap {
+		file, err := OpenMmapFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if preload {
+			file.Preload()
+		}
+
+		return file, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if preload {
+		if _, err := io.Copy(ioutil.Discard, file); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	return file, nil
+}
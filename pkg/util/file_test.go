@@ -0,0 +1,34 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestOpenReadOnlyFile(t *testing.T) {
+	for _, testCase := range []struct {
+		name    string
+		mmap    bool
+		preload bool
+	}{
+		{"regular", false, false},
+		{"regular preloaded", false, true},
+		{"mmap", true, false},
+		{"mmap preloaded", true, true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := OpenReadOnlyFile(createMmapTestFile(t, "Hello World!"), testCase.mmap, testCase.preload)
+			if err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			defer file.Close()
+
+			data := make([]byte, 5)
+			if _, err := file.ReadAt(data, 6); err != nil {
+				t.Fatalf("Unexpected error: '%+v'", err)
+			}
+			if expect, got := "World", string(data); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+		})
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// The onChange callback (optional) is called every
+// time the file is modified, unless dieOnChange is true
 func StartFilesystemWatchProcess(
 	watcher *fsnotify.Watcher,
 	dieOnChange bool,
 	logger *logrus.Entry,
+	onChange func(),
 ) {
 	go func() {
 		for {
@@ -24,6 +27,9 @@ func StartFilesystemWatchProcess(
 						logger.Fatalln(message + ". Quitting because it may have corrupted data and 'dieOnInputChange' is 'true'.")
 					} else {
 						logger.Warnln(message + ", but 'dieOnInputChange' is 'false'. This could have unpredictable consequences.")
+						if onChange != nil {
+							onChange()
+						}
 					}
 				}
 			case err, ok := <-watcher.Errors:
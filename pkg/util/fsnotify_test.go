@@ -31,7 +31,7 @@ func TestStartFilesystemWatchProcess(t *testing.T) {
 			t.Fatalf("Unexpected error: '%+v'", err)
 		}
 
-		StartFilesystemWatchProcess(watcher, true, logger)
+		StartFilesystemWatchProcess(watcher, true, logger, nil)
 
 		if err := watcher.Add(file.Name()); err != nil {
 			t.Fatalf("Unexpected error: '%+v'", err)
@@ -70,4 +70,39 @@ func TestStartFilesystemWatchProcess(t *testing.T) {
 			t.Fatalf("Expected the process not to exit, got '%v' calls to Exit", dieCount)
 		}
 	})
+	t.Run("callback", func(t *testing.T) {
+		path := t.TempDir()
+
+		file, err := os.Create(path + "/testCallback")
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		defer file.Close()
+
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		defer watcher.Close()
+
+		changed := make(chan bool, 1)
+		StartFilesystemWatchProcess(watcher, false, logrus.NewEntry(logrus.StandardLogger()), func() {
+			select {
+			case changed <- true:
+			default:
+			}
+		})
+
+		if err := watcher.Add(file.Name()); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if _, err = file.WriteString("changed content"); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if !<-changed {
+			t.Fatalf("Expected the callback to be called")
+		}
+	})
 }
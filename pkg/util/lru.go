@@ -0,0 +1,113 @@
+package util
+
+import (
+	"container/list"
+	"sync"
+)
+
+// A least-recently-used cache, bounded by the total
+// (estimated) size of the values it contains
+type Lru struct {
+	maxSize int64
+	size    int64
+	list    *list.List
+	items   map[interface{}]*list.Element
+	lock    sync.Mutex
+	hits    uint64
+	misses  uint64
+}
+
+type LruStats struct {
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Size    int64  `json:"size"`
+	MaxSize int64  `json:"maxSize"`
+	Count   int    `json:"count"`
+}
+
+type lruItem struct {
+	key   interface{}
+	value interface{}
+	size  int64
+}
+
+func NewLru(maxSize int64) *Lru {
+	return &Lru{
+		maxSize: maxSize,
+		size:    0,
+		list:    list.New(),
+		items:   make(map[interface{}]*list.Element),
+		lock:    sync.Mutex{},
+	}
+}
+
+func (lru *Lru) Get(key interface{}) (interface{}, bool) {
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	element, exists := lru.items[key]
+	if !exists {
+		lru.misses++
+		return nil, false
+	}
+
+	lru.hits++
+	lru.list.MoveToFront(element)
+	return element.Value.(*lruItem).value, true
+}
+
+// Adds or replaces the given value, and evicts the least
+// recently used ones until the cache fits in it's maximum size.
+// Values bigger than the maximum size are never stored.
+func (lru *Lru) Add(key interface{}, value interface{}, size int64) {
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if element, exists := lru.items[key]; exists {
+		lru.removeElement(element)
+	}
+	if size > lru.maxSize {
+		return
+	}
+
+	element := lru.list.PushFront(&lruItem{
+		key:   key,
+		value: value,
+		size:  size,
+	})
+	lru.items[key] = element
+	lru.size += size
+
+	for lru.size > lru.maxSize {
+		lru.removeElement(lru.list.Back())
+	}
+}
+
+func (lru *Lru) removeElement(element *list.Element) {
+	item := lru.list.Remove(element).(*lruItem)
+	delete(lru.items, item.key)
+	lru.size -= item.size
+}
+
+// Removes all the values. The statistics are preserved.
+func (lru *Lru) Purge() {
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	lru.list.Init()
+	lru.items = make(map[interface{}]*list.Element)
+	lru.size = 0
+}
+
+func (lru *Lru) Stats() LruStats {
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	return LruStats{
+		Hits:    lru.hits,
+		Misses:  lru.misses,
+		Size:    lru.size,
+		MaxSize: lru.maxSize,
+		Count:   lru.list.Len(),
+	}
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestLru(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		lru := NewLru(100)
+		lru.Add("a", 1, 10)
+
+		value, exists := lru.Get("a")
+		if !exists {
+			t.Fatalf("Expected the value to exist")
+		}
+		if expect, got := 1, value; got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+
+		if _, exists := lru.Get("b"); exists {
+			t.Fatalf("Expected the value to not exist")
+		}
+
+		stats := lru.Stats()
+		if expect, got := uint64(1), stats.Hits; got != expect {
+			t.Fatalf("Expected %v hits, got %v", expect, got)
+		}
+		if expect, got := uint64(1), stats.Misses; got != expect {
+			t.Fatalf("Expected %v misses, got %v", expect, got)
+		}
+	})
+	t.Run("eviction", func(t *testing.T) {
+		lru := NewLru(30)
+		lru.Add("a", 1, 10)
+		lru.Add("b", 2, 10)
+		lru.Add("c", 3, 10)
+		lru.Get("a")
+		lru.Add("d", 4, 10)
+
+		if _, exists := lru.Get("b"); exists {
+			t.Fatalf("Expected the least recently used value to be evicted")
+		}
+		for _, key := range []string{"a", "c", "d"} {
+			if _, exists := lru.Get(key); !exists {
+				t.Fatalf("Expected the value '%v' to exist", key)
+			}
+		}
+		if expect, got := int64(30), lru.Stats().Size; got != expect {
+			t.Fatalf("Expected a size of %v, got %v", expect, got)
+		}
+	})
+	t.Run("replace", func(t *testing.T) {
+		lru := NewLru(30)
+		lru.Add("a", 1, 10)
+		lru.Add("a", 2, 20)
+
+		if value, _ := lru.Get("a"); value != 2 {
+			t.Fatalf("Expected '%v', got '%v'", 2, value)
+		}
+		if expect, got := int64(20), lru.Stats().Size; got != expect {
+			t.Fatalf("Expected a size of %v, got %v", expect, got)
+		}
+	})
+	t.Run("too big", func(t *testing.T) {
+		lru := NewLru(30)
+		lru.Add("a", 1, 31)
+
+		if _, exists := lru.Get("a"); exists {
+			t.Fatalf("Expected the value to not be stored")
+		}
+	})
+	t.Run("purge", func(t *testing.T) {
+		lru := NewLru(30)
+		lru.Add("a", 1, 10)
+		lru.Purge()
+
+		if _, exists := lru.Get("a"); exists {
+			t.Fatalf("Expected the value to be removed")
+		}
+		if expect, got := 0, lru.Stats().Count; got != expect {
+			t.Fatalf("Expected %v values, got %v", expect, got)
+		}
+	})
+}
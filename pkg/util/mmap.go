@@ -0,0 +1,125 @@
+package util
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// A read-only memory-mapped file. The reads become
+// simple slice accesses, and the OS manages the cache.
+type MmapFile struct {
+	// Held for reading while the mapped memory is accessed,
+	// so that it cannot be unmapped in the meantime
+	lock sync.RWMutex
+	data []byte
+}
+
+var ErrMmapClosed = errors.New("The memory-mapped file is closed")
+
+func OpenMmapFile(path string) (*MmapFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// Mapping an empty file is not allowed
+	if fileInfo.Size() == 0 {
+		return &MmapFile{data: []byte{}}, nil
+	}
+
+	data, err := mmap(file, fileInfo.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	return &MmapFile{data: data}, nil
+}
+
+func (file *MmapFile) Len() int64 {
+	file.lock.RLock()
+	defer file.lock.RUnlock()
+
+	return int64(len(file.data))
+}
+
+func (file *MmapFile) ReadAt(bytes []byte, offset int64) (int, error) {
+	file.lock.RLock()
+	defer file.lock.RUnlock()
+
+	if file.data == nil {
+		return 0, ErrMmapClosed
+	}
+	if offset < 0 {
+		return 0, errors.New("Cannot read a memory-mapped file at a negative offset")
+	}
+	if offset >= int64(len(file.data)) {
+		return 0, io.EOF
+	}
+
+	size := copy(bytes, file.data[offset:])
+	if size < len(bytes) {
+		return size, io.EOF
+	}
+
+	return size, nil
+}
+
+// Calls the callback with the data at the given offset, without copying it.
+// The data must not be modified, nor used after the callback returns,
+// because the file may then be unmapped.
+func (file *MmapFile) View(offset int64, length int, callback func(data []byte) error) error {
+	file.lock.RLock()
+	defer file.lock.RUnlock()
+
+	if file.data == nil {
+		return ErrMmapClosed
+	}
+	if offset < 0 || offset+int64(length) > int64(len(file.data)) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return callback(file.data[offset : offset+int64(length)])
+}
+
+// Reads one byte of each memory page, forcing
+// the OS to load the whole file in memory
+func (file *MmapFile) Preload() {
+	file.lock.RLock()
+	defer file.lock.RUnlock()
+
+	pageSize := os.Getpagesize()
+	sum := byte(0)
+	for i := 0; i < len(file.data); i += pageSize {
+		sum += file.data[i]
+	}
+	mmapPreloadSink = sum
+}
+
+// Prevents the compiler from optimizing-out the preloading loop
+var mmapPreloadSink byte
+
+// Waits for the pending reads before unmapping the file
+func (file *MmapFile) Close() error {
+	file.lock.Lock()
+	defer file.lock.Unlock()
+
+	if file.data == nil {
+		return nil
+	}
+
+	data := file.data
+	file.data = nil
+	if len(data) == 0 {
+		return nil
+	}
+
+	return munmap(data)
+}
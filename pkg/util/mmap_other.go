@@ -0,0 +1,17 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package util
+
+import (
+	"errors"
+	"os"
+)
+
+func mmap(file *os.File, size int64) ([]byte, error) {
+	return nil, errors.New("Memory-mapped files are not supported on this platform")
+}
+
+func munmap(data []byte) error {
+	return nil
+}
@@ -0,0 +1,126 @@
+package util
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func createMmapTestFile(t *testing.T, data string) string {
+	path := t.TempDir() + "/testMmap"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Unexpected error: '%+v'", err)
+	}
+
+	return path
+}
+
+func TestMmapFile(t *testing.T) {
+	t.Run("read", func(t *testing.T) {
+		file, err := OpenMmapFile(createMmapTestFile(t, "Hello World!"))
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		defer file.Close()
+
+		file.Preload()
+
+		data := make([]byte, 5)
+		if _, err := file.ReadAt(data, 6); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if expect, got := "World", string(data); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+
+		size, err := file.ReadAt(data, 10)
+		if err != io.EOF {
+			t.Fatalf("Expected EOF, got '%+v'", err)
+		}
+		if expect, got := "d!", string(data[:size]); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+	})
+	t.Run("view", func(t *testing.T) {
+		file, err := OpenMmapFile(createMmapTestFile(t, "Hello World!"))
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		defer file.Close()
+
+		err = file.View(0, 5, func(data []byte) error {
+			if expect, got := "Hello", string(data); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if err := file.View(10, 5, func(data []byte) error { return nil }); err == nil {
+			t.Fatalf("Expected an error, got nil")
+		}
+	})
+	t.Run("close while viewing", func(t *testing.T) {
+		file, err := OpenMmapFile(createMmapTestFile(t, "Hello World!"))
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		closed := make(chan error)
+		err = file.View(0, 5, func(data []byte) error {
+			go func() {
+				closed <- file.Close()
+			}()
+
+			select {
+			case err := <-closed:
+				t.Fatalf("Expected the file not to be closed while viewing it, got '%+v'", err)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			if expect, got := "Hello", string(data); got != expect {
+				t.Fatalf("Expected '%v', got '%v'", expect, got)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if err := <-closed; err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if err := file.View(0, 5, func(data []byte) error { return nil }); err != ErrMmapClosed {
+			t.Fatalf("Expected '%v', got '%+v'", ErrMmapClosed, err)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		file, err := OpenMmapFile(createMmapTestFile(t, ""))
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if expect, got := int64(0), file.Len(); got != expect {
+			t.Fatalf("Expected '%v', got '%v'", expect, got)
+		}
+		if err := file.Close(); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+	})
+	t.Run("closed", func(t *testing.T) {
+		file, err := OpenMmapFile(createMmapTestFile(t, "Hello World!"))
+		if err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+		if err := file.Close(); err != nil {
+			t.Fatalf("Unexpected error: '%+v'", err)
+		}
+
+		if _, err := file.ReadAt(make([]byte, 1), 0); err != ErrMmapClosed {
+			t.Fatalf("Expected '%v', got '%+v'", ErrMmapClosed, err)
+		}
+	})
+}